@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"github.com/QuangTung97/promo-readonly/config"
+	"github.com/QuangTung97/promo-readonly/internal/sampledata"
 	"github.com/QuangTung97/promo-readonly/model"
 	"github.com/QuangTung97/promo-readonly/pkg/cacheclient"
 	"github.com/QuangTung97/promo-readonly/pkg/dhash"
@@ -52,6 +53,7 @@ func processElements(numElements int, s *readonly.Server, durations *[]time.Dura
 				MerchantCode: getMerchantCode(randInt()),
 				TerminalCode: "TERMINAL01",
 				Phone:        getPhone(randInt()),
+				Amount:       "100000",
 			})
 		}
 
@@ -244,7 +246,7 @@ func migrateDataCommand() *cobra.Command {
 				migrateMerchants(ctx, repo)
 				migrateCustomers(ctx, repo)
 
-				return nil
+				return sampledata.MigrateCampaign(ctx)
 			})
 			if err != nil {
 				panic(err)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"github.com/QuangTung97/promo-readonly/config"
+	"github.com/QuangTung97/promo-readonly/internal/sampledata"
 	"github.com/QuangTung97/promo-readonly/model"
 	"github.com/QuangTung97/promo-readonly/pkg/cacheclient"
 	"github.com/QuangTung97/promo-readonly/pkg/dhash"
@@ -80,7 +81,19 @@ func startServer() {
 	provider := repository.NewProvider(db)
 	dhashProvider := dhash.NewProvider(memTable, client)
 
-	promoServer := readonly.NewServer(provider, dhashProvider, conf.DBOnly)
+	promoServer := readonly.NewServer(provider, dhashProvider, conf.DBOnly,
+		readonly.WithValidator(readonly.NewValidator(
+			readonly.WithMaxBatchSize(conf.Validation.MaxBatchSize),
+			readonly.WithMaxPhoneLength(conf.Validation.MaxPhoneLength),
+			readonly.WithMaxCodeLength(conf.Validation.MaxCodeLength),
+			readonly.WithMaxBankCodeLength(conf.Validation.MaxBankCodeLength),
+		)),
+		readonly.WithCheckStreamBatchWindow(conf.CheckStream.BatchWindow),
+		readonly.WithCheckStreamMaxBatchSize(conf.CheckStream.MaxBatchSize),
+		readonly.WithBusinessLocation(conf.MustLoadBusinessLocation()),
+		readonly.WithDHashFallbackToDB(conf.DHash.FallbackMaxConcurrent),
+		readonly.WithHashSelectLimits(conf.DHash.SelectChunkSize, conf.DHash.SelectMaxConcurrent),
+	)
 	promopb.RegisterPromoServiceServer(grpcServer, promoServer)
 
 	grpc_prometheus.EnableHandlingTimeHistogram()
@@ -232,7 +245,7 @@ func migrateDataCommand() *cobra.Command {
 					return err
 				}
 
-				return nil
+				return sampledata.MigrateCampaign(ctx)
 			})
 			if err != nil {
 				panic(err)
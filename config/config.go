@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -28,6 +29,35 @@ type ServerConfig struct {
 	GRPC ServerListen `mapstructure:"grpc"`
 }
 
+// CheckStreamConfig for micro-batching inputs of the CheckStream RPC
+type CheckStreamConfig struct {
+	BatchWindow  time.Duration `mapstructure:"batch_window"`
+	MaxBatchSize int           `mapstructure:"max_batch_size"`
+}
+
+// DHashConfig for the memcached layer
+type DHashConfig struct {
+	// FallbackMaxConcurrent is the max number of concurrent MySQL reads after lease retries are exhausted,
+	// zero means disabled
+	FallbackMaxConcurrent int `mapstructure:"fallback_max_concurrent"`
+
+	// SelectChunkSize is the max number of hash ranges in a single MySQL query, zero means default
+	SelectChunkSize int `mapstructure:"select_chunk_size"`
+
+	// SelectMaxConcurrent is the max number of those queries executed in parallel for a batch, zero means default
+	SelectMaxConcurrent int `mapstructure:"select_max_concurrent"`
+}
+
+// ValidationConfig for validating inputs of check requests
+type ValidationConfig struct {
+	MaxBatchSize int `mapstructure:"max_batch_size"`
+
+	// max lengths of input fields, zero means the lengths of database columns
+	MaxPhoneLength    int `mapstructure:"max_phone_length"`
+	MaxCodeLength     int `mapstructure:"max_code_length"`
+	MaxBankCodeLength int `mapstructure:"max_bank_code_length"`
+}
+
 // Config for app configuration
 type Config struct {
 	Server   ServerConfig   `mapstructure:"server"`
@@ -36,11 +66,27 @@ type Config struct {
 	Memcache MemcacheConfig `mapstructure:"memcache"`
 	Jaeger   JaegerConfig   `mapstructure:"jaeger"`
 
+	// BusinessTimezone for computing daily, weekly and monthly usage periods, empty means UTC
+	BusinessTimezone string `mapstructure:"business_timezone"`
+
+	DHash       DHashConfig       `mapstructure:"dhash"`
+	Validation  ValidationConfig  `mapstructure:"validation"`
+	CheckStream CheckStreamConfig `mapstructure:"check_stream"`
+
 	DBOnly      bool `mapstructure:"dbonly"`
 	NumThreads  int  `mapstructure:"num_threads"`
 	NumElements int  `mapstructure:"num_elements"`
 }
 
+// MustLoadBusinessLocation loads the location of BusinessTimezone
+func (c Config) MustLoadBusinessLocation() *time.Location {
+	loc, err := time.LoadLocation(c.BusinessTimezone)
+	if err != nil {
+		panic(err)
+	}
+	return loc
+}
+
 // Load config from config.yml
 func Load() Config {
 	vip := viper.New()
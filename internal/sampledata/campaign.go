@@ -0,0 +1,47 @@
+package sampledata
+
+import (
+	"context"
+	"github.com/QuangTung97/promo-readonly/model"
+	"github.com/QuangTung97/promo-readonly/pkg/util"
+	"github.com/QuangTung97/promo-readonly/repository"
+	"github.com/shopspring/decimal"
+	"time"
+)
+
+// MigrateCampaign upserts a sample campaign with voucher code VOUCHER01 and its benefit
+func MigrateCampaign(ctx context.Context) error {
+	repo := repository.NewCampaign()
+	err := repo.UpsertCampaign(ctx, model.Campaign{
+		ID:     1,
+		Name:   "CAMPAIGN01",
+		Status: model.CampaignStatusActive,
+		Type:   model.CampaignTypeMerchant,
+
+		VoucherHash: util.HashFunc("VOUCHER01"),
+		VoucherCode: "VOUCHER01",
+		StartTime:   time.Now(),
+		EndTime:     time.Now().AddDate(1, 0, 0),
+
+		CustomerUsageMax: 1000000,
+		PeriodTermType:   model.PeriodTermTypeCampaign,
+
+		AllMerchants: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	return repo.UpsertCampaignBenefits(ctx, []model.CampaignBenefit{
+		{
+			ID:         1,
+			CampaignID: 1,
+			StartTime:  time.Now(),
+			EndTime:    time.Now().AddDate(1, 0, 0),
+
+			TxnMinAmount:      decimal.Zero,
+			DiscountPercent:   decimal.NewFromInt(10),
+			MaxDiscountAmount: decimal.NewFromInt(50000),
+		},
+	})
+}
@@ -0,0 +1,7 @@
+ALTER TABLE `campaign_terminal`
+    DROP INDEX `idx_hash`,
+    DROP PRIMARY KEY,
+    ADD PRIMARY KEY (`campaign_id`, `hash`, `merchant_code`);
+
+ALTER TABLE `campaign_merchant`
+    DROP INDEX `idx_hash`;
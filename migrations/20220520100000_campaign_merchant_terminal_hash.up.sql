@@ -0,0 +1,7 @@
+ALTER TABLE `campaign_merchant`
+    ADD INDEX `idx_hash` (`hash`);
+
+ALTER TABLE `campaign_terminal`
+    DROP PRIMARY KEY,
+    ADD PRIMARY KEY (`campaign_id`, `hash`, `merchant_code`, `terminal_code`),
+    ADD INDEX `idx_hash` (`hash`);
@@ -0,0 +1,4 @@
+ALTER TABLE `campaign_bank`
+    DROP INDEX `idx_hash`,
+    DROP COLUMN `end_time`,
+    DROP COLUMN `start_time`;
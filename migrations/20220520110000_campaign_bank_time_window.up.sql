@@ -0,0 +1,4 @@
+ALTER TABLE `campaign_bank`
+    ADD COLUMN `start_time` DATETIME NULL AFTER `status`,
+    ADD COLUMN `end_time`   DATETIME NULL AFTER `start_time`,
+    ADD INDEX `idx_hash` (`hash`);
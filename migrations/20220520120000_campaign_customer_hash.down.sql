@@ -0,0 +1,2 @@
+ALTER TABLE `campaign_customer`
+    DROP INDEX `idx_hash`;
@@ -0,0 +1,2 @@
+ALTER TABLE `campaign_customer`
+    ADD INDEX `idx_hash` (`hash`);
@@ -0,0 +1,13 @@
+CREATE TABLE `campaign_customer_usage`
+(
+    `campaign_id` INT UNSIGNED NOT NULL,
+    `hash`        INT UNSIGNED NOT NULL,
+    `phone`       VARCHAR(20)  NOT NULL,
+
+    `usage_num`   INT UNSIGNED NOT NULL,
+
+    `created_at`  TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at`  TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (`campaign_id`, `hash`, `phone`)
+);
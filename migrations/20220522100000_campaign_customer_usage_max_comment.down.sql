@@ -0,0 +1,2 @@
+ALTER TABLE `campaign`
+    MODIFY COLUMN `customer_usage_max` INT UNSIGNED NOT NULL;
@@ -18,6 +18,12 @@ type BlacklistTerminal struct {
 	UpdatedAt time.Time `db:"updated_at"`
 }
 
+// NullBlacklistTerminal ...
+type NullBlacklistTerminal struct {
+	Valid    bool
+	Terminal BlacklistTerminal
+}
+
 // BlacklistTerminalStatus ...
 type BlacklistTerminalStatus int
 
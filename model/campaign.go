@@ -20,7 +20,7 @@ type Campaign struct {
 
 	BudgetMax        decimal.NullDecimal `db:"budget_max"`
 	CampaignUsageMax sql.NullInt64       `db:"campaign_usage_max"`
-	CustomerUsageMax int64               `db:"customer_usage_max"`
+	CustomerUsageMax int64               `db:"customer_usage_max"` // zero means unlimited
 
 	PeriodUsageType        PeriodUsageType `db:"period_usage_type"`
 	PeriodCustomerUsageMax sql.NullInt64   `db:"period_customer_usage_max"`
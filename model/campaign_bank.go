@@ -19,6 +19,12 @@ type CampaignBank struct {
 	UpdatedAt time.Time `db:"updated_at"`
 }
 
+// NullCampaignBank ...
+type NullCampaignBank struct {
+	Valid bool
+	Bank  CampaignBank
+}
+
 // CampaignBankStatus ...
 type CampaignBankStatus int
 
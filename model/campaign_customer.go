@@ -19,6 +19,12 @@ type CampaignCustomer struct {
 	UpdatedAt time.Time `db:"updated_at"`
 }
 
+// NullCampaignCustomer ...
+type NullCampaignCustomer struct {
+	Valid    bool
+	Customer CampaignCustomer
+}
+
 // CampaignCustomerStatus ...
 type CampaignCustomerStatus int
 
@@ -1 +1,15 @@
 package model
+
+import "time"
+
+// CampaignCustomerUsage is the lifetime usage of a campaign by a customer
+type CampaignCustomerUsage struct {
+	CampaignID int64  `db:"campaign_id"`
+	Hash       uint32 `db:"hash"`
+	Phone      string `db:"phone"`
+
+	UsageNum int64 `db:"usage_num"`
+
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
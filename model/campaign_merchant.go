@@ -20,6 +20,12 @@ type CampaignMerchant struct {
 	UpdatedAt time.Time `db:"updated_at"`
 }
 
+// NullCampaignMerchant ...
+type NullCampaignMerchant struct {
+	Valid    bool
+	Merchant CampaignMerchant
+}
+
 // CampaignMerchantStatus ...
 type CampaignMerchantStatus int
 
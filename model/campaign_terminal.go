@@ -20,6 +20,12 @@ type CampaignTerminal struct {
 	UpdatedAt time.Time `db:"updated_at"`
 }
 
+// NullCampaignTerminal ...
+type NullCampaignTerminal struct {
+	Valid    bool
+	Terminal CampaignTerminal
+}
+
 // CampaignTerminalStatus ...
 type CampaignTerminalStatus int
 
@@ -82,8 +82,13 @@ func (p *Pipeline) Get(key string) func() (dhash.GetOutput, error) {
 			return dhash.GetOutput{}, err
 		}
 		if resp.Type == memcache.MGetResponseTypeVA {
+			stale := resp.Flags&memcache.MGetFlagX != 0
+			if stale {
+				p.missCount++
+			}
 			return dhash.GetOutput{
 				Found: true,
+				Stale: stale,
 				Data:  resp.Data,
 			}, nil
 		}
@@ -105,7 +110,7 @@ func (p *Pipeline) LeaseGet(key string) func() (dhash.LeaseGetOutput, error) {
 		if err != nil {
 			return dhash.LeaseGetOutput{}, err
 		}
-		if resp.Type != memcache.MGetResponseTypeVA || resp.Flags&memcache.MGetFlagZ != 0 {
+		if resp.Type != memcache.MGetResponseTypeVA {
 			p.missCount++
 			return dhash.LeaseGetOutput{
 				Type: dhash.LeaseGetTypeRejected,
@@ -120,6 +125,22 @@ func (p *Pipeline) LeaseGet(key string) func() (dhash.LeaseGetOutput, error) {
 			}, nil
 		}
 
+		// stale data with the lease granted to another client
+		if resp.Flags&memcache.MGetFlagX != 0 {
+			p.missCount++
+			return dhash.LeaseGetOutput{
+				Type: dhash.LeaseGetTypeStale,
+				Data: resp.Data,
+			}, nil
+		}
+
+		if resp.Flags&memcache.MGetFlagZ != 0 {
+			p.missCount++
+			return dhash.LeaseGetOutput{
+				Type: dhash.LeaseGetTypeRejected,
+			}, nil
+		}
+
 		return dhash.LeaseGetOutput{
 			Type: dhash.LeaseGetTypeOK,
 			Data: resp.Data,
@@ -148,6 +169,18 @@ func (p *Pipeline) Delete(key string) func() error {
 	}
 }
 
+// Invalidate marks the key as stale, using the meta delete with the I flag
+func (p *Pipeline) Invalidate(key string, ttl uint32) func() error {
+	fn := p.pipe.MDel(key, memcache.MDelOptions{
+		I:   true,
+		TTL: ttl,
+	})
+	return func() error {
+		_, err := fn()
+		return err
+	}
+}
+
 // Finish ...
 func (p *Pipeline) Finish() {
 	atomic.AddUint64(&p.client.accessCount, p.accessCount)
@@ -90,3 +90,55 @@ func TestCacheClient__LeaseGet__OK(t *testing.T) {
 	assert.Equal(t, nil, err)
 	assert.Equal(t, dhash.LeaseGetTypeGranted, output.Type)
 }
+
+func TestCacheClient__Invalidate__Stale(t *testing.T) {
+	c := New("localhost:11211", 1)
+	truncateMemcached(c)
+
+	p := c.Pipeline()
+
+	output, err := p.LeaseGet("key01")()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, dhash.LeaseGetTypeGranted, output.Type)
+
+	err = p.LeaseSet("key01", []byte("some value"), output.LeaseID, 0)()
+	assert.Equal(t, nil, err)
+
+	// Invalidate
+	err = p.Invalidate("key01", 30)()
+	assert.Equal(t, nil, err)
+
+	// Get Stale
+	getOutput, err := p.Get("key01")()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, dhash.GetOutput{
+		Found: true,
+		Stale: true,
+		Data:  []byte("some value"),
+	}, getOutput)
+
+	// Lease Get First Time After Invalidate
+	output, err = p.LeaseGet("key01")()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, dhash.LeaseGetTypeGranted, output.Type)
+	leaseID := output.LeaseID
+
+	// Lease Get Second Time
+	output, err = p.LeaseGet("key01")()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, dhash.LeaseGetOutput{
+		Type: dhash.LeaseGetTypeStale,
+		Data: []byte("some value"),
+	}, output)
+
+	// Lease Set Clears Stale
+	err = p.LeaseSet("key01", []byte("new value"), leaseID, 0)()
+	assert.Equal(t, nil, err)
+
+	output, err = p.LeaseGet("key01")()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, dhash.LeaseGetOutput{
+		Type: dhash.LeaseGetTypeOK,
+		Data: []byte("new value"),
+	}, output)
+}
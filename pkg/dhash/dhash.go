@@ -3,13 +3,14 @@ package dhash
 import (
 	"context"
 	"errors"
+	"math/rand"
 	"sync/atomic"
 	"time"
 )
 
 //go:generate moq -out dhash_mocks_test.go . MemTable CacheClient CachePipeline HashDatabase StoreDatabase
 
-//go:generate moq -out dhash_mocks.go . Session Hash
+//go:generate moq -out dhash_mocks.go . Session Hash Store
 
 // MemTable for in memory hash table storing size log (with eviction)
 type MemTable interface {
@@ -30,11 +31,16 @@ const (
 
 	// LeaseGetTypeRejected when entry is not found and lease is not granted
 	LeaseGetTypeRejected LeaseGetType = 3
+
+	// LeaseGetTypeStale when entry is marked as stale and lease is granted to another client,
+	// the stale data is returned in Data
+	LeaseGetTypeStale LeaseGetType = 4
 )
 
 // GetOutput ...
 type GetOutput struct {
 	Found bool
+	Stale bool // entry is found but marked as stale by Invalidate
 	Data  []byte
 }
 
@@ -53,7 +59,9 @@ type CacheClient interface {
 
 type delayTimer interface {
 	Now() time.Time
-	Sleep(d time.Duration)
+
+	// Sleep returns early when ctx is done
+	Sleep(ctx context.Context, d time.Duration)
 }
 
 // CachePipeline for batching cache requests
@@ -62,6 +70,11 @@ type CachePipeline interface {
 	LeaseGet(key string) func() (LeaseGetOutput, error)
 	LeaseSet(key string, value []byte, leaseID uint64, ttl uint32) func() error
 	Delete(key string) func() error
+
+	// Invalidate marks the entry as stale instead of deleting it, ttl = 0 keeps the current expiration.
+	// The next LeaseGet is granted with type LeaseGetTypeGranted, other clients get LeaseGetTypeStale
+	Invalidate(key string, ttl uint32) func() error
+
 	Finish()
 }
 
@@ -85,6 +98,7 @@ type HashDatabase interface {
 
 // StoreDatabase backing store of simple kv cache
 type StoreDatabase interface {
+	// Get returns ErrNotFound for missing keys
 	Get(ctx context.Context, key string) func() ([]byte, error)
 }
 
@@ -95,23 +109,38 @@ type Provider interface {
 
 // Session can NOT be shared between goroutines
 type Session interface {
-	NewHash(namespace string, db HashDatabase) Hash
-	NewStore(fn StoreDatabase) Store
+	NewHash(namespace string, db HashDatabase, options ...HashOption) Hash
+	NewStore(db StoreDatabase, options ...StoreOption) Store
 	Finish()
 }
 
 // ErrLeaseNotGranted after multiple retries configured by WithWaitLeaseDurations
 var ErrLeaseNotGranted = errors.New("lease not granted after retries")
 
+// ErrNotFound is returned by StoreDatabase for missing keys, missing keys are also cached by Store
+var ErrNotFound = errors.New("not found")
+
+// ErrResizeSizeLogTooLarge when the new size log of Resize is greater than the limit set by WithMaxResizeSizeLog
+var ErrResizeSizeLogTooLarge = errors.New("resize size log too large")
+
 // Hash likes Redis hash map (but consistent)
 type Hash interface {
 	SelectEntries(ctx context.Context, hash uint32) func() ([]Entry, error)
+
+	// SelectMultiEntries returns the entries of each hash, in the order of the hashes
+	SelectMultiEntries(ctx context.Context, hashes []uint32) func() ([][]Entry, error)
 	InvalidateSizeLog(ctx context.Context) func() error
 	InvalidateEntry(ctx context.Context, sizeLog uint64, hash uint32) func() error
+
+	// Resize must be called after the size log of the backing database changed.
+	// Buckets are deleted in batches, returns ErrResizeSizeLogTooLarge without deleting anything
+	// when newSizeLog is greater than the limit set by WithMaxResizeSizeLog
+	Resize(ctx context.Context, oldSizeLog uint64, newSizeLog uint64) func() error
 }
 
 // Store for simple kv store (plain memcached key-value)
 type Store interface {
+	// Get returns ErrNotFound for keys not existed in the backing store
 	Get(ctx context.Context, key string) func() ([]byte, error)
 	Invalidate(ctx context.Context, key string) func() error
 }
@@ -123,8 +152,14 @@ func (t defaultDelayTimer) Now() time.Time {
 	return time.Now()
 }
 
-func (t defaultDelayTimer) Sleep(d time.Duration) {
-	time.Sleep(d)
+func (t defaultDelayTimer) Sleep(ctx context.Context, d time.Duration) {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	}
 }
 
 func newProviderImpl(mem MemTable, client CacheClient) *ProviderImpl {
@@ -132,9 +167,14 @@ func newProviderImpl(mem MemTable, client CacheClient) *ProviderImpl {
 		mem:    mem,
 		client: client,
 		timer:  defaultDelayTimer{},
+		random: defaultRandom,
 	}
 }
 
+func defaultRandom(n uint32) uint32 {
+	return uint32(rand.Int63n(int64(n)))
+}
+
 // NewProvider ...
 func NewProvider(mem MemTable, client CacheClient) *ProviderImpl {
 	return newProviderImpl(mem, client)
@@ -145,6 +185,7 @@ type ProviderImpl struct {
 	mem    MemTable
 	client CacheClient
 	timer  delayTimer
+	random func(n uint32) uint32 // returns a number in [0, n)
 
 	hashSizeLogAccessCount uint64
 	hashBucketAccessCount  uint64
@@ -178,7 +219,9 @@ func (p *ProviderImpl) HashBucketMissCount() uint64 {
 
 type delayedCall struct {
 	startedAt time.Time
+	ctx       context.Context
 	call      func()
+	abort     func(err error) // instead of call when ctx is cancelled or its deadline is reached
 }
 
 type sessionImpl struct {
@@ -207,13 +250,52 @@ func (s *sessionImpl) addNextCall(fn func()) {
 	s.nextCalls = append(s.nextCalls, fn)
 }
 
-func (s *sessionImpl) addDelayedCall(d time.Duration, call func()) {
+// addDelayedCall runs the call after the duration, or runs abort at the deadline of ctx if it is earlier
+func (s *sessionImpl) addDelayedCall(ctx context.Context, d time.Duration, call func(), abort func(err error)) {
+	startedAt := s.timer.Now().Add(d)
+	if deadline, ok := ctx.Deadline(); ok && deadline.Before(startedAt) {
+		startedAt = deadline
+	}
+
 	s.delayed.push(delayedCall{
-		startedAt: s.timer.Now().Add(d),
+		startedAt: startedAt,
+		ctx:       ctx,
 		call:      call,
+		abort:     abort,
 	})
 }
 
+// contextErr checks the deadline using the timer of the session
+func (s *sessionImpl) contextErr(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok && !s.timer.Now().Before(deadline) {
+		return context.DeadlineExceeded
+	}
+	return nil
+}
+
+func (s *sessionImpl) runDelayedCall(c delayedCall) {
+	if err := s.contextErr(c.ctx); err != nil {
+		c.abort(err)
+		return
+	}
+	c.call()
+}
+
+// acquireFallback returns false when WithFallbackToDB is not used or the limit is reached
+func (s *sessionImpl) acquireFallback() bool {
+	if s.options.fallbackLimiter == nil {
+		return false
+	}
+	return s.options.fallbackLimiter.tryAcquire()
+}
+
+func (s *sessionImpl) releaseFallback() {
+	s.options.fallbackLimiter.release()
+}
+
 func (s *sessionImpl) processAllCalls() {
 	for {
 		for len(s.nextCalls) > 0 {
@@ -233,19 +315,21 @@ func (s *sessionImpl) processAllCalls() {
 
 		now := s.timer.Now()
 
+		// the ctx of the earliest call is used for interrupting the sleep,
+		// calls with other contexts are aborted when they are popped
 		top := s.delayed.pop()
 		sleepDuration := top.startedAt.Sub(now)
-		s.timer.Sleep(sleepDuration)
+		s.timer.Sleep(top.ctx, sleepDuration)
 
 		s.pipeline.reset()
-		top.call()
+		s.runDelayedCall(top)
 
 		now = s.timer.Now().Add(200 * time.Microsecond) // earlier about 200 microseconds
 
 		// now >= startedAt <=> ~(now < startedAt)
 		for s.delayed.size() > 0 && !now.Before(s.delayed.top().startedAt) {
 			top := s.delayed.pop()
-			top.call()
+			s.runDelayedCall(top)
 		}
 	}
 }
@@ -262,9 +346,10 @@ func (p *ProviderImpl) NewSession(options ...SessionOption) Session {
 }
 
 // NewHash ...
-func (s *sessionImpl) NewHash(namespace string, db HashDatabase) Hash {
+func (s *sessionImpl) NewHash(namespace string, db HashDatabase, options ...HashOption) Hash {
 	return &hashImpl{
-		sess: s,
+		sess:    s,
+		options: newHashOptions(options...),
 
 		mem:        s.mem,
 		pipeline:   s.pipeline,
@@ -275,9 +360,10 @@ func (s *sessionImpl) NewHash(namespace string, db HashDatabase) Hash {
 }
 
 // NewStore ...
-func (s *sessionImpl) NewStore(db StoreDatabase) Store {
+func (s *sessionImpl) NewStore(db StoreDatabase, options ...StoreOption) Store {
 	return &storeImpl{
 		sess:     s,
+		options:  newStoreOptions(options...),
 		db:       db,
 		pipeline: s.pipeline,
 	}
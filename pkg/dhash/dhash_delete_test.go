@@ -1,8 +1,10 @@
 package dhash
 
 import (
+	"errors"
 	"github.com/stretchr/testify/assert"
 	"testing"
+	"time"
 )
 
 func TestHash_InvalidateSizeLog__Call_Delete_On_Client(t *testing.T) {
@@ -15,13 +17,267 @@ func TestHash_InvalidateSizeLog__Call_Delete_On_Client(t *testing.T) {
 	assert.Equal(t, "sample:size-log", h.pipe.DeleteCalls()[0].Key)
 }
 
-func TestHash_InvalidateEntry__Call_Delete_On_2_Buckets(t *testing.T) {
+func (h *hashTest) deleteKeys() []string {
+	var keys []string
+	for _, call := range h.pipe.DeleteCalls() {
+		keys = append(keys, call.Key)
+	}
+	return keys
+}
+
+func TestHash_InvalidateEntry__Call_Delete_On_Adjacent_Buckets(t *testing.T) {
+	h := newHashTest("sample")
+
+	err := h.hash.InvalidateEntry(newContext(), 4, 0xfc345678)()
+	assert.Equal(t, nil, err)
+
+	assert.Equal(t, []string{
+		"sample:3:e0000000",
+		"sample:4:f0000000",
+		"sample:5:f8000000",
+	}, h.deleteKeys())
+}
+
+func TestHash_InvalidateEntry__Size_Log_Zero__Without_Previous_Size_Log_Bucket(t *testing.T) {
+	h := newHashTest("sample")
+
+	err := h.hash.InvalidateEntry(newContext(), 0, 0xfc345678)()
+	assert.Equal(t, nil, err)
+
+	assert.Equal(t, []string{
+		"sample:0:00000000",
+		"sample:1:80000000",
+	}, h.deleteKeys())
+}
+
+func TestHash_InvalidateEntry__Max_Size_Log__Without_Next_Size_Log_Bucket(t *testing.T) {
 	h := newHashTest("sample")
 
+	err := h.hash.InvalidateEntry(newContext(), 32, 0xfc345678)()
+	assert.Equal(t, nil, err)
+
+	assert.Equal(t, []string{
+		"sample:31:fc345678",
+		"sample:32:fc345678",
+	}, h.deleteKeys())
+}
+
+func TestHash_InvalidateEntry__Stale_Buckets__Call_Invalidate_On_Adjacent_Buckets(t *testing.T) {
+	h := newHashTest("sample")
+	h.hash = h.sess.NewHash("sample", h.db, WithStaleBuckets(30*time.Second))
+
 	err := h.hash.InvalidateEntry(newContext(), 4, 0xfc345678)()
 	assert.Equal(t, nil, err)
 
+	assert.Equal(t, 0, len(h.pipe.DeleteCalls()))
+	assert.Equal(t, 3, len(h.pipe.InvalidateCalls()))
+	assert.Equal(t, "sample:3:e0000000", h.pipe.InvalidateCalls()[0].Key)
+	assert.Equal(t, "sample:4:f0000000", h.pipe.InvalidateCalls()[1].Key)
+	assert.Equal(t, "sample:5:f8000000", h.pipe.InvalidateCalls()[2].Key)
+	assert.Equal(t, uint32(30), h.pipe.InvalidateCalls()[0].TTL)
+}
+
+func TestHash_Resize__Same_Size_Log__Do_Nothing(t *testing.T) {
+	h := newHashTest("sample")
+
+	err := h.hash.Resize(newContext(), 3, 3)()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 0, len(h.pipe.DeleteCalls()))
+}
+
+func TestHash_Resize__Grow__Delete_All_Buckets_Of_Next_Size_Log__Then_Size_Log(t *testing.T) {
+	h := newHashTest("sample")
+
+	err := h.hash.Resize(newContext(), 1, 2)()
+	assert.Equal(t, nil, err)
+
+	assert.Equal(t, []string{
+		"sample:3:00000000",
+		"sample:3:20000000",
+		"sample:3:40000000",
+		"sample:3:60000000",
+		"sample:3:80000000",
+		"sample:3:a0000000",
+		"sample:3:c0000000",
+		"sample:3:e0000000",
+		"sample:size-log",
+	}, h.deleteKeys())
+}
+
+func TestHash_Resize__Shrink__Delete_All_Buckets_Of_Previous_Size_Log__Then_Size_Log(t *testing.T) {
+	h := newHashTest("sample")
+
+	err := h.hash.Resize(newContext(), 3, 2)()
+	assert.Equal(t, nil, err)
+
+	assert.Equal(t, []string{
+		"sample:1:00000000",
+		"sample:1:80000000",
+		"sample:size-log",
+	}, h.deleteKeys())
+}
+
+func TestHash_Resize__Shrink_To_Zero(t *testing.T) {
+	h := newHashTest("sample")
+
+	err := h.hash.Resize(newContext(), 1, 0)()
+	assert.Equal(t, nil, err)
+
+	assert.Equal(t, []string{
+		"sample:size-log",
+	}, h.deleteKeys())
+}
+
+func TestHash_Resize__Size_Log_Delete_After_Buckets_Deleted(t *testing.T) {
+	h := newHashTest("sample")
+
+	fn := h.hash.Resize(newContext(), 3, 2)
+	assert.Equal(t, []string{
+		"sample:1:00000000",
+		"sample:1:80000000",
+	}, h.deleteKeys())
+
+	err := fn()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "sample:size-log", h.deleteKeys()[2])
+}
+
+func TestHash_Resize__Delete_Bucket_Error__Not_Delete_Size_Log(t *testing.T) {
+	h := newHashTest("sample")
+	h.pipe.DeleteFunc = func(key string) func() error {
+		return func() error { return errors.New("delete error") }
+	}
+
+	err := h.hash.Resize(newContext(), 3, 2)()
+	assert.Equal(t, errors.New("delete error"), err)
 	assert.Equal(t, 2, len(h.pipe.DeleteCalls()))
-	assert.Equal(t, "sample:3:e0000000", h.pipe.DeleteCalls()[0].Key)
-	assert.Equal(t, "sample:4:f0000000", h.pipe.DeleteCalls()[1].Key)
+}
+
+func TestHash_Resize__Stale_Buckets__Still_Delete_Buckets(t *testing.T) {
+	h := newHashTest("sample")
+	h.hash = h.sess.NewHash("sample", h.db, WithStaleBuckets(30*time.Second))
+
+	err := h.hash.Resize(newContext(), 3, 2)()
+	assert.Equal(t, nil, err)
+
+	assert.Equal(t, 0, len(h.pipe.InvalidateCalls()))
+	assert.Equal(t, []string{
+		"sample:1:00000000",
+		"sample:1:80000000",
+		"sample:size-log",
+	}, h.deleteKeys())
+}
+
+func TestHash_Resize__Near_Max_Size_Log__Returns_Error_Without_Deleting(t *testing.T) {
+	h := newHashTest("sample")
+
+	err := h.hash.Resize(newContext(), 30, 31)()
+	assert.Equal(t, ErrResizeSizeLogTooLarge, err)
+
+	err = h.hash.Resize(newContext(), 32, 31)()
+	assert.Equal(t, ErrResizeSizeLogTooLarge, err)
+
+	assert.Equal(t, 0, len(h.pipe.DeleteCalls()))
+}
+
+func TestHash_Resize__Shrink_From_Max_Size_Log__Allowed(t *testing.T) {
+	h := newHashTest("sample")
+
+	err := h.hash.Resize(newContext(), 32, 3)()
+	assert.Equal(t, nil, err)
+
+	assert.Equal(t, 4+8+16+1, len(h.pipe.DeleteCalls()))
+	assert.Equal(t, "sample:size-log", h.deleteKeys()[4+8+16])
+}
+
+func TestHash_Resize__With_Max_Resize_Size_Log(t *testing.T) {
+	h := newHashTest("sample")
+	h.hash = h.sess.NewHash("sample", h.db, WithMaxResizeSizeLog(2))
+
+	err := h.hash.Resize(newContext(), 2, 3)()
+	assert.Equal(t, ErrResizeSizeLogTooLarge, err)
+
+	err = h.hash.Resize(newContext(), 3, 2)()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 3, len(h.pipe.DeleteCalls()))
+}
+
+func TestHash_Resize__Delete_Buckets_In_Batches(t *testing.T) {
+	h := newHashTest("sample")
+	h.hash = h.sess.NewHash("sample", h.db, WithResizeBatchSize(3))
+
+	var waited []int
+	h.pipe.DeleteFunc = func(key string) func() error {
+		index := len(h.pipe.DeleteCalls()) - 1
+		return func() error {
+			waited = append(waited, index)
+			return nil
+		}
+	}
+
+	fn := h.hash.Resize(newContext(), 1, 2)
+	assert.Equal(t, []string{
+		"sample:3:00000000",
+		"sample:3:20000000",
+		"sample:3:40000000",
+	}, h.deleteKeys())
+
+	err := fn()
+	assert.Equal(t, nil, err)
+
+	assert.Equal(t, []string{
+		"sample:3:00000000",
+		"sample:3:20000000",
+		"sample:3:40000000",
+		"sample:3:60000000",
+		"sample:3:80000000",
+		"sample:3:a0000000",
+		"sample:3:c0000000",
+		"sample:3:e0000000",
+		"sample:size-log",
+	}, h.deleteKeys())
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8}, waited)
+}
+
+func TestHash_Resize__Batch_Error__Stop_Deleting(t *testing.T) {
+	h := newHashTest("sample")
+	h.hash = h.sess.NewHash("sample", h.db, WithResizeBatchSize(3))
+	h.pipe.DeleteFunc = func(key string) func() error {
+		return func() error { return errors.New("delete error") }
+	}
+
+	err := h.hash.Resize(newContext(), 1, 2)()
+	assert.Equal(t, errors.New("delete error"), err)
+	assert.Equal(t, 3, len(h.pipe.DeleteCalls()))
+}
+
+func TestInvalidateAfterWrite__Size_Log_Not_Changed(t *testing.T) {
+	h := newHashTest("sample")
+
+	err := InvalidateAfterWrite(newContext(), h.hash, 2, 2, []uint32{0xfc345678, 0x12345678})()
+	assert.Equal(t, nil, err)
+
+	assert.Equal(t, []string{
+		"sample:1:80000000",
+		"sample:2:c0000000",
+		"sample:3:e0000000",
+		"sample:1:00000000",
+		"sample:2:00000000",
+		"sample:3:00000000",
+	}, h.deleteKeys())
+}
+
+func TestInvalidateAfterWrite__Size_Log_Changed__Invalidate_Entries__Then_Resize(t *testing.T) {
+	h := newHashTest("sample")
+
+	err := InvalidateAfterWrite(newContext(), h.hash, 2, 1, []uint32{0xfc345678})()
+	assert.Equal(t, nil, err)
+
+	assert.Equal(t, []string{
+		"sample:1:80000000",
+		"sample:2:c0000000",
+		"sample:3:e0000000",
+		"sample:0:00000000",
+		"sample:size-log",
+	}, h.deleteKeys())
 }
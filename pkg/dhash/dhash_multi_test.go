@@ -0,0 +1,263 @@
+package dhash
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func (h *hashTest) stubClientGetByKey(buckets map[string][]Entry) {
+	h.pipe.GetFunc = func(key string) func() (GetOutput, error) {
+		return func() (GetOutput, error) {
+			entries, existed := buckets[key]
+			if !existed {
+				return GetOutput{}, nil
+			}
+			return GetOutput{
+				Found: true,
+				Data:  marshalEntries(entries),
+			}, nil
+		}
+	}
+}
+
+func (h *hashTest) stubLeaseGetByKey(outputs map[string]LeaseGetOutput) {
+	h.pipe.LeaseGetFunc = func(key string) func() (LeaseGetOutput, error) {
+		return func() (LeaseGetOutput, error) {
+			return outputs[key], nil
+		}
+	}
+}
+
+func (h *hashTest) getKeys() []string {
+	var keys []string
+	for _, call := range h.pipe.GetCalls() {
+		keys = append(keys, call.Key)
+	}
+	return keys
+}
+
+func (h *hashTest) leaseGetKeys() []string {
+	var keys []string
+	for _, call := range h.pipe.LeaseGetCalls() {
+		keys = append(keys, call.Key)
+	}
+	return keys
+}
+
+func TestSelectMultiEntries__Empty_Hashes__Do_Nothing(t *testing.T) {
+	h := newHashTest("sample")
+
+	results, err := h.hash.SelectMultiEntries(newContext(), nil)()
+	assert.Equal(t, nil, err)
+	assert.Nil(t, results)
+
+	assert.Equal(t, 0, len(h.mem.GetNumCalls()))
+	assert.Equal(t, 0, len(h.pipe.LeaseGetCalls()))
+	assert.Equal(t, 0, len(h.pipe.GetCalls()))
+}
+
+func TestSelectMultiEntries__Same_Bucket__Get_Bucket_Once(t *testing.T) {
+	h := newHashTest("sample")
+
+	h.stubGetNum(5)
+	h.stubLeaseGetOK("5")
+	h.stubClientGetByKey(map[string][]Entry{
+		"sample:5:f8000000": {
+			newEntry(0xfc345678, 1),
+			newEntry(0xf9000000, 2),
+			newEntry(0xfa000000, 3),
+		},
+	})
+
+	results, err := h.hash.SelectMultiEntries(newContext(), []uint32{
+		0xfc345678, 0xf9000000, 0xfc345678,
+	})()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, [][]Entry{
+		{newEntry(0xfc345678, 1)},
+		{newEntry(0xf9000000, 2)},
+		{newEntry(0xfc345678, 1)},
+	}, results)
+
+	assert.Equal(t, []string{"sample:size-log"}, h.leaseGetKeys())
+	assert.Equal(t, []string{
+		"sample:4:f0000000",
+		"sample:5:f8000000",
+		"sample:6:fc000000",
+		"sample:6:f8000000",
+	}, h.getKeys())
+
+	h.finish()
+	assert.Equal(t, uint64(1), h.provider.HashSizeLogAccessCount())
+	assert.Equal(t, uint64(1), h.provider.HashBucketAccessCount())
+	assert.Equal(t, uint64(0), h.provider.HashBucketMissCount())
+}
+
+func TestSelectMultiEntries__Different_Buckets__Returns_In_Order_Of_Hashes(t *testing.T) {
+	h := newHashTest("sample")
+
+	h.stubGetNum(5)
+	h.stubLeaseGetOK("5")
+	h.stubClientGetByKey(map[string][]Entry{
+		"sample:5:f8000000": {newEntry(0xfc345678, 1)},
+		"sample:4:10000000": {newEntry(0x10000000, 2)},
+	})
+
+	results, err := h.hash.SelectMultiEntries(newContext(), []uint32{
+		0x10000000, 0xfc345678, 0x10000001,
+	})()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, [][]Entry{
+		{newEntry(0x10000000, 2)},
+		{newEntry(0xfc345678, 1)},
+		nil,
+	}, results)
+
+	assert.Equal(t, []string{"sample:size-log"}, h.leaseGetKeys())
+	assert.Equal(t, 6, len(h.pipe.GetCalls()))
+}
+
+func TestSelectMultiEntries__Next_Size_Log__Only_One_Half_Found__Lease_Get(t *testing.T) {
+	h := newHashTest("sample")
+
+	h.stubGetNum(5)
+	h.stubLeaseGetByKey(map[string]LeaseGetOutput{
+		"sample:size-log": {Type: LeaseGetTypeOK, Data: []byte("5")},
+		"sample:5:f8000000": {
+			Type: LeaseGetTypeOK,
+			Data: marshalEntries([]Entry{newEntry(0xfc345678, 1), newEntry(0xf9000000, 2)}),
+		},
+	})
+	h.stubClientGetByKey(map[string][]Entry{
+		"sample:6:fc000000": {newEntry(0xfc345678, 1)},
+	})
+
+	results, err := h.hash.SelectMultiEntries(newContext(), []uint32{0xfc345678, 0xf9000000})()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, [][]Entry{
+		{newEntry(0xfc345678, 1)},
+		{newEntry(0xf9000000, 2)},
+	}, results)
+
+	assert.Equal(t, []string{"sample:size-log", "sample:5:f8000000"}, h.leaseGetKeys())
+}
+
+func TestSelectMultiEntries__Next_Size_Log__Both_Halves_Found(t *testing.T) {
+	h := newHashTest("sample")
+
+	h.stubGetNum(5)
+	h.stubLeaseGetOK("5")
+	h.stubClientGetByKey(map[string][]Entry{
+		"sample:6:fc000000": {newEntry(0xfc345678, 1)},
+		"sample:6:f8000000": {newEntry(0xf9000000, 2)},
+	})
+
+	results, err := h.hash.SelectMultiEntries(newContext(), []uint32{0xfc345678, 0xf9000000})()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, [][]Entry{
+		{newEntry(0xfc345678, 1)},
+		{newEntry(0xf9000000, 2)},
+	}, results)
+
+	assert.Equal(t, []string{"sample:size-log"}, h.leaseGetKeys())
+}
+
+func TestSelectMultiEntries__Buckets_Not_Found__Merge_Adjacent_Buckets_In_DB_Query(t *testing.T) {
+	h := newHashTest("sample")
+
+	h.stubGetNum(5)
+	h.stubLeaseGetByKey(map[string]LeaseGetOutput{
+		"sample:size-log":   {Type: LeaseGetTypeOK, Data: []byte("5")},
+		"sample:5:f8000000": newLeaseGetGranted(11),
+		"sample:5:f0000000": newLeaseGetGranted(22),
+		"sample:5:10000000": newLeaseGetGranted(33),
+	})
+	h.stubClientGetByKey(map[string][]Entry{})
+	h.stubDBSelectEntries([]Entry{
+		newEntry(0xf0000001, 1),
+		newEntry(0xf4000000, 2),
+		newEntry(0xfc345678, 3),
+	})
+
+	results, err := h.hash.SelectMultiEntries(newContext(), []uint32{
+		0xfc345678, 0x10000000, 0xf0000001,
+	})()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, [][]Entry{
+		{newEntry(0xfc345678, 3)},
+		nil,
+		{newEntry(0xf0000001, 1)},
+	}, results)
+
+	assert.Equal(t, 2, len(h.db.SelectEntriesCalls()))
+	assert.Equal(t, uint32(0x10000000), h.db.SelectEntriesCalls()[0].HashBegin)
+	assert.Equal(t, newNullUint32(0x18000000), h.db.SelectEntriesCalls()[0].HashEnd)
+	assert.Equal(t, uint32(0xf0000000), h.db.SelectEntriesCalls()[1].HashBegin)
+	assert.Equal(t, NullUint32{}, h.db.SelectEntriesCalls()[1].HashEnd)
+
+	calls := h.pipe.LeaseSetCalls()
+	assert.Equal(t, 3, len(calls))
+
+	assert.Equal(t, "sample:5:10000000", calls[0].Key)
+	assert.Equal(t, marshalEntries(nil), calls[0].Value)
+	assert.Equal(t, uint64(33), calls[0].LeaseID)
+
+	assert.Equal(t, "sample:5:f0000000", calls[1].Key)
+	assert.Equal(t, marshalEntries([]Entry{newEntry(0xf0000001, 1), newEntry(0xf4000000, 2)}), calls[1].Value)
+	assert.Equal(t, uint64(22), calls[1].LeaseID)
+
+	assert.Equal(t, "sample:5:f8000000", calls[2].Key)
+	assert.Equal(t, marshalEntries([]Entry{newEntry(0xfc345678, 3)}), calls[2].Value)
+	assert.Equal(t, uint64(11), calls[2].LeaseID)
+
+	h.finish()
+	assert.Equal(t, uint64(6), h.provider.HashBucketAccessCount())
+	assert.Equal(t, uint64(6), h.provider.HashBucketMissCount())
+}
+
+func TestSelectMultiEntries__Bucket_Lease_Rejected_All_Times__Returns_Error(t *testing.T) {
+	h := newHashTest("sample")
+
+	h.stubGetNum(5)
+	h.stubLeaseGetByKey(map[string]LeaseGetOutput{
+		"sample:size-log":   {Type: LeaseGetTypeOK, Data: []byte("5")},
+		"sample:5:f8000000": newLeaseGetRejected(),
+	})
+	h.stubClientGetByKey(map[string][]Entry{
+		"sample:5:10000000": {newEntry(0x10000000, 1)},
+	})
+
+	results, err := h.hash.SelectMultiEntries(newContext(), []uint32{0x10000000, 0xfc345678})()
+	assert.Equal(t, ErrLeaseNotGranted, err)
+	assert.Nil(t, results)
+}
+
+func TestSelectMultiEntries__Size_Log_Changed__Regroup_Buckets(t *testing.T) {
+	h := newHashTest("sample")
+
+	h.stubGetNum(5)
+	h.stubLeaseGetOK("0")
+	h.stubClientGetByKey(map[string][]Entry{
+		"sample:0:00000000": {newEntry(0x10000000, 1), newEntry(0xfc345678, 2)},
+	})
+
+	results, err := h.hash.SelectMultiEntries(newContext(), []uint32{0xfc345678, 0x10000000})()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, [][]Entry{
+		{newEntry(0xfc345678, 2)},
+		{newEntry(0x10000000, 1)},
+	}, results)
+
+	assert.Equal(t, []string{
+		"sample:4:f0000000",
+		"sample:5:f8000000",
+		"sample:6:fc000000",
+		"sample:4:10000000",
+		"sample:5:10000000",
+		"sample:6:10000000",
+
+		"sample:0:00000000",
+		"sample:1:80000000",
+		"sample:1:00000000",
+	}, h.getKeys())
+}
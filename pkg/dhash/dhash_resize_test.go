@@ -0,0 +1,394 @@
+package dhash
+
+import (
+	"context"
+	"encoding/binary"
+	"github.com/stretchr/testify/assert"
+	"math/bits"
+	"math/rand"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeCacheItem struct {
+	data   []byte
+	cas    uint64
+	leased bool
+	stale  bool
+}
+
+// fakeCache is an in-memory cache with the lease semantics of memcached
+type fakeCache struct {
+	mut     sync.Mutex
+	items   map[string]*fakeCacheItem
+	lastCAS uint64
+}
+
+func newFakeCache() *fakeCache {
+	return &fakeCache{
+		items: map[string]*fakeCacheItem{},
+	}
+}
+
+func (c *fakeCache) Pipeline() CachePipeline {
+	return &fakeCachePipeline{cache: c}
+}
+
+type fakeCachePipeline struct {
+	cache *fakeCache
+}
+
+func (p *fakeCachePipeline) Get(key string) func() (GetOutput, error) {
+	c := p.cache
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	item, existed := c.items[key]
+	if !existed || item.leased {
+		return func() (GetOutput, error) { return GetOutput{}, nil }
+	}
+	output := GetOutput{Found: true, Stale: item.stale, Data: item.data}
+	return func() (GetOutput, error) { return output, nil }
+}
+
+func (p *fakeCachePipeline) LeaseGet(key string) func() (LeaseGetOutput, error) {
+	c := p.cache
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	var output LeaseGetOutput
+
+	item, existed := c.items[key]
+	if !existed {
+		c.lastCAS++
+		c.items[key] = &fakeCacheItem{cas: c.lastCAS, leased: true}
+		output = LeaseGetOutput{Type: LeaseGetTypeGranted, LeaseID: c.lastCAS}
+	} else if item.stale && item.leased {
+		output = LeaseGetOutput{Type: LeaseGetTypeStale, Data: item.data}
+	} else if item.stale {
+		c.lastCAS++
+		item.cas = c.lastCAS
+		item.leased = true
+		output = LeaseGetOutput{Type: LeaseGetTypeGranted, LeaseID: c.lastCAS}
+	} else if item.leased {
+		output = LeaseGetOutput{Type: LeaseGetTypeRejected}
+	} else {
+		output = LeaseGetOutput{Type: LeaseGetTypeOK, Data: item.data}
+	}
+	return func() (LeaseGetOutput, error) { return output, nil }
+}
+
+func (p *fakeCachePipeline) LeaseSet(key string, value []byte, leaseID uint64, _ uint32) func() error {
+	c := p.cache
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	item, existed := c.items[key]
+	if existed && item.leased && item.cas == leaseID {
+		item.data = value
+		item.leased = false
+		item.stale = false
+	}
+	return func() error { return nil }
+}
+
+func (p *fakeCachePipeline) Delete(key string) func() error {
+	c := p.cache
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	delete(c.items, key)
+	return func() error { return nil }
+}
+
+func (p *fakeCachePipeline) Invalidate(key string, _ uint32) func() error {
+	c := p.cache
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	item, existed := c.items[key]
+	if existed {
+		// a lease granted before invalidating can not be used for setting
+		c.lastCAS++
+		item.cas = c.lastCAS
+		item.leased = false
+		item.stale = true
+	}
+	return func() error { return nil }
+}
+
+func (p *fakeCachePipeline) Finish() {
+}
+
+type fakeMemTable struct {
+	mut  sync.Mutex
+	nums map[string]uint64
+}
+
+func (m *fakeMemTable) GetNum(key string) (uint64, bool) {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+	num, ok := m.nums[key]
+	return num, ok
+}
+
+func (m *fakeMemTable) SetNum(key string, num uint64) {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+	m.nums[key] = num
+}
+
+// fakeHashDB stores a version for each hash, an entry exists when its version is odd
+type fakeHashDB struct {
+	mut      sync.Mutex
+	versions map[uint32]uint64
+}
+
+func fakeSizeLog(count int) uint64 {
+	if count <= 1 {
+		return 0
+	}
+	return 64 - uint64(bits.LeadingZeros64(uint64(count-1)))
+}
+
+func (d *fakeHashDB) sizeLogLocked() uint64 {
+	count := 0
+	for _, version := range d.versions {
+		if version%2 == 1 {
+			count++
+		}
+	}
+	return fakeSizeLog(count)
+}
+
+func (d *fakeHashDB) GetSizeLog(_ context.Context) func() (uint64, error) {
+	return func() (uint64, error) {
+		d.mut.Lock()
+		defer d.mut.Unlock()
+		return d.sizeLogLocked(), nil
+	}
+}
+
+func (d *fakeHashDB) SelectEntries(_ context.Context, hashBegin uint32, hashEnd NullUint32) func() ([]Entry, error) {
+	return func() ([]Entry, error) {
+		d.mut.Lock()
+		defer d.mut.Unlock()
+
+		var result []Entry
+		for hash, version := range d.versions {
+			if version%2 == 0 || hash < hashBegin || (hashEnd.Valid && hash >= hashEnd.Num) {
+				continue
+			}
+			data := make([]byte, 8)
+			binary.LittleEndian.PutUint64(data, version)
+			result = append(result, Entry{Hash: hash, Data: data})
+		}
+		return result, nil
+	}
+}
+
+// update changes the existence of the entry and returns the size logs before and after
+func (d *fakeHashDB) update(hash uint32) (version uint64, oldSizeLog uint64, newSizeLog uint64) {
+	d.mut.Lock()
+	defer d.mut.Unlock()
+
+	oldSizeLog = d.sizeLogLocked()
+	d.versions[hash]++
+	return d.versions[hash], oldSizeLog, d.sizeLogLocked()
+}
+
+func (d *fakeHashDB) getVersion(hash uint32) uint64 {
+	d.mut.Lock()
+	defer d.mut.Unlock()
+	return d.versions[hash]
+}
+
+type completedVersions struct {
+	mut      sync.Mutex
+	versions map[uint32]uint64
+}
+
+func (c *completedVersions) get(hash uint32) uint64 {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	return c.versions[hash]
+}
+
+func (c *completedVersions) set(hash uint32, version uint64) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	c.versions[hash] = version
+}
+
+type resizeTest struct {
+	provider  *ProviderImpl
+	db        *fakeHashDB
+	completed *completedVersions
+	hashes    []uint32
+
+	hashOptions []HashOption
+	staleReads  bool // stale entries are allowed while writing
+}
+
+func newResizeTest(seed int64, numHashes int) *resizeTest {
+	r := rand.New(rand.NewSource(seed))
+
+	hashes := make([]uint32, 0, numHashes)
+	for i := 0; i < numHashes; i++ {
+		hashes = append(hashes, r.Uint32())
+	}
+
+	return &resizeTest{
+		provider:  NewProvider(&fakeMemTable{nums: map[string]uint64{}}, newFakeCache()),
+		db:        &fakeHashDB{versions: map[uint32]uint64{}},
+		completed: &completedVersions{versions: map[uint32]uint64{}},
+		hashes:    hashes,
+	}
+}
+
+func (r *resizeTest) newSession() Session {
+	return r.provider.NewSession(WithWaitLeaseDurations([]time.Duration{
+		100 * time.Microsecond,
+		200 * time.Microsecond,
+		500 * time.Microsecond,
+		time.Millisecond,
+	}))
+}
+
+// runWriter grows and shrinks the number of entries by phases, so the size log goes up and down
+func (r *resizeTest) runWriter(t *testing.T, rnd *rand.Rand, numOps int) {
+	grow := true
+	for i := 0; i < numOps; i++ {
+		var candidates []uint32
+		for _, hash := range r.hashes {
+			present := r.db.getVersion(hash)%2 == 1
+			if present != grow {
+				candidates = append(candidates, hash)
+			}
+		}
+		if len(candidates) == 0 {
+			grow = !grow
+			continue
+		}
+		if rnd.Intn(8) == 0 {
+			candidates = r.hashes
+		}
+
+		hash := candidates[rnd.Intn(len(candidates))]
+		version, oldSizeLog, newSizeLog := r.db.update(hash)
+
+		sess := r.newSession()
+		err := InvalidateAfterWrite(newContext(), sess.NewHash("sample", r.db, r.hashOptions...), oldSizeLog, newSizeLog, []uint32{hash})()
+		sess.Finish()
+		assert.Equal(t, nil, err)
+
+		r.completed.set(hash, version)
+
+		// let readers fill buckets between some of the writes
+		for k := rnd.Intn(4); k > 0; k-- {
+			runtime.Gosched()
+		}
+	}
+}
+
+// checkRead asserts the read result is not older than the writes completed before the read started
+func (r *resizeTest) checkRead(t *testing.T, hash uint32, writing bool) bool {
+	completed := r.completed.get(hash)
+
+	sess := r.newSession()
+	bucketEntries, err := sess.NewHash("sample", r.db, r.hashOptions...).SelectEntries(newContext(), hash)()
+	sess.Finish()
+
+	if err == ErrLeaseNotGranted {
+		return true
+	}
+	if !assert.Equal(t, nil, err) {
+		return false
+	}
+	if writing && r.staleReads {
+		return true
+	}
+
+	// entries of the same bucket can be returned
+	var entries []Entry
+	for _, entry := range bucketEntries {
+		if entry.Hash == hash {
+			entries = append(entries, entry)
+		}
+	}
+
+	if len(entries) == 0 {
+		if completed%2 == 1 {
+			// entry existed when the read started, must be deleted by a later write
+			return assert.Greater(t, r.db.getVersion(hash), completed, "stale not found of hash %08x", hash)
+		}
+		return true
+	}
+
+	if !assert.Equal(t, 1, len(entries)) {
+		return false
+	}
+	version := binary.LittleEndian.Uint64(entries[0].Data)
+	return assert.GreaterOrEqual(t, version, completed, "stale entry of hash %08x", hash)
+}
+
+func (r *resizeTest) run(t *testing.T, seed int64, numReaders int, numOps int) {
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+
+	for i := 0; i < numReaders; i++ {
+		rnd := rand.New(rand.NewSource(seed + int64(i) + 1))
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+
+				hash := r.hashes[rnd.Intn(len(r.hashes))]
+				if !r.checkRead(t, hash, true) {
+					return
+				}
+				runtime.Gosched()
+			}
+		}()
+	}
+
+	r.runWriter(t, rand.New(rand.NewSource(seed)), numOps)
+	close(done)
+	wg.Wait()
+
+	// after all writes completed
+	for _, hash := range r.hashes {
+		r.checkRead(t, hash, false)
+	}
+}
+
+func TestHash_Resize__Concurrent_Readers__Never_See_Stale_Data(t *testing.T) {
+	for seed := int64(1); seed <= 8; seed++ {
+		r := newResizeTest(seed, 40)
+		r.run(t, seed*100, 8, 1000)
+		if t.Failed() {
+			t.Log("seed:", seed)
+			return
+		}
+	}
+}
+
+func TestHash_Resize__Stale_Buckets__Readers_See_Latest_Data_After_Writes(t *testing.T) {
+	for seed := int64(1); seed <= 4; seed++ {
+		r := newResizeTest(seed, 40)
+		r.hashOptions = []HashOption{WithStaleBuckets(30 * time.Second)}
+		r.staleReads = true
+		r.run(t, seed*100, 8, 1000)
+		if t.Failed() {
+			t.Log("seed:", seed)
+			return
+		}
+	}
+}
@@ -3,19 +3,22 @@ package dhash
 import (
 	"context"
 	"errors"
+	"fmt"
 	"github.com/stretchr/testify/assert"
 	"testing"
 	"time"
 )
 
 type storeTest struct {
+	provider *ProviderImpl
+
 	pipe  *CachePipelineMock
 	db    *StoreDatabaseMock
 	store Store
 	timer *timerMock
 }
 
-func newStoreTest() *storeTest {
+func newStoreTest(options ...StoreOption) *storeTest {
 	client := &CacheClientMock{}
 	pipeline := &CachePipelineMock{}
 
@@ -26,13 +29,16 @@ func newStoreTest() *storeTest {
 	timer := newTimeMock()
 	p := newProviderImpl(nil, client)
 	p.timer = timer
+	p.random = maxRandom
 
 	db := &StoreDatabaseMock{}
 
 	s := &storeTest{
+		provider: p,
+
 		pipe:  pipeline,
 		db:    db,
-		store: p.NewSession().NewStore(db),
+		store: p.NewSession().NewStore(db, options...),
 		timer: timer,
 	}
 
@@ -41,10 +47,14 @@ func newStoreTest() *storeTest {
 	return s
 }
 
+func (s *storeTest) newStoreWithSession(sessOptions []SessionOption, options ...StoreOption) {
+	s.store = s.provider.NewSession(sessOptions...).NewStore(s.db, options...)
+}
+
 func (s *storeTest) stubPipeline() {
 	s.stubLeaseGet(LeaseGetOutput{
 		Type: LeaseGetTypeOK,
-		Data: []byte("default lease data return"),
+		Data: marshalStoreValue([]byte("default lease data return"), true),
 	})
 	s.pipe.LeaseSetFunc = func(key string, value []byte, leaseID uint64, ttl uint32) func() error {
 		return func() error {
@@ -116,7 +126,7 @@ func TestStore_Get__Lease_OK__Returns_Data(t *testing.T) {
 	s := newStoreTest()
 	s.stubLeaseGet(LeaseGetOutput{
 		Type: LeaseGetTypeOK,
-		Data: []byte("sample data"),
+		Data: marshalStoreValue([]byte("sample data"), true),
 	})
 
 	data, err := s.store.Get(newContext(), "key01")()
@@ -145,11 +155,51 @@ func TestStore_Get__Lease_Granted__Call_Lease_Set(t *testing.T) {
 
 	assert.Equal(t, 1, len(s.pipe.LeaseSetCalls()))
 	assert.Equal(t, "key01", s.pipe.LeaseSetCalls()[0].Key)
-	assert.Equal(t, []byte("db get data"), s.pipe.LeaseSetCalls()[0].Value)
+	assert.Equal(t, []byte("\x01db get data"), s.pipe.LeaseSetCalls()[0].Value)
 	assert.Equal(t, uint64(889900), s.pipe.LeaseSetCalls()[0].LeaseID)
 	assert.Equal(t, uint32(0), s.pipe.LeaseSetCalls()[0].TTL)
 }
 
+func TestStore_Get__Lease_Granted__With_TTL__Call_Lease_Set_With_TTL(t *testing.T) {
+	s := newStoreTest(WithStoreTTL(10 * time.Second))
+	s.stubLeaseGet(newLeaseGetGranted(889900))
+	s.stubDBGet("db get data")
+
+	_, _ = s.store.Get(newContext(), "key01")()
+
+	assert.Equal(t, 1, len(s.pipe.LeaseSetCalls()))
+	assert.Equal(t, uint32(10), s.pipe.LeaseSetCalls()[0].TTL)
+}
+
+func TestWithStoreTTL__Round_Up_To_Seconds(t *testing.T) {
+	assert.Equal(t, uint32(0), newStoreOptions().ttl.ttl)
+	assert.Equal(t, uint32(1), newStoreOptions(WithStoreTTL(time.Millisecond)).ttl.ttl)
+	assert.Equal(t, uint32(1), newStoreOptions(WithStoreTTL(time.Second)).ttl.ttl)
+	assert.Equal(t, uint32(2), newStoreOptions(WithStoreTTL(1500*time.Millisecond)).ttl.ttl)
+}
+
+func TestStore_Get__Lease_Granted__With_TTL_Jitter__Call_Lease_Set_With_Jitter(t *testing.T) {
+	s := newStoreTest(WithStoreTTL(10*time.Second), WithStoreTTLJitter(5*time.Second))
+	s.stubLeaseGet(newLeaseGetGranted(889900))
+	s.stubDBGet("db get data")
+
+	_, _ = s.store.Get(newContext(), "key01")()
+
+	assert.Equal(t, 1, len(s.pipe.LeaseSetCalls()))
+	assert.Equal(t, uint32(15), s.pipe.LeaseSetCalls()[0].TTL)
+}
+
+func TestStore_Get__Lease_Granted__Jitter_Without_TTL__Not_Expire(t *testing.T) {
+	s := newStoreTest(WithStoreTTLJitter(5 * time.Second))
+	s.stubLeaseGet(newLeaseGetGranted(889900))
+	s.stubDBGet("db get data")
+
+	_, _ = s.store.Get(newContext(), "key01")()
+
+	assert.Equal(t, 1, len(s.pipe.LeaseSetCalls()))
+	assert.Equal(t, uint32(0), s.pipe.LeaseSetCalls()[0].TTL)
+}
+
 func TestStore_Get__Lease_Granted__Returns_Data(t *testing.T) {
 	s := newStoreTest()
 	s.stubLeaseGet(newLeaseGetGranted(889900))
@@ -173,6 +223,116 @@ func TestStore_Get__Lease_Granted__Get_From_DB_Err__Call_Delete_Cache(t *testing
 	assert.Equal(t, "key01", s.pipe.DeleteCalls()[0].Key)
 }
 
+func TestStore_Get__Lease_OK__Empty_Data(t *testing.T) {
+	s := newStoreTest()
+	s.stubLeaseGet(LeaseGetOutput{
+		Type: LeaseGetTypeOK,
+		Data: []byte{storeValueFound},
+	})
+
+	data, err := s.store.Get(newContext(), "key01")()
+
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []byte{}, data)
+}
+
+func TestStore_Get__Lease_OK__Not_Found__Returns_Err_Not_Found(t *testing.T) {
+	s := newStoreTest()
+	s.stubLeaseGet(LeaseGetOutput{
+		Type: LeaseGetTypeOK,
+		Data: []byte{storeValueNotFound},
+	})
+
+	data, err := s.store.Get(newContext(), "key01")()
+
+	assert.Equal(t, ErrNotFound, err)
+	assert.Nil(t, data)
+	assert.Equal(t, 0, len(s.db.GetCalls()))
+}
+
+func TestStore_Get__Lease_OK__Invalid_Value__Delete__Then_Get_From_DB(t *testing.T) {
+	s := newStoreTest()
+	s.stubLeaseGetOutputs([]LeaseGetOutput{
+		{Type: LeaseGetTypeOK, Data: []byte("old format")},
+		newLeaseGetGranted(889900),
+	})
+	s.stubDBGet("db get data")
+
+	data, err := s.store.Get(newContext(), "key01")()
+
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "db get data", string(data))
+
+	assert.Equal(t, 1, len(s.pipe.DeleteCalls()))
+	assert.Equal(t, "key01", s.pipe.DeleteCalls()[0].Key)
+	assert.Equal(t, 2, len(s.pipe.LeaseGetCalls()))
+
+	assert.Equal(t, 1, len(s.pipe.LeaseSetCalls()))
+	assert.Equal(t, marshalStoreValue([]byte("db get data"), true), s.pipe.LeaseSetCalls()[0].Value)
+	assert.Equal(t, uint64(889900), s.pipe.LeaseSetCalls()[0].LeaseID)
+}
+
+func TestStore_Get__Lease_OK__Invalid_Value_After_Delete__Returns_Error(t *testing.T) {
+	s := newStoreTest()
+	s.stubLeaseGet(LeaseGetOutput{
+		Type: LeaseGetTypeOK,
+		Data: []byte("invalid"),
+	})
+
+	data, err := s.store.Get(newContext(), "key01")()
+
+	assert.Equal(t, errors.New("unmarshal store value: invalid prefix 105"), err)
+	assert.Nil(t, data)
+
+	assert.Equal(t, 1, len(s.pipe.DeleteCalls()))
+	assert.Equal(t, 2, len(s.pipe.LeaseGetCalls()))
+	assert.Equal(t, 0, len(s.db.GetCalls()))
+}
+
+func TestStore_Get__Lease_Granted__DB_Not_Found__Call_Lease_Set_With_Negative_TTL(t *testing.T) {
+	s := newStoreTest(
+		WithStoreTTL(100*time.Second),
+		WithStoreNegativeTTL(10*time.Second),
+		WithStoreTTLJitter(5*time.Second),
+	)
+	s.stubLeaseGet(newLeaseGetGranted(889900))
+	s.stubDBGetError(ErrNotFound)
+
+	data, err := s.store.Get(newContext(), "key01")()
+	assert.Equal(t, ErrNotFound, err)
+	assert.Nil(t, data)
+
+	assert.Equal(t, 0, len(s.pipe.DeleteCalls()))
+	assert.Equal(t, 1, len(s.pipe.LeaseSetCalls()))
+	assert.Equal(t, "key01", s.pipe.LeaseSetCalls()[0].Key)
+	assert.Equal(t, []byte{storeValueNotFound}, s.pipe.LeaseSetCalls()[0].Value)
+	assert.Equal(t, uint64(889900), s.pipe.LeaseSetCalls()[0].LeaseID)
+	assert.Equal(t, uint32(15), s.pipe.LeaseSetCalls()[0].TTL)
+}
+
+func TestStore_Get__Lease_Granted__DB_Wrapped_Not_Found(t *testing.T) {
+	s := newStoreTest(WithStoreNegativeTTL(10 * time.Second))
+	s.stubLeaseGet(newLeaseGetGranted(889900))
+	s.stubDBGetError(fmt.Errorf("campaign: %w", ErrNotFound))
+
+	_, err := s.store.Get(newContext(), "key01")()
+	assert.Equal(t, ErrNotFound, err)
+
+	assert.Equal(t, 1, len(s.pipe.LeaseSetCalls()))
+	assert.Equal(t, uint32(10), s.pipe.LeaseSetCalls()[0].TTL)
+}
+
+func TestWithStoreNegativeTTL__Round_Up_To_Seconds(t *testing.T) {
+	assert.Equal(t, uint32(2), newStoreOptions(WithStoreNegativeTTL(1500*time.Millisecond)).negativeTTL.ttl)
+}
+
+func TestWithStoreNegativeTTL__Default__Always_Expire(t *testing.T) {
+	assert.Equal(t, uint32(30), newStoreOptions().negativeTTL.ttl)
+	assert.Equal(t, uint32(30), newStoreOptions(WithStoreTTL(time.Minute)).negativeTTL.ttl)
+	assert.Equal(t, uint32(10), newStoreOptions(WithStoreTTL(10*time.Second)).negativeTTL.ttl)
+	assert.Equal(t, uint32(30), newStoreOptions(WithStoreNegativeTTL(0)).negativeTTL.ttl)
+}
+
 func TestStore_Get__Lease_Rejected__Call_Lease_Get_Multiple_Times__Returns_Error(t *testing.T) {
 	s := newStoreTest()
 	s.stubLeaseGetOutputs([]LeaseGetOutput{
@@ -197,6 +357,73 @@ func TestStore_Get__Lease_Rejected__Call_Lease_Get_Multiple_Times__Returns_Error
 	}, s.timer.sleepCalls)
 }
 
+func newRejectedAllTimes() []LeaseGetOutput {
+	return []LeaseGetOutput{
+		newLeaseGetRejected(),
+		newLeaseGetRejected(),
+		newLeaseGetRejected(),
+		newLeaseGetRejected(),
+	}
+}
+
+func TestStore_Get__Lease_Rejected_All_Times__Fallback_To_DB(t *testing.T) {
+	limiter := NewFallbackLimiter(2)
+
+	s := newStoreTest()
+	s.newStoreWithSession([]SessionOption{WithFallbackToDB(limiter)})
+	s.stubLeaseGetOutputs(newRejectedAllTimes())
+	s.stubDBGet("some db data")
+
+	data, err := s.store.Get(newContext(), "key01")()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "some db data", string(data))
+
+	assert.Equal(t, 1, len(s.db.GetCalls()))
+	assert.Equal(t, "key01", s.db.GetCalls()[0].Key)
+	assert.Equal(t, 0, len(s.pipe.LeaseSetCalls()))
+	assert.Equal(t, int64(0), limiter.Current())
+}
+
+func TestStore_Get__Lease_Rejected_All_Times__Fallback_To_DB__Not_Found(t *testing.T) {
+	limiter := NewFallbackLimiter(2)
+
+	s := newStoreTest()
+	s.newStoreWithSession([]SessionOption{WithFallbackToDB(limiter)})
+	s.stubLeaseGetOutputs(newRejectedAllTimes())
+	s.stubDBGetError(fmt.Errorf("campaign: %w", ErrNotFound))
+
+	data, err := s.store.Get(newContext(), "key01")()
+	assert.Equal(t, ErrNotFound, err)
+	assert.Nil(t, data)
+
+	assert.Equal(t, 0, len(s.pipe.LeaseSetCalls()))
+	assert.Equal(t, int64(0), limiter.Current())
+}
+
+func TestStore_Get__Lease_Rejected_All_Times__Fallback_Limit_Reached__Returns_Error(t *testing.T) {
+	limiter := NewFallbackLimiter(1)
+
+	s := newStoreTest()
+	s.newStoreWithSession([]SessionOption{WithFallbackToDB(limiter)})
+	s.stubLeaseGetOutputs(append(newRejectedAllTimes(), newRejectedAllTimes()...))
+	s.stubDBGet("some db data")
+
+	fn1 := s.store.Get(newContext(), "key01")
+	fn2 := s.store.Get(newContext(), "key02")
+
+	data, err := fn1()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "some db data", string(data))
+
+	data, err = fn2()
+	assert.Equal(t, ErrLeaseNotGranted, err)
+	assert.Nil(t, data)
+
+	assert.Equal(t, 1, len(s.db.GetCalls()))
+	assert.Equal(t, "key01", s.db.GetCalls()[0].Key)
+	assert.Equal(t, int64(0), limiter.Current())
+}
+
 func TestStore_Get__Lease_Rejected__Then_Granted(t *testing.T) {
 	s := newStoreTest()
 	s.stubLeaseGetOutputs([]LeaseGetOutput{
@@ -210,6 +437,79 @@ func TestStore_Get__Lease_Rejected__Then_Granted(t *testing.T) {
 	assert.Equal(t, "some db data", string(data))
 }
 
+// newDeadlineContext returns a context with the deadline after d in the time of the timer,
+// the timer is moved to the future so that the deadline is never reached in real time
+func newDeadlineContext(timer *timerMock, d time.Duration) (context.Context, func()) {
+	timer.current = time.Now().Add(time.Hour)
+	return context.WithDeadline(context.Background(), timer.current.Add(d))
+}
+
+func TestStore_Get__Lease_Rejected__Context_Cancelled__Returns_Context_Err(t *testing.T) {
+	s := newStoreTest()
+	s.stubLeaseGetOutputs(newRejectedAllTimes())
+
+	ctx, cancel := context.WithCancel(newContext())
+	fn := s.store.Get(ctx, "key01")
+	cancel()
+
+	data, err := fn()
+	assert.Equal(t, context.Canceled, err)
+	assert.Nil(t, data)
+
+	assert.Equal(t, 1, len(s.pipe.LeaseGetCalls()))
+	assert.Equal(t, []time.Duration{10 * time.Millisecond}, s.timer.sleepCalls)
+	assert.Equal(t, startOfTime(), s.timer.current)
+}
+
+func TestStore_Get__Lease_Rejected__Deadline_Before_Next_Retry__Returns_Deadline_Exceeded(t *testing.T) {
+	s := newStoreTest()
+	s.stubLeaseGetOutputs(newRejectedAllTimes())
+
+	ctx, cancel := newDeadlineContext(s.timer, 15*time.Millisecond)
+	defer cancel()
+
+	data, err := s.store.Get(ctx, "key01")()
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.Nil(t, data)
+
+	assert.Equal(t, 2, len(s.pipe.LeaseGetCalls()))
+	assert.Equal(t, []time.Duration{
+		10 * time.Millisecond,
+		5 * time.Millisecond,
+	}, s.timer.sleepCalls)
+}
+
+func TestStore_Get__Lease_Rejected__Multi_Gets__Sleep_Until_Earliest_Deadline(t *testing.T) {
+	s := newStoreTest()
+	s.stubLeaseGetOutputs([]LeaseGetOutput{
+		newLeaseGetRejected(),
+		newLeaseGetRejected(),
+		newLeaseGetGranted(3344),
+	})
+	s.stubDBGet("some db data")
+
+	ctx1, cancel := newDeadlineContext(s.timer, 5*time.Millisecond)
+	defer cancel()
+
+	fn1 := s.store.Get(ctx1, "key01")
+	fn2 := s.store.Get(newContext(), "key02")
+
+	data, err := fn1()
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.Nil(t, data)
+
+	data, err = fn2()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "some db data", string(data))
+
+	assert.Equal(t, 3, len(s.pipe.LeaseGetCalls()))
+	assert.Equal(t, "key02", s.pipe.LeaseGetCalls()[2].Key)
+	assert.Equal(t, []time.Duration{
+		5 * time.Millisecond,
+		5 * time.Millisecond,
+	}, s.timer.sleepCalls)
+}
+
 func TestStore_Invalidate(t *testing.T) {
 	s := newStoreTest()
 
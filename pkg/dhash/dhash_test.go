@@ -2,6 +2,7 @@ package dhash
 
 import (
 	"context"
+	"errors"
 	"github.com/stretchr/testify/assert"
 	"testing"
 	"time"
@@ -30,8 +31,12 @@ func (t *timerMock) Now() time.Time {
 	return t.current
 }
 
-func (t *timerMock) Sleep(d time.Duration) {
+// Sleep is interrupted immediately when ctx is done
+func (t *timerMock) Sleep(ctx context.Context, d time.Duration) {
 	t.sleepCalls = append(t.sleepCalls, d)
+	if ctx.Err() != nil {
+		return
+	}
 	t.current = t.current.Add(d)
 }
 
@@ -47,6 +52,11 @@ func newTimeMock() *timerMock {
 	return m
 }
 
+// maxRandom always returns the max value for asserting TTLs with jitter
+func maxRandom(n uint32) uint32 {
+	return n - 1
+}
+
 func newHashTest(ns string, options ...SessionOption) *hashTest {
 	mem := &MemTableMock{}
 	client := &CacheClientMock{}
@@ -59,6 +69,7 @@ func newHashTest(ns string, options ...SessionOption) *hashTest {
 	timer := newTimeMock()
 	p := newProviderImpl(mem, client)
 	p.timer = timer
+	p.random = maxRandom
 
 	db := &HashDatabaseMock{}
 	sess := p.NewSession(options...)
@@ -116,6 +127,9 @@ func (h *hashTest) stubPipeline() {
 	h.pipe.DeleteFunc = func(key string) func() error {
 		return func() error { return nil }
 	}
+	h.pipe.InvalidateFunc = func(key string, ttl uint32) func() error {
+		return func() error { return nil }
+	}
 	h.pipe.FinishFunc = func() {}
 }
 
@@ -227,9 +241,10 @@ func TestSelectEntries__MemTable_Exist__Call_Get_From_Cache_Client(t *testing.T)
 	assert.Equal(t, 1, len(h.pipe.LeaseGetCalls()))
 	assert.Equal(t, "sample:size-log", h.pipe.LeaseGetCalls()[0].Key)
 
-	assert.Equal(t, 2, len(h.pipe.GetCalls()))
+	assert.Equal(t, 3, len(h.pipe.GetCalls()))
 	assert.Equal(t, "sample:4:f0000000", h.pipe.GetCalls()[0].Key)
 	assert.Equal(t, "sample:5:f8000000", h.pipe.GetCalls()[1].Key)
+	assert.Equal(t, "sample:6:fc000000", h.pipe.GetCalls()[2].Key)
 }
 
 func newEntry(hash uint32, data ...byte) Entry {
@@ -247,6 +262,7 @@ func TestSelectEntries__Second_Slot_Found(t *testing.T) {
 			newEntry(0xfc345678, 1, 2, 3),
 			newEntry(0xfc345000, 5, 6, 7),
 		},
+		{},
 	})
 
 	entries, err := h.hash.SelectEntries(newContext(), 0xfc345678)()
@@ -272,6 +288,47 @@ func TestSelectEntries__First_Slot_Found(t *testing.T) {
 			newEntry(0xfc345000, 5, 6, 7),
 		},
 		{},
+		{},
+	})
+
+	entries, err := h.hash.SelectEntries(newContext(), 0xfc345678)()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []Entry{newEntry(0xfc345678, 1, 2, 3)}, entries)
+}
+
+func TestSelectEntries__Third_Slot_Found__After_Size_Log_Shrinked(t *testing.T) {
+	h := newHashTest("sample")
+
+	h.stubGetNum(5)
+	h.stubLeaseGetOK("5")
+	h.stubClientGet([][]Entry{
+		{},
+		{},
+		{
+			newEntry(0xfc345678, 1, 2, 3),
+			newEntry(0xfc345000, 5, 6, 7),
+		},
+	})
+
+	entries, err := h.hash.SelectEntries(newContext(), 0xfc345678)()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []Entry{newEntry(0xfc345678, 1, 2, 3)}, entries)
+
+	assert.Equal(t, 0, len(h.pipe.LeaseSetCalls()))
+
+	h.finish()
+	assert.Equal(t, uint64(0), h.provider.HashBucketMissCount())
+}
+
+func TestSelectEntries__Second_Slot_Preferred_Over_Third_Slot(t *testing.T) {
+	h := newHashTest("sample")
+
+	h.stubGetNum(5)
+	h.stubLeaseGetOK("5")
+	h.stubClientGet([][]Entry{
+		{},
+		{newEntry(0xfc345678, 1, 2, 3)},
+		{newEntry(0xfc345678, 8, 8, 8)},
 	})
 
 	entries, err := h.hash.SelectEntries(newContext(), 0xfc345678)()
@@ -301,9 +358,69 @@ func TestSelectEntries__When_GetNum_Not_Found__Call_Client_Get(t *testing.T) {
 
 	_, _ = h.hash.SelectEntries(newContext(), 0xfc345678)()
 
-	assert.Equal(t, 2, len(h.pipe.GetCalls()))
+	assert.Equal(t, 3, len(h.pipe.GetCalls()))
 	assert.Equal(t, "sample:4:f0000000", h.pipe.GetCalls()[0].Key)
 	assert.Equal(t, "sample:5:f8000000", h.pipe.GetCalls()[1].Key)
+	assert.Equal(t, "sample:6:fc000000", h.pipe.GetCalls()[2].Key)
+}
+
+func TestSelectEntries__Size_Log_Zero__Call_Client_Get_Without_Previous_Size_Log_Bucket(t *testing.T) {
+	h := newHashTest("sample")
+
+	h.stubGetNum(0)
+	h.stubLeaseGetOK("0")
+	h.stubClientGet([][]Entry{
+		{newEntry(0xfc345678, 1, 2, 3), newEntry(0x12345678, 4, 5, 6)},
+		{},
+	})
+
+	entries, err := h.hash.SelectEntries(newContext(), 0xfc345678)()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []Entry{newEntry(0xfc345678, 1, 2, 3)}, entries)
+
+	assert.Equal(t, 2, len(h.pipe.GetCalls()))
+	assert.Equal(t, "sample:0:00000000", h.pipe.GetCalls()[0].Key)
+	assert.Equal(t, "sample:1:80000000", h.pipe.GetCalls()[1].Key)
+}
+
+func TestSelectEntries__Size_Log_Zero__Bucket_Not_Found__Select_Whole_Hash_Space_From_DB(t *testing.T) {
+	h := newHashTest("sample")
+
+	h.stubGetNum(0)
+	h.stubLeaseGetOutputs([]LeaseGetOutput{
+		{
+			Type: LeaseGetTypeOK,
+			Data: []byte("0"),
+		},
+		newLeaseGetGranted(7788),
+	})
+	h.stubClientGet([][]Entry{
+		{}, {},
+	})
+	h.stubDBSelectEntries([]Entry{newEntry(0xfc345678, 1, 2, 3)})
+
+	entries, err := h.hash.SelectEntries(newContext(), 0xfc345678)()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []Entry{newEntry(0xfc345678, 1, 2, 3)}, entries)
+
+	assert.Equal(t, 1, len(h.db.SelectEntriesCalls()))
+	assert.Equal(t, uint32(0), h.db.SelectEntriesCalls()[0].HashBegin)
+	assert.Equal(t, NullUint32{}, h.db.SelectEntriesCalls()[0].HashEnd)
+
+	assert.Equal(t, 1, len(h.pipe.LeaseSetCalls()))
+	assert.Equal(t, "sample:0:00000000", h.pipe.LeaseSetCalls()[0].Key)
+}
+
+func TestSelectEntries__Client_Size_Log_Out_Of_Range__Returns_Error(t *testing.T) {
+	h := newHashTest("sample")
+
+	h.stubGetNumNotFound()
+	h.stubLeaseGetOK("33")
+
+	entries, err := h.hash.SelectEntries(newContext(), 0xfc345678)()
+	assert.Equal(t, errors.New("invalid size log: 33"), err)
+	assert.Equal(t, []Entry(nil), entries)
+	assert.Equal(t, 0, len(h.pipe.GetCalls()))
 }
 
 func TestSelectEntries__When_GetNum_Not_Found__Second_Slot_Found(t *testing.T) {
@@ -317,6 +434,7 @@ func TestSelectEntries__When_GetNum_Not_Found__Second_Slot_Found(t *testing.T) {
 			newEntry(0xfc345678, 1, 2, 3),
 			newEntry(0xfc345000, 5, 6, 7),
 		},
+		{},
 	})
 
 	entries, err := h.hash.SelectEntries(newContext(), 0xfc345678)()
@@ -384,7 +502,30 @@ func TestSelectEntries__When_Client_Get_Size_Log_Granted__Do_Cache_Client_Lease_
 	assert.Equal(t, uint32(0), h.pipe.LeaseSetCalls()[0].TTL)
 }
 
-func TestSelectEntries__When_Client_Get_Size_Log_Granted__Returns_Entry_From_Client_Get(t *testing.T) {
+func TestSelectEntries__When_Client_Get_Size_Log_Granted__Lease_Set_With_Size_Log_TTL(t *testing.T) {
+	h := newHashTest("sample")
+	h.hash = h.sess.NewHash("sample", h.db,
+		WithSizeLogTTL(30*time.Minute),
+		WithBucketTTL(10*time.Minute),
+		WithHashTTLJitter(time.Minute),
+	)
+
+	h.stubGetNum(5)
+	h.stubLeaseGet(LeaseGetOutput{
+		Type:    LeaseGetTypeGranted,
+		LeaseID: 0x3344,
+	})
+
+	h.stubDBGetSizeLog(7)
+
+	_, _ = h.hash.SelectEntries(newContext(), 0xfc345678)()
+
+	assert.Equal(t, 1, len(h.pipe.LeaseSetCalls()))
+	assert.Equal(t, "sample:size-log", h.pipe.LeaseSetCalls()[0].Key)
+	assert.Equal(t, uint32(30*60+60), h.pipe.LeaseSetCalls()[0].TTL)
+}
+
+func TestSelectEntries__When_Client_Get_Size_Log_Granted__Get_Buckets_Again__Returns_Entry_From_Client_Get(t *testing.T) {
 	h := newHashTest("sample")
 
 	h.stubGetNum(5)
@@ -393,7 +534,8 @@ func TestSelectEntries__When_Client_Get_Size_Log_Granted__Returns_Entry_From_Cli
 		LeaseID: 0x3344,
 	})
 	h.stubClientGet([][]Entry{
-		{}, {newEntry(0xfc345678, 1, 2, 3)},
+		{}, {newEntry(0xfc345678, 1, 2, 3)}, {},
+		{}, {newEntry(0xfc345678, 8, 8, 8)}, {},
 	})
 
 	h.stubDBGetSizeLog(5)
@@ -401,12 +543,16 @@ func TestSelectEntries__When_Client_Get_Size_Log_Granted__Returns_Entry_From_Cli
 	entries, err := h.hash.SelectEntries(newContext(), 0xfc345678)()
 	assert.Equal(t, nil, err)
 	assert.Equal(t, []Entry{
-		newEntry(0xfc345678, 1, 2, 3),
+		newEntry(0xfc345678, 8, 8, 8),
 	}, entries)
 
+	assert.Equal(t, 6, len(h.pipe.GetCalls()))
+	assert.Equal(t, "sample:5:f8000000", h.pipe.GetCalls()[1].Key)
+	assert.Equal(t, "sample:5:f8000000", h.pipe.GetCalls()[4].Key)
+
 	h.finish()
 	assert.Equal(t, uint64(1), h.provider.HashSizeLogAccessCount())
-	assert.Equal(t, uint64(1), h.provider.HashBucketAccessCount())
+	assert.Equal(t, uint64(2), h.provider.HashBucketAccessCount())
 	assert.Equal(t, uint64(1), h.provider.HashSizeLogMissCount())
 	assert.Equal(t, uint64(0), h.provider.HashBucketMissCount())
 }
@@ -425,6 +571,8 @@ func TestSelectEntries__When_Client_Get_Size_Log_Reject__Do_Retries(t *testing.T
 		{
 			newEntry(0xfc345678, 1, 2, 3),
 		},
+		{},
+		{}, {newEntry(0xfc345678, 1, 2, 3)}, {},
 	})
 
 	h.stubDBGetSizeLog(5)
@@ -471,13 +619,82 @@ func TestSelectEntries__When_Client_Get_Size_Log_Reject__Retries_All_Times(t *te
 	assert.Equal(t, uint64(4), h.provider.HashSizeLogMissCount())
 }
 
+func TestSelectEntries__When_Client_Get_Size_Log_Reject__Retries_All_Times__Fallback_To_DB(t *testing.T) {
+	limiter := NewFallbackLimiter(1)
+	h := newHashTest("sample", WithFallbackToDB(limiter))
+
+	h.stubGetNum(5)
+	h.stubLeaseGetOutputs([]LeaseGetOutput{
+		newLeaseGetRejected(),
+		newLeaseGetRejected(),
+		newLeaseGetRejected(),
+		newLeaseGetRejected(),
+	})
+	h.stubDBGetSizeLog(5)
+	h.stubClientGet([][]Entry{
+		{}, {}, {},
+		{}, {newEntry(0xfc345678, 1, 2, 3)}, {},
+	})
+
+	entries, err := h.hash.SelectEntries(newContext(), 0xfc345678)()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []Entry{newEntry(0xfc345678, 1, 2, 3)}, entries)
+
+	assert.Equal(t, 1, len(h.db.GetSizeLogCalls()))
+	assert.Equal(t, 0, len(h.pipe.LeaseSetCalls()))
+	assert.Equal(t, 6, len(h.pipe.GetCalls()))
+	assert.Equal(t, int64(0), limiter.Current())
+}
+
+func TestSelectEntries__When_Client_Get_Size_Log_Reject__Fallback_Limit_Reached__Returns_Err(t *testing.T) {
+	limiter := NewFallbackLimiter(1)
+	assert.Equal(t, true, limiter.tryAcquire())
+
+	h := newHashTest("sample", WithFallbackToDB(limiter))
+
+	h.stubGetNum(5)
+	h.stubLeaseGetOutputs([]LeaseGetOutput{
+		newLeaseGetRejected(),
+		newLeaseGetRejected(),
+		newLeaseGetRejected(),
+		newLeaseGetRejected(),
+	})
+
+	_, err := h.hash.SelectEntries(newContext(), 0xfc345678)()
+	assert.Equal(t, ErrLeaseNotGranted, err)
+
+	assert.Equal(t, 0, len(h.db.GetSizeLogCalls()))
+	assert.Equal(t, int64(1), limiter.Current())
+}
+
+func TestSelectEntries__When_Client_Get_Size_Log_Reject__Context_Cancelled__Returns_Context_Err(t *testing.T) {
+	h := newHashTest("sample")
+
+	h.stubGetNum(5)
+	h.stubLeaseGetOutputs([]LeaseGetOutput{
+		newLeaseGetRejected(),
+		newLeaseGetRejected(),
+	})
+
+	ctx, cancel := context.WithCancel(newContext())
+	fn := h.hash.SelectEntries(ctx, 0xfc345678)
+	cancel()
+
+	entries, err := fn()
+	assert.Equal(t, context.Canceled, err)
+	assert.Nil(t, entries)
+
+	assert.Equal(t, 1, len(h.pipe.LeaseGetCalls()))
+	assert.Equal(t, []time.Duration{10 * time.Millisecond}, h.timer.sleepCalls)
+}
+
 func TestSelectEntries__When_Both_Bucket_Not_Found__Client_Lease_Get(t *testing.T) {
 	h := newHashTest("sample")
 
 	h.stubGetNum(5)
 	h.stubLeaseGetOK("5")
 	h.stubClientGet([][]Entry{
-		{}, {}, // both not found
+		{}, {}, {}, // all not found
 	})
 
 	_, _ = h.hash.SelectEntries(newContext(), 0xfc345678)()
@@ -490,6 +707,89 @@ func TestSelectEntries__When_Both_Bucket_Not_Found__Client_Lease_Get(t *testing.
 	assert.Equal(t, uint64(2), h.provider.HashBucketAccessCount())
 }
 
+func (h *hashTest) stubClientGetOutputs(outputs []GetOutput) {
+	h.pipe.GetFunc = func(key string) func() (GetOutput, error) {
+		index := len(h.pipe.GetCalls()) - 1
+		return func() (GetOutput, error) {
+			return outputs[index], nil
+		}
+	}
+}
+
+func TestSelectEntries__Second_Slot_Stale__Client_Lease_Get(t *testing.T) {
+	h := newHashTest("sample")
+
+	h.stubGetNum(5)
+	h.stubLeaseGetOK("5")
+	h.stubClientGetOutputs([]GetOutput{
+		{},
+		{
+			Found: true,
+			Stale: true,
+			Data:  marshalEntries([]Entry{newEntry(0xfc345678, 1, 2, 3)}),
+		},
+		{},
+	})
+
+	_, _ = h.hash.SelectEntries(newContext(), 0xfc345678)()
+
+	assert.Equal(t, 2, len(h.pipe.LeaseGetCalls()))
+	assert.Equal(t, "sample:size-log", h.pipe.LeaseGetCalls()[0].Key)
+	assert.Equal(t, "sample:5:f8000000", h.pipe.LeaseGetCalls()[1].Key)
+}
+
+func TestSelectEntries__Second_Slot_Stale__First_Slot_Found(t *testing.T) {
+	h := newHashTest("sample")
+
+	h.stubGetNum(5)
+	h.stubLeaseGetOK("5")
+	h.stubClientGetOutputs([]GetOutput{
+		{
+			Found: true,
+			Data:  marshalEntries([]Entry{newEntry(0xfc345678, 4, 5, 6)}),
+		},
+		{
+			Found: true,
+			Stale: true,
+			Data:  marshalEntries([]Entry{newEntry(0xfc345678, 1, 2, 3)}),
+		},
+		{},
+	})
+
+	entries, err := h.hash.SelectEntries(newContext(), 0xfc345678)()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []Entry{newEntry(0xfc345678, 4, 5, 6)}, entries)
+
+	assert.Equal(t, 1, len(h.pipe.LeaseGetCalls()))
+}
+
+func TestSelectEntries__Bucket_Lease_Get_Stale__Returns_Stale_Entries_Without_Waiting(t *testing.T) {
+	h := newHashTest("sample")
+
+	h.stubGetNum(5)
+	h.stubLeaseGetOutputs([]LeaseGetOutput{
+		{
+			Type: LeaseGetTypeOK,
+			Data: []byte("5"),
+		},
+		{
+			Type: LeaseGetTypeStale,
+			Data: marshalEntries([]Entry{newEntry(0xfc345678, 1, 2, 3)}),
+		},
+	})
+	h.stubClientGet([][]Entry{
+		{}, {}, {}, // all not found
+	})
+
+	entries, err := h.hash.SelectEntries(newContext(), 0xfc345678)()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []Entry{newEntry(0xfc345678, 1, 2, 3)}, entries)
+
+	assert.Equal(t, 2, len(h.pipe.LeaseGetCalls()))
+	assert.Equal(t, 0, len(h.timer.sleepCalls))
+	assert.Equal(t, 0, len(h.db.SelectEntriesCalls()))
+}
+
 func TestSelectEntries__When_Client_SizeLog_Too_Different__Get_Buckets_Again(t *testing.T) {
 	h := newHashTest("sample")
 
@@ -502,9 +802,8 @@ func TestSelectEntries__When_Client_SizeLog_Too_Different__Get_Buckets_Again(t *
 	})
 
 	h.stubClientGet([][]Entry{
-		{}, {newEntry(0xdc345678, 1, 2, 3)},
-		{},
-		{newEntry(0xdc345678, 8, 8, 8), newEntry(0xdc345000, 5, 6, 7)},
+		{}, {newEntry(0xdc345678, 1, 2, 3)}, {},
+		{}, {newEntry(0xdc345678, 8, 8, 8), newEntry(0xdc345000, 5, 6, 7)}, {},
 	})
 
 	entries, err := h.hash.SelectEntries(newContext(), 0xdc345678)()
@@ -514,9 +813,10 @@ func TestSelectEntries__When_Client_SizeLog_Too_Different__Get_Buckets_Again(t *
 	assert.Equal(t, "sample", h.mem.SetNumCalls()[0].Key)
 	assert.Equal(t, uint64(7), h.mem.SetNumCalls()[0].Num)
 
-	assert.Equal(t, 4, len(h.pipe.GetCalls()))
-	assert.Equal(t, "sample:6:dc000000", h.pipe.GetCalls()[2].Key)
-	assert.Equal(t, "sample:7:dc000000", h.pipe.GetCalls()[3].Key)
+	assert.Equal(t, 6, len(h.pipe.GetCalls()))
+	assert.Equal(t, "sample:6:dc000000", h.pipe.GetCalls()[3].Key)
+	assert.Equal(t, "sample:7:dc000000", h.pipe.GetCalls()[4].Key)
+	assert.Equal(t, "sample:8:dc000000", h.pipe.GetCalls()[5].Key)
 
 	assert.Equal(t, []Entry{
 		newEntry(0xdc345678, 8, 8, 8),
@@ -537,9 +837,8 @@ func TestSelectEntries__When_DB_SizeLog_Too_Different__Get_Buckets_Again(t *test
 	h.stubDBGetSizeLog(7)
 
 	h.stubClientGet([][]Entry{
-		{}, {newEntry(0xdc345678, 1, 2, 3)},
-		{},
-		{newEntry(0xdc345678, 8, 8, 8), newEntry(0xdc345000, 5, 6, 7)},
+		{}, {newEntry(0xdc345678, 1, 2, 3)}, {},
+		{}, {newEntry(0xdc345678, 8, 8, 8), newEntry(0xdc345000, 5, 6, 7)}, {},
 	})
 
 	entries, err := h.hash.SelectEntries(newContext(), 0xdc345678)()
@@ -549,9 +848,10 @@ func TestSelectEntries__When_DB_SizeLog_Too_Different__Get_Buckets_Again(t *test
 	assert.Equal(t, "sample", h.mem.SetNumCalls()[0].Key)
 	assert.Equal(t, uint64(7), h.mem.SetNumCalls()[0].Num)
 
-	assert.Equal(t, 4, len(h.pipe.GetCalls()))
-	assert.Equal(t, "sample:6:dc000000", h.pipe.GetCalls()[2].Key)
-	assert.Equal(t, "sample:7:dc000000", h.pipe.GetCalls()[3].Key)
+	assert.Equal(t, 6, len(h.pipe.GetCalls()))
+	assert.Equal(t, "sample:6:dc000000", h.pipe.GetCalls()[3].Key)
+	assert.Equal(t, "sample:7:dc000000", h.pipe.GetCalls()[4].Key)
+	assert.Equal(t, "sample:8:dc000000", h.pipe.GetCalls()[5].Key)
 
 	assert.Equal(t, []Entry{
 		newEntry(0xdc345678, 8, 8, 8),
@@ -591,7 +891,7 @@ func TestSelectEntries__When_Both_Bucket_Not_Found__Select_Entries_From_DB(t *te
 	})
 
 	h.stubClientGet([][]Entry{
-		{}, {}, // both not found
+		{}, {}, {}, // all not found
 	})
 
 	_, _ = h.hash.SelectEntries(newContext(), 0xdc345678)()
@@ -618,7 +918,7 @@ func TestSelectEntries__When_Both_Bucket_Not_Found__Returns_Entry_From_DB__And_S
 	})
 
 	h.stubClientGet([][]Entry{
-		{}, {}, // both not found
+		{}, {}, {}, // all not found
 	})
 
 	dbEntries := []Entry{
@@ -657,6 +957,41 @@ func TestSelectEntries__When_Both_Bucket_Not_Found__Returns_Entry_From_DB__And_S
 	assert.Equal(t, uint64(2), h.provider.HashBucketMissCount())
 }
 
+func TestSelectEntries__When_Both_Bucket_Not_Found__Lease_Set_With_Bucket_TTL(t *testing.T) {
+	h := newHashTest("sample")
+	h.hash = h.sess.NewHash("sample", h.db,
+		WithSizeLogTTL(30*time.Minute),
+		WithBucketTTL(10*time.Minute),
+	)
+
+	h.stubGetNum(5)
+	h.stubLeaseGetOutputs([]LeaseGetOutput{
+		{
+			Type: LeaseGetTypeOK,
+			Data: []byte("5"),
+		},
+		newLeaseGetGranted(7788),
+	})
+
+	h.stubClientGet([][]Entry{
+		{}, {}, {}, // all not found
+	})
+
+	h.stubDBSelectEntries([]Entry{
+		{
+			Hash: 0xdc345679,
+			Data: []byte("db data 01"),
+		},
+	})
+
+	_, err := h.hash.SelectEntries(newContext(), 0xdc345678)()
+	assert.Equal(t, nil, err)
+
+	assert.Equal(t, 1, len(h.pipe.LeaseSetCalls()))
+	assert.Equal(t, "sample:5:d8000000", h.pipe.LeaseSetCalls()[0].Key)
+	assert.Equal(t, uint32(10*60), h.pipe.LeaseSetCalls()[0].TTL)
+}
+
 func TestSelectEntries__When_Both_Bucket_Not_Found__Client_Lease_Get_Rejected__Call_Second_Times(t *testing.T) {
 	h := newHashTest("sample")
 
@@ -670,7 +1005,7 @@ func TestSelectEntries__When_Both_Bucket_Not_Found__Client_Lease_Get_Rejected__C
 		newLeaseGetGranted(5544),
 	})
 	h.stubClientGet([][]Entry{
-		{}, {}, // both not found
+		{}, {}, {}, // all not found
 	})
 
 	_, _ = h.hash.SelectEntries(newContext(), 0xfc345678)()
@@ -703,7 +1038,7 @@ func TestSelectEntries__When_Both_Bucket_Not_Found__Client_Lease_Get_Rejected_Al
 		newLeaseGetRejected(),
 	})
 	h.stubClientGet([][]Entry{
-		{}, {}, // both not found
+		{}, {}, {}, // all not found
 	})
 
 	_, err := h.hash.SelectEntries(newContext(), 0xfc345678)()
@@ -727,6 +1062,70 @@ func TestSelectEntries__When_Both_Bucket_Not_Found__Client_Lease_Get_Rejected_Al
 	assert.Equal(t, uint64(5), h.provider.HashBucketMissCount())
 }
 
+func TestSelectEntries__When_Both_Bucket_Not_Found__Client_Lease_Get_Rejected_All_Times__Fallback_To_DB(t *testing.T) {
+	limiter := NewFallbackLimiter(1)
+	h := newHashTest("sample", WithFallbackToDB(limiter))
+
+	h.stubGetNum(5)
+	h.stubLeaseGetOutputs([]LeaseGetOutput{
+		{
+			Type: LeaseGetTypeOK,
+			Data: []byte("5"),
+		},
+		newLeaseGetRejected(),
+		newLeaseGetRejected(),
+		newLeaseGetRejected(),
+		newLeaseGetRejected(),
+	})
+	h.stubClientGet([][]Entry{
+		{}, {}, {}, // all not found
+	})
+	h.stubDBSelectEntries([]Entry{newEntry(0xfc345678, 1, 2, 3)})
+
+	entries, err := h.hash.SelectEntries(newContext(), 0xfc345678)()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []Entry{newEntry(0xfc345678, 1, 2, 3)}, entries)
+
+	assert.Equal(t, 1, len(h.db.SelectEntriesCalls()))
+	assert.Equal(t, uint32(0xf8000000), h.db.SelectEntriesCalls()[0].HashBegin)
+	assert.Equal(t, NullUint32{}, h.db.SelectEntriesCalls()[0].HashEnd)
+
+	assert.Equal(t, 0, len(h.pipe.LeaseSetCalls()))
+	assert.Equal(t, int64(0), limiter.Current())
+}
+
+func TestSelectEntries__When_Both_Bucket_Not_Found__Client_Lease_Get_Rejected__Deadline_Exceeded(t *testing.T) {
+	h := newHashTest("sample")
+
+	h.stubGetNum(5)
+	h.stubLeaseGetOutputs([]LeaseGetOutput{
+		{
+			Type: LeaseGetTypeOK,
+			Data: []byte("5"),
+		},
+		newLeaseGetRejected(),
+		newLeaseGetRejected(),
+		newLeaseGetRejected(),
+	})
+	h.stubClientGet([][]Entry{
+		{}, {}, {}, // all not found
+	})
+
+	ctx, cancel := newDeadlineContext(h.timer, 25*time.Millisecond)
+	defer cancel()
+
+	entries, err := h.hash.SelectEntries(ctx, 0xfc345678)()
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.Nil(t, entries)
+
+	assert.Equal(t, 3, len(h.pipe.LeaseGetCalls()))
+	assert.Equal(t, []time.Duration{
+		10 * time.Millisecond,
+		15 * time.Millisecond,
+	}, h.timer.sleepCalls)
+	assert.Equal(t, 0, len(h.db.SelectEntriesCalls()))
+}
+
 func TestSelectEntries__When_Both_Bucket_Not_Found__Client_Lease_Get_OK__Returns_Client_Entries(t *testing.T) {
 	h := newHashTest("sample")
 
@@ -748,7 +1147,7 @@ func TestSelectEntries__When_Both_Bucket_Not_Found__Client_Lease_Get_OK__Returns
 		},
 	})
 	h.stubClientGet([][]Entry{
-		{}, {}, // both not found
+		{}, {}, {}, // all not found
 	})
 
 	entries, err := h.hash.SelectEntries(newContext(), 0xfc345678)()
@@ -785,6 +1184,10 @@ func TestSelectEntries__When_Request_Size_Log_Duplicated__And_Client_Size_Log_Gr
 	h.stubClientGet([][]Entry{
 		{newEntry(0xdc345678, 1, 2, 3)},
 		{newEntry(0xdc345678, 8, 8, 8)},
+		{},
+		{newEntry(0xdc345678, 1, 2, 3)},
+		{newEntry(0xdc345678, 8, 8, 8)},
+		{},
 	})
 
 	h.stubDBGetSizeLog(5)
@@ -819,9 +1222,20 @@ func TestSelectEntries__When_Request_Size_Log_Duplicated__And_Client_Size_Log_Gr
 	assert.Equal(t, []byte("5"), h.pipe.LeaseSetCalls()[0].Value)
 	assert.Equal(t, uint64(7788), h.pipe.LeaseSetCalls()[0].LeaseID)
 
+	assert.Equal(t, 6, len(h.pipe.GetCalls()))
+
 	h.finish()
 	assert.Equal(t, uint64(2), h.provider.HashSizeLogAccessCount())
-	assert.Equal(t, uint64(2), h.provider.HashBucketAccessCount())
+	assert.Equal(t, uint64(4), h.provider.HashBucketAccessCount())
 	assert.Equal(t, uint64(2), h.provider.HashSizeLogMissCount())
 	assert.Equal(t, uint64(0), h.provider.HashBucketMissCount())
 }
+
+func TestDefaultDelayTimer__Sleep__Interrupted_By_Context(t *testing.T) {
+	ctx, cancel := context.WithCancel(newContext())
+	cancel()
+
+	start := time.Now()
+	defaultDelayTimer{}.Sleep(ctx, time.Hour)
+	assert.Less(t, int64(time.Since(start)), int64(time.Second))
+}
@@ -0,0 +1,35 @@
+package dhash
+
+import "sync/atomic"
+
+// FallbackLimiter limits the number of concurrent direct database reads of WithFallbackToDB,
+// can be shared between goroutines
+type FallbackLimiter struct {
+	limit   int64
+	current int64
+}
+
+// NewFallbackLimiter with the max number of concurrent database reads
+func NewFallbackLimiter(limit int) *FallbackLimiter {
+	return &FallbackLimiter{
+		limit: int64(limit),
+	}
+}
+
+// tryAcquire does NOT block, returns false when the limit is reached
+func (l *FallbackLimiter) tryAcquire() bool {
+	if atomic.AddInt64(&l.current, 1) > l.limit {
+		atomic.AddInt64(&l.current, -1)
+		return false
+	}
+	return true
+}
+
+func (l *FallbackLimiter) release() {
+	atomic.AddInt64(&l.current, -1)
+}
+
+// Current returns the number of running database reads
+func (l *FallbackLimiter) Current() int64 {
+	return atomic.LoadInt64(&l.current)
+}
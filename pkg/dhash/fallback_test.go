@@ -0,0 +1,26 @@
+package dhash
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestFallbackLimiter(t *testing.T) {
+	l := NewFallbackLimiter(2)
+
+	assert.Equal(t, true, l.tryAcquire())
+	assert.Equal(t, true, l.tryAcquire())
+	assert.Equal(t, false, l.tryAcquire())
+	assert.Equal(t, int64(2), l.Current())
+
+	l.release()
+	assert.Equal(t, int64(1), l.Current())
+	assert.Equal(t, true, l.tryAcquire())
+	assert.Equal(t, false, l.tryAcquire())
+}
+
+func TestFallbackLimiter__Zero_Limit(t *testing.T) {
+	l := NewFallbackLimiter(0)
+	assert.Equal(t, false, l.tryAcquire())
+	assert.Equal(t, int64(0), l.Current())
+}
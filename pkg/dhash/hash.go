@@ -6,7 +6,8 @@ import (
 )
 
 type hashImpl struct {
-	sess *sessionImpl
+	sess    *sessionImpl
+	options hashOptions
 
 	mem        MemTable
 	pipeline   CachePipeline
@@ -15,12 +16,39 @@ type hashImpl struct {
 	sizeLogKey string
 }
 
-// SelectEntries ...
+// SelectEntries can return entries of other hashes in the same bucket
 func (h *hashImpl) SelectEntries(ctx context.Context, hash uint32) func() ([]Entry, error) {
+	action := h.newSelectAction(ctx, []uint32{hash})
+	return func() ([]Entry, error) {
+		h.sess.processAllCalls()
+		results, err := action.getResults(false)
+		if err != nil {
+			return nil, err
+		}
+		return results[0], nil
+	}
+}
+
+// SelectMultiEntries shares the size log between the hashes, hashes in the same bucket get the bucket once
+func (h *hashImpl) SelectMultiEntries(ctx context.Context, hashes []uint32) func() ([][]Entry, error) {
+	if len(hashes) == 0 {
+		return func() ([][]Entry, error) {
+			return nil, nil
+		}
+	}
+
+	action := h.newSelectAction(ctx, hashes)
+	return func() ([][]Entry, error) {
+		h.sess.processAllCalls()
+		return action.getResults(true)
+	}
+}
+
+func (h *hashImpl) newSelectAction(ctx context.Context, hashes []uint32) *hashSelectAction {
 	action := &hashSelectAction{
-		root: h,
-		ctx:  ctx,
-		hash: hash,
+		root:   h,
+		ctx:    ctx,
+		hashes: hashes,
 	}
 
 	sizeLogNum, ok := h.mem.GetNum(h.namespace)
@@ -33,10 +61,7 @@ func (h *hashImpl) SelectEntries(ctx context.Context, hash uint32) func() ([]Ent
 		action.handleMemSizeLogExisted()
 	}
 
-	return func() ([]Entry, error) {
-		h.sess.processAllCalls()
-		return action.results, action.err
-	}
+	return action
 }
 
 // InvalidateSizeLog ...
@@ -44,15 +69,135 @@ func (h *hashImpl) InvalidateSizeLog(_ context.Context) func() error {
 	return h.pipeline.Delete(h.sizeLogKey)
 }
 
-// InvalidateEntry ...
+// InvalidateEntry deletes the buckets containing the hash of the size log and its adjacent size logs,
+// or marks them as stale if WithStaleBuckets is used
 func (h *hashImpl) InvalidateEntry(_ context.Context, sizeLog uint64, hash uint32) func() error {
-	fn1 := h.pipeline.Delete(computeBucketKey(h.namespace, int(sizeLog-1), hash))
-	fn2 := h.pipeline.Delete(computeBucketKey(h.namespace, int(sizeLog), hash))
+	var fns []func() error
+	for _, level := range adjacentSizeLogs(int(sizeLog)) {
+		key := computeBucketKey(h.namespace, level, hash)
+		if h.options.staleBuckets {
+			fns = append(fns, h.pipeline.Invalidate(key, h.options.staleBucketTTL))
+		} else {
+			fns = append(fns, h.pipeline.Delete(key))
+		}
+	}
+	return waitAll(fns)
+}
+
+// Resize deletes all buckets of the size logs that are read at newSizeLog but were not invalidated
+// by InvalidateEntry at oldSizeLog, then deletes the size log.
+// The size log is deleted last so readers observing the new size log never see stale buckets.
+// Buckets are always deleted here, even with WithStaleBuckets, because buckets of the size logs
+// not maintained at oldSizeLog can be arbitrarily old.
+// Only the first batch of deletes is sent before the returned function is called
+func (h *hashImpl) Resize(_ context.Context, oldSizeLog uint64, newSizeLog uint64) func() error {
+	if oldSizeLog == newSizeLog {
+		return func() error { return nil }
+	}
+	if newSizeLog > uint64(h.options.maxResizeSizeLog) {
+		return func() error { return ErrResizeSizeLogTooLarge }
+	}
+
+	maintained := map[int]struct{}{}
+	for _, level := range adjacentSizeLogs(int(oldSizeLog)) {
+		maintained[level] = struct{}{}
+	}
+
+	deleter := &bucketDeleter{
+		root:      h,
+		batchSize: h.options.resizeBatchSize,
+	}
+	for _, level := range adjacentSizeLogs(int(newSizeLog)) {
+		if _, existed := maintained[level]; existed {
+			continue
+		}
+		deleter.sizeLogs = append(deleter.sizeLogs, level)
+	}
+	batchFn := deleter.nextBatch()
+
+	return func() error {
+		for batchFn != nil {
+			if err := batchFn(); err != nil {
+				return err
+			}
+			batchFn = deleter.nextBatch()
+		}
+		return h.pipeline.Delete(h.sizeLogKey)()
+	}
+}
+
+// bucketDeleter deletes all buckets of the size logs, at most batchSize deletes are queued at a time
+type bucketDeleter struct {
+	root      *hashImpl
+	batchSize int
+
+	sizeLogs []int
+	next     uint64 // index of the next bucket of sizeLogs[0]
+}
+
+// nextBatch returns nil when all buckets are deleted
+func (d *bucketDeleter) nextBatch() func() error {
+	if len(d.sizeLogs) == 0 {
+		return nil
+	}
+
+	fns := make([]func() error, 0, d.batchSize)
+	for len(fns) < d.batchSize && len(d.sizeLogs) > 0 {
+		sizeLog := d.sizeLogs[0]
+		hash := uint32(d.next << (32 - sizeLog))
+		fns = append(fns, d.root.pipeline.Delete(computeBucketKey(d.root.namespace, sizeLog, hash)))
+
+		d.next++
+		if d.next == 1<<sizeLog {
+			d.sizeLogs = d.sizeLogs[1:]
+			d.next = 0
+		}
+	}
+	return waitAll(fns)
+}
+
+// adjacentSizeLogs returns the size logs of buckets read by SelectEntries at the size log
+func adjacentSizeLogs(sizeLog int) []int {
+	var result []int
+	if sizeLog > 0 {
+		result = append(result, sizeLog-1)
+	}
+	result = append(result, sizeLog)
+	if sizeLog < maxSizeLog {
+		result = append(result, sizeLog+1)
+	}
+	return result
+}
+
+func waitAll(fns []func() error) func() error {
+	return func() error {
+		for _, fn := range fns {
+			if err := fn(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// InvalidateAfterWrite is the write-side invalidation sequence after committing changes of entries
+// to the backing database, with the size logs before and after the commit.
+// Buckets of the changed entries are invalidated at the old size log,
+// then the hash is resized, which deletes the other buckets readable at the new size log
+func InvalidateAfterWrite(
+	ctx context.Context, h Hash, oldSizeLog uint64, newSizeLog uint64, hashes []uint32,
+) func() error {
+	var fns []func() error
+	for _, hash := range hashes {
+		fns = append(fns, h.InvalidateEntry(ctx, oldSizeLog, hash))
+	}
+	entriesFn := waitAll(fns)
+	resizeFn := h.Resize(ctx, oldSizeLog, newSizeLog)
 
 	return func() error {
-		if err := fn1(); err != nil {
+		if err := entriesFn(); err != nil {
 			return err
 		}
-		return fn2()
+		return resizeFn()
 	}
 }
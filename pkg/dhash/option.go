@@ -4,6 +4,7 @@ import "time"
 
 type sessionOptions struct {
 	waitLeaseDurations []time.Duration
+	fallbackLimiter    *FallbackLimiter // nil when fallback to the database is disabled
 }
 
 func defaultSessionOptions() sessionOptions {
@@ -33,3 +34,158 @@ func WithWaitLeaseDurations(durations []time.Duration) SessionOption {
 		opts.waitLeaseDurations = durations
 	}
 }
+
+// WithFallbackToDB reads directly from the database without setting the cache after lease retries are exhausted.
+// The limiter should be shared between sessions, ErrLeaseNotGranted is returned when its limit is reached
+func WithFallbackToDB(limiter *FallbackLimiter) SessionOption {
+	return func(opts *sessionOptions) {
+		opts.fallbackLimiter = limiter
+	}
+}
+
+// ttlOptions for the expiration of values set by LeaseSet
+type ttlOptions struct {
+	ttl    uint32 // in seconds, zero means no expiration
+	jitter uint32 // in seconds, a random value in [0, jitter] is added to ttl to avoid synchronized expiry
+}
+
+func (o ttlOptions) compute(random func(n uint32) uint32) uint32 {
+	if o.ttl == 0 || o.jitter == 0 {
+		return o.ttl
+	}
+	return o.ttl + random(o.jitter+1)
+}
+
+// durationToSeconds rounds up to seconds
+func durationToSeconds(d time.Duration) uint32 {
+	return uint32((d + time.Second - 1) / time.Second)
+}
+
+type hashOptions struct {
+	sizeLogTTL ttlOptions
+	bucketTTL  ttlOptions
+
+	staleBuckets   bool
+	staleBucketTTL uint32
+
+	maxResizeSizeLog int // Resize returns ErrResizeSizeLogTooLarge for new size logs greater than this
+	resizeBatchSize  int // max number of bucket deletes queued at a time by Resize
+}
+
+const (
+	defaultMaxResizeSizeLog = 20
+	defaultResizeBatchSize  = 1000
+)
+
+func newHashOptions(options ...HashOption) hashOptions {
+	opts := hashOptions{
+		maxResizeSizeLog: defaultMaxResizeSizeLog,
+		resizeBatchSize:  defaultResizeBatchSize,
+	}
+	for _, fn := range options {
+		fn(&opts)
+	}
+	return opts
+}
+
+// HashOption ...
+type HashOption func(opts *hashOptions)
+
+// WithSizeLogTTL sets the expiration of the size log key, rounded up to seconds
+func WithSizeLogTTL(ttl time.Duration) HashOption {
+	return func(opts *hashOptions) {
+		opts.sizeLogTTL.ttl = durationToSeconds(ttl)
+	}
+}
+
+// WithBucketTTL sets the expiration of bucket keys, rounded up to seconds
+func WithBucketTTL(ttl time.Duration) HashOption {
+	return func(opts *hashOptions) {
+		opts.bucketTTL.ttl = durationToSeconds(ttl)
+	}
+}
+
+// WithHashTTLJitter adds a random duration in [0, jitter] to the TTLs of size log and bucket keys
+func WithHashTTLJitter(jitter time.Duration) HashOption {
+	return func(opts *hashOptions) {
+		opts.sizeLogTTL.jitter = durationToSeconds(jitter)
+		opts.bucketTTL.jitter = durationToSeconds(jitter)
+	}
+}
+
+// WithStaleBuckets makes InvalidateEntry mark buckets as stale instead of deleting them.
+// Readers get stale buckets immediately while a single lease holder refreshes them from the database,
+// stale buckets expire after ttl (rounded up to seconds), zero keeps the current expiration
+func WithStaleBuckets(ttl time.Duration) HashOption {
+	return func(opts *hashOptions) {
+		opts.staleBuckets = true
+		opts.staleBucketTTL = durationToSeconds(ttl)
+	}
+}
+
+// WithMaxResizeSizeLog limits the new size log accepted by Resize,
+// Resize deletes up to 2^(maxSizeLog + 1) buckets
+func WithMaxResizeSizeLog(maxSizeLog int) HashOption {
+	return func(opts *hashOptions) {
+		opts.maxResizeSizeLog = maxSizeLog
+	}
+}
+
+// WithResizeBatchSize sets the number of bucket deletes sent in a batch by Resize,
+// the next batch is sent only after the previous one finished
+func WithResizeBatchSize(size int) HashOption {
+	return func(opts *hashOptions) {
+		if size > 0 {
+			opts.resizeBatchSize = size
+		}
+	}
+}
+
+type storeOptions struct {
+	ttl         ttlOptions
+	negativeTTL ttlOptions
+}
+
+// defaultStoreNegativeTTL in seconds, not found values must expire for rows inserted later to be seen
+const defaultStoreNegativeTTL = 30
+
+func newStoreOptions(options ...StoreOption) storeOptions {
+	opts := storeOptions{}
+	for _, fn := range options {
+		fn(&opts)
+	}
+
+	if opts.negativeTTL.ttl == 0 {
+		opts.negativeTTL.ttl = defaultStoreNegativeTTL
+		if opts.ttl.ttl != 0 && opts.ttl.ttl < opts.negativeTTL.ttl {
+			opts.negativeTTL.ttl = opts.ttl.ttl
+		}
+	}
+	return opts
+}
+
+// StoreOption ...
+type StoreOption func(opts *storeOptions)
+
+// WithStoreTTL sets the expiration of values set by Store, rounded up to seconds
+func WithStoreTTL(ttl time.Duration) StoreOption {
+	return func(opts *storeOptions) {
+		opts.ttl.ttl = durationToSeconds(ttl)
+	}
+}
+
+// WithStoreNegativeTTL sets the expiration of not found values set by Store, rounded up to seconds.
+// Defaults to 30 seconds, or the TTL set by WithStoreTTL if it is shorter. Not found values always expire
+func WithStoreNegativeTTL(ttl time.Duration) StoreOption {
+	return func(opts *storeOptions) {
+		opts.negativeTTL.ttl = durationToSeconds(ttl)
+	}
+}
+
+// WithStoreTTLJitter adds a random duration in [0, jitter] to the TTLs of values set by Store
+func WithStoreTTLJitter(jitter time.Duration) StoreOption {
+	return func(opts *storeOptions) {
+		opts.ttl.jitter = durationToSeconds(jitter)
+		opts.negativeTTL.jitter = durationToSeconds(jitter)
+	}
+}
@@ -4,38 +4,62 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"sort"
 	"strconv"
 	"time"
 )
 
 type hashSelectAction struct {
-	root *hashImpl
-	ctx  context.Context
-	hash uint32
-
-	sizeLogFn      func() (LeaseGetOutput, error)
-	bucketFn1      func() (GetOutput, error)
-	bucketFn2      func() (GetOutput, error)
-	sizeLogDBFn    func() (uint64, error)
-	bucketLeaseGet func() (LeaseGetOutput, error)
-	entriesDBFn    func() ([]Entry, error)
+	root   *hashImpl
+	ctx    context.Context
+	hashes []uint32
+
+	sizeLogFn   func() (LeaseGetOutput, error)
+	sizeLogDBFn func() (uint64, error)
 
 	sizeLog        sql.NullInt64
 	sizeLogLeaseID uint64
-	bucketLeaseID  uint64
 
 	sizeLogWaitLeaseStarted   bool
 	sizeLogWaitLeaseDurations []time.Duration
 
-	bucketWaitLeaseStarted   bool
-	bucketWaitLeaseDurations []time.Duration
+	buckets     []*bucketSelectAction
+	bucketIndex map[uint32]*bucketSelectAction // by the begin of buckets
+
+	err error
+}
+
+// bucketSelectAction for the hashes in the same bucket of the size log
+type bucketSelectAction struct {
+	root   *hashSelectAction
+	begin  uint32
+	hashes []uint32
+
+	bucketFn1      func() (GetOutput, error)   // bucket of sizeLog - 1, for reading after the size log grows
+	bucketFn2      func() (GetOutput, error)   // bucket of sizeLog
+	bucketFns3     []func() (GetOutput, error) // buckets of sizeLog + 1 containing the hashes, after the size log shrinks
+	bucketLeaseGet func() (LeaseGetOutput, error)
+	bucketLeaseID  uint64
+
+	waitLeaseStarted   bool
+	waitLeaseDurations []time.Duration
 
-	results []Entry
-	err     error
+	entries       []Entry // all entries of the bucket
+	fromClientGet bool
+	err           error
 }
 
 //revive:disable:get-return
 
+func (h *hashSelectAction) abort(err error) {
+	h.err = err
+}
+
+func (b *bucketSelectAction) abort(err error) {
+	b.entries = nil
+	b.err = err
+}
+
 func (h *hashSelectAction) getSizeLogFromClient() {
 	h.root.sess.hashSizeLogAccessCount++
 
@@ -47,19 +71,63 @@ func computeBucketKey(ns string, sizeLog int, hash uint32) string {
 	return fmt.Sprintf("%s:%d:%08x", ns, sizeLog, startOfSlot(hash, sizeLog))
 }
 
+// getBuckets groups the hashes by the buckets of the size log, each bucket is got once
 func (h *hashSelectAction) getBuckets() {
 	if !h.sizeLog.Valid {
 		panic("Must be valid")
 	}
 
+	sizeLog := int(h.sizeLog.Int64)
+
+	h.buckets = nil
+	h.bucketIndex = map[uint32]*bucketSelectAction{}
+	for _, hash := range h.hashes {
+		begin := startOfSlot(hash, sizeLog)
+		b, existed := h.bucketIndex[begin]
+		if !existed {
+			b = &bucketSelectAction{root: h, begin: begin}
+			h.bucketIndex[begin] = b
+			h.buckets = append(h.buckets, b)
+		}
+		b.hashes = append(b.hashes, hash)
+	}
+
+	for _, b := range h.buckets {
+		b.getFromClient(sizeLog)
+	}
+}
+
+func (b *bucketSelectAction) getFromClient(sizeLog int) {
+	h := b.root
 	h.root.sess.hashBucketAccessCount++
 
-	sizeLog := int(h.sizeLog.Int64)
-	key1 := computeBucketKey(h.root.namespace, sizeLog-1, h.hash) // TODO Size Log = 0, Should Not Negative
-	key2 := computeBucketKey(h.root.namespace, sizeLog, h.hash)
+	// size log = 0 is a single bucket for the whole hash space, there is no bucket of the previous size log
+	b.bucketFn1 = nil
+	if sizeLog > 0 {
+		key1 := computeBucketKey(h.root.namespace, sizeLog-1, b.begin)
+		b.bucketFn1 = h.root.pipeline.Get(key1)
+	}
 
-	h.bucketFn1 = h.root.pipeline.Get(key1)
-	h.bucketFn2 = h.root.pipeline.Get(key2)
+	key2 := computeBucketKey(h.root.namespace, sizeLog, b.begin)
+	b.bucketFn2 = h.root.pipeline.Get(key2)
+
+	b.bucketFns3 = nil
+	if sizeLog < maxSizeLog {
+		var begins []uint32
+		for _, hash := range b.hashes {
+			begin := startOfSlot(hash, sizeLog+1)
+			if len(begins) > 0 && begins[0] == begin {
+				continue
+			}
+			begins = append(begins, begin)
+
+			key3 := computeBucketKey(h.root.namespace, sizeLog+1, begin)
+			b.bucketFns3 = append(b.bucketFns3, h.root.pipeline.Get(key3))
+			if len(begins) == 2 {
+				break // both halves of the bucket
+			}
+		}
+	}
 }
 
 func (h *hashSelectAction) handleMemSizeLogNotExisted() {
@@ -98,16 +166,22 @@ func (h *hashSelectAction) updateSizeLogFromDB(callback func(), redoCallback fun
 		h.err = err
 		return
 	}
+	if dbSizeLog > maxSizeLog {
+		h.err = invalidSizeLogError(int64(dbSizeLog))
+		return
+	}
 	h.handleNewSizeLog(int(dbSizeLog), callback, redoCallback)
 }
 
-func (h *hashSelectAction) handleSizeLogFromDB(callback func(), redoCallback func()) {
-	h.updateSizeLogFromDB(callback, redoCallback)
+// handleSizeLogFromDB always gets buckets again, because buckets got before the size log
+// might be deleted by a concurrent Resize, the size log key is deleted only after resizing
+func (h *hashSelectAction) handleSizeLogFromDB(_ func(), redoCallback func()) {
+	h.updateSizeLogFromDB(redoCallback, redoCallback)
 
 	h.root.pipeline.LeaseSet(
 		h.root.sizeLogKey,
 		[]byte(strconv.FormatUint(uint64(h.sizeLog.Int64), 10)),
-		h.sizeLogLeaseID, 0, // TODO Customize TTL
+		h.sizeLogLeaseID, h.root.options.sizeLogTTL.compute(h.root.sess.provider.random),
 	)
 }
 
@@ -143,17 +217,25 @@ func (h *hashSelectAction) handleSizeLogFromClientWithError(callback func(), red
 		}
 
 		if len(h.sizeLogWaitLeaseDurations) == 0 {
-			return ErrLeaseNotGranted
+			if !sess.acquireFallback() {
+				return ErrLeaseNotGranted
+			}
+			h.sizeLogDBFn = h.root.db.GetSizeLog(h.ctx)
+			sess.addNextCall(func() {
+				defer sess.releaseFallback()
+				h.updateSizeLogFromDB(redoCallback, redoCallback)
+			})
+			return nil
 		}
 		duration := h.sizeLogWaitLeaseDurations[0]
 		h.sizeLogWaitLeaseDurations = h.sizeLogWaitLeaseDurations[1:]
 
-		h.root.sess.addDelayedCall(duration, func() {
+		h.root.sess.addDelayedCall(h.ctx, duration, func() {
 			h.getSizeLogFromClient()
 			h.root.sess.addNextCall(func() {
 				h.handleSizeLogFromClient(callback, redoCallback)
 			})
-		})
+		}, h.abort)
 		return nil
 	}
 
@@ -161,6 +243,9 @@ func (h *hashSelectAction) handleSizeLogFromClientWithError(callback func(), red
 	if err != nil {
 		return err
 	}
+	if sizeLogValue < 0 || sizeLogValue > maxSizeLog {
+		return invalidSizeLogError(sizeLogValue)
+	}
 	sizeLog := int(sizeLogValue)
 	h.handleNewSizeLog(sizeLog, callback, redoCallback)
 	return nil
@@ -182,123 +267,269 @@ func (h *hashSelectAction) handleMemSizeLogExisted() {
 }
 
 func (h *hashSelectAction) handleBuckets() {
-	h.results, h.err = h.handleBucketsWithOutput()
-}
-
-func (h *hashSelectAction) handleBucketsWithOutput() ([]Entry, error) {
-	bucket1Output, err := h.bucketFn1()
-	if err != nil {
-		return nil, err
+	var missed []*bucketSelectAction
+	for _, b := range h.buckets {
+		found, err := b.handleGetOutputs()
+		if err != nil {
+			b.err = err
+			continue
+		}
+		if !found {
+			h.root.sess.hashBucketMissCount++
+			missed = append(missed, b)
+		}
 	}
+	h.leaseGetBuckets(missed)
+}
 
+// handleGetOutputs ignores stale buckets, a lease get is needed for refreshing them
+func (b *bucketSelectAction) handleGetOutputs() (bool, error) {
 	var data []byte
-	if bucket1Output.Found {
-		data = bucket1Output.Data
+	if b.bucketFn1 != nil {
+		bucket1Output, err := b.bucketFn1()
+		if err != nil {
+			return false, err
+		}
+		if bucket1Output.Found && !bucket1Output.Stale {
+			data = bucket1Output.Data
+		}
 	}
 
-	bucket2Output, err := h.bucketFn2()
+	bucket2Output, err := b.bucketFn2()
 	if err != nil {
-		return nil, err
+		return false, err
 	}
-	if bucket2Output.Found {
+	if bucket2Output.Found && !bucket2Output.Stale {
 		data = bucket2Output.Data
 	}
 
-	if len(data) == 0 {
-		h.root.sess.hashBucketMissCount++
+	// the buckets of the next size log are used only when all of them are found
+	var nextData [][]byte
+	for _, fn := range b.bucketFns3 {
+		bucket3Output, err := fn()
+		if err != nil {
+			return false, err
+		}
+		if bucket3Output.Found && !bucket3Output.Stale {
+			nextData = append(nextData, bucket3Output.Data)
+		}
+	}
 
-		h.getBucketFromCacheClientForLeasing()
-		return nil, nil
+	if len(data) > 0 {
+		b.entries, err = unmarshalEntries(data)
+		b.fromClientGet = true
+		return err == nil, err
 	}
 
-	entries, err := unmarshalEntries(data)
-	if err != nil {
-		return nil, err
+	if len(nextData) == 0 || len(nextData) < len(b.bucketFns3) {
+		return false, nil
 	}
 
-	var result []Entry
-	for _, entry := range entries {
-		if entry.Hash != h.hash {
-			continue
+	var entries []Entry
+	for _, d := range nextData {
+		nextEntries, err := unmarshalEntries(d)
+		if err != nil {
+			return false, err
 		}
-		result = append(result, entry)
+		entries = append(entries, nextEntries...)
 	}
-	return result, nil
+	b.entries = entries
+	b.fromClientGet = true
+	return true, nil
 }
 
-func (h *hashSelectAction) getBucketFromCacheClientForLeasing() {
-	h.root.sess.hashBucketAccessCount++
+func (h *hashSelectAction) leaseGetBuckets(buckets []*bucketSelectAction) {
+	if len(buckets) == 0 {
+		return
+	}
+
+	sizeLog := int(h.sizeLog.Int64)
+	for _, b := range buckets {
+		h.root.sess.hashBucketAccessCount++
+
+		key := computeBucketKey(h.root.namespace, sizeLog, b.begin)
+		b.bucketLeaseGet = h.root.pipeline.LeaseGet(key)
+	}
 
-	key := computeBucketKey(h.root.namespace, int(h.sizeLog.Int64), h.hash)
-	h.bucketLeaseGet = h.root.pipeline.LeaseGet(key)
 	h.root.sess.addNextCall(func() {
-		h.handleGetBucketFromDB()
+		h.handleBucketLeaseGets(buckets)
 	})
 }
 
-func (h *hashSelectAction) handleGetBucketFromDB() {
-	h.err = h.handleGetBucketFromDBWithError()
-}
-
-func (h *hashSelectAction) handleGetBucketFromDBWithError() error {
-	bucketGetOutput, err := h.bucketLeaseGet()
-	if err != nil {
-		return err
-	}
+// handleBucketLeaseGets selects the buckets that are granted in the same call,
+// for the backing database to merge them into one query
+func (h *hashSelectAction) handleBucketLeaseGets(buckets []*bucketSelectAction) {
+	var granted []*bucketSelectAction
+	var fallback []*bucketSelectAction
 
-	if bucketGetOutput.Type == LeaseGetTypeOK {
-		entries, err := unmarshalEntries(bucketGetOutput.Data)
+	for _, b := range buckets {
+		output, err := b.bucketLeaseGet()
 		if err != nil {
-			return err
+			b.err = err
+			continue
 		}
-		h.results = entries
-		return nil
-	}
 
-	h.root.sess.hashBucketMissCount++
+		// stale buckets are returned immediately, the lease holder is refreshing them
+		if output.Type == LeaseGetTypeOK || output.Type == LeaseGetTypeStale {
+			b.entries, b.err = unmarshalEntries(output.Data)
+			continue
+		}
 
-	if bucketGetOutput.Type == LeaseGetTypeRejected {
-		sess := h.root.sess
+		h.root.sess.hashBucketMissCount++
 
-		if !h.bucketWaitLeaseStarted {
-			h.bucketWaitLeaseStarted = true
-			h.bucketWaitLeaseDurations = sess.options.waitLeaseDurations
+		if output.Type == LeaseGetTypeRejected {
+			if b.waitLease() {
+				continue
+			}
+			if !h.root.sess.acquireFallback() {
+				b.err = ErrLeaseNotGranted
+				continue
+			}
+			fallback = append(fallback, b)
+			continue
 		}
 
-		if len(h.bucketWaitLeaseDurations) == 0 {
-			return ErrLeaseNotGranted
-		}
-		duration := h.bucketWaitLeaseDurations[0]
-		h.bucketWaitLeaseDurations = h.bucketWaitLeaseDurations[1:]
+		b.bucketLeaseID = output.LeaseID
+		granted = append(granted, b)
+	}
 
-		sess.addDelayedCall(duration, func() {
-			h.getBucketFromCacheClientForLeasing()
-		})
-		return nil
+	h.selectBucketsFromDB(granted, true)
+
+	// does NOT set the buckets because the leases are held by other clients
+	h.selectBucketsFromDB(fallback, false)
+}
+
+// waitLease returns false when lease retries are exhausted
+func (b *bucketSelectAction) waitLease() bool {
+	h := b.root
+	sess := h.root.sess
+
+	if !b.waitLeaseStarted {
+		b.waitLeaseStarted = true
+		b.waitLeaseDurations = sess.options.waitLeaseDurations
+	}
+
+	if len(b.waitLeaseDurations) == 0 {
+		return false
+	}
+	duration := b.waitLeaseDurations[0]
+	b.waitLeaseDurations = b.waitLeaseDurations[1:]
+
+	sess.addDelayedCall(h.ctx, duration, func() {
+		h.leaseGetBuckets([]*bucketSelectAction{b})
+	}, b.abort)
+	return true
+}
+
+// selectBucketsFromDB merges adjacent buckets into a single hash range,
+// the fallback is released after the select if leased = false
+func (h *hashSelectAction) selectBucketsFromDB(buckets []*bucketSelectAction, leased bool) {
+	if len(buckets) == 0 {
+		return
 	}
 
-	h.bucketLeaseID = bucketGetOutput.LeaseID
+	sizeLog := int(h.sizeLog.Int64)
+
+	sorted := make([]*bucketSelectAction, len(buckets))
+	copy(sorted, buckets)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].begin < sorted[j].begin
+	})
+
+	var groups [][]*bucketSelectAction
+	for _, b := range sorted {
+		if len(groups) > 0 {
+			last := groups[len(groups)-1]
+			end := nextSlot(last[len(last)-1].begin, sizeLog)
+			if end.Valid && end.Num == b.begin {
+				groups[len(groups)-1] = append(last, b)
+				continue
+			}
+		}
+		groups = append(groups, []*bucketSelectAction{b})
+	}
 
-	begin := startOfSlot(h.hash, int(h.sizeLog.Int64))
-	end := nextSlot(h.hash, int(h.sizeLog.Int64))
-	h.entriesDBFn = h.root.db.SelectEntries(h.ctx, begin, end)
+	entriesFns := make([]func() ([]Entry, error), 0, len(groups))
+	for _, group := range groups {
+		end := nextSlot(group[len(group)-1].begin, sizeLog)
+		entriesFns = append(entriesFns, h.root.db.SelectEntries(h.ctx, group[0].begin, end))
+	}
 
 	h.root.sess.addNextCall(func() {
-		h.handleBucketDataFromDB()
+		for i, group := range groups {
+			h.handleBucketsDataFromDB(group, entriesFns[i], leased)
+		}
 	})
-	return nil
 }
 
-func (h *hashSelectAction) handleBucketDataFromDB() {
-	h.results, h.err = h.handleBucketDataFromDBWithOutput()
+func (h *hashSelectAction) handleBucketsDataFromDB(
+	buckets []*bucketSelectAction, entriesFn func() ([]Entry, error), leased bool,
+) {
+	dbEntries, err := entriesFn()
+	if !leased {
+		for range buckets {
+			h.root.sess.releaseFallback()
+		}
+	}
+	if err != nil {
+		for _, b := range buckets {
+			b.err = err
+		}
+		return
+	}
+
+	sizeLog := int(h.sizeLog.Int64)
+	for _, b := range buckets {
+		b.entries = entriesInRange(dbEntries, b.begin, nextSlot(b.begin, sizeLog))
+		if !leased {
+			continue
+		}
+
+		key := computeBucketKey(h.root.namespace, sizeLog, b.begin)
+		ttl := h.root.options.bucketTTL.compute(h.root.sess.provider.random)
+		h.root.pipeline.LeaseSet(key, marshalEntries(b.entries), b.bucketLeaseID, ttl)
+	}
 }
 
-func (h *hashSelectAction) handleBucketDataFromDBWithOutput() ([]Entry, error) {
-	dbEntries, err := h.entriesDBFn()
-	if err != nil {
-		return nil, err
+func entriesInRange(entries []Entry, begin uint32, end NullUint32) []Entry {
+	var result []Entry
+	for _, entry := range entries {
+		if entry.Hash < begin {
+			continue
+		}
+		if end.Valid && entry.Hash >= end.Num {
+			continue
+		}
+		result = append(result, entry)
+	}
+	return result
+}
+
+// getResults returns the entries of each hash, in the order of the hashes.
+// If exact = false, entries of other hashes are NOT removed from the buckets got by leases
+func (h *hashSelectAction) getResults(exact bool) ([][]Entry, error) {
+	if h.err != nil {
+		return nil, h.err
+	}
+	for _, b := range h.buckets {
+		if b.err != nil {
+			return nil, b.err
+		}
+	}
+
+	sizeLog := int(h.sizeLog.Int64)
+	results := make([][]Entry, len(h.hashes))
+	for i, hash := range h.hashes {
+		b := h.bucketIndex[startOfSlot(hash, sizeLog)]
+		if !exact && !b.fromClientGet {
+			results[i] = b.entries
+			continue
+		}
+		for _, entry := range b.entries {
+			if entry.Hash == hash {
+				results[i] = append(results[i], entry)
+			}
+		}
 	}
-	key := computeBucketKey(h.root.namespace, int(h.sizeLog.Int64), h.hash)
-	h.root.pipeline.LeaseSet(key, marshalEntries(dbEntries), h.bucketLeaseID, 0) // TODO TTL
-	return dbEntries, nil
+	return results, nil
 }
@@ -2,11 +2,13 @@ package dhash
 
 import (
 	"context"
+	"errors"
 	"time"
 )
 
 type storeImpl struct {
 	sess     *sessionImpl
+	options  storeOptions
 	db       StoreDatabase
 	pipeline CachePipeline
 }
@@ -21,10 +23,17 @@ type storeGetAction struct {
 	leaseWaitStarted   bool
 	leaseWaitDurations []time.Duration
 
+	invalidValueDeleted bool
+
 	data []byte
 	err  error
 }
 
+func (s *storeGetAction) abort(err error) {
+	s.data = nil
+	s.err = err
+}
+
 func (s *storeGetAction) handleLeaseGet() {
 	s.data, s.err = s.handleLeaseGetWithOutput()
 }
@@ -41,13 +50,20 @@ func (s *storeGetAction) handleLeaseGetWithOutput() ([]byte, error) {
 		dbFn := s.root.db.Get(s.ctx, s.key)
 		s.root.sess.addNextCall(func() {
 			dbData, err := dbFn()
+			if errors.Is(err, ErrNotFound) {
+				s.err = ErrNotFound
+				ttl := s.root.options.negativeTTL.compute(s.root.sess.provider.random)
+				s.root.pipeline.LeaseSet(s.key, marshalStoreValue(nil, false), output.LeaseID, ttl)
+				return
+			}
 			if err != nil {
 				s.err = err
 				s.root.pipeline.Delete(s.key)
 				return
 			}
 			s.data = dbData
-			s.root.pipeline.LeaseSet(s.key, s.data, output.LeaseID, 0) // TODO TTL
+			ttl := s.root.options.ttl.compute(s.root.sess.provider.random)
+			s.root.pipeline.LeaseSet(s.key, marshalStoreValue(s.data, true), output.LeaseID, ttl)
 		})
 		return nil, nil
 	}
@@ -63,20 +79,60 @@ func (s *storeGetAction) handleLeaseGetWithOutput() ([]byte, error) {
 		}
 
 		if len(s.leaseWaitDurations) == 0 {
-			return nil, ErrLeaseNotGranted
+			if !sess.acquireFallback() {
+				return nil, ErrLeaseNotGranted
+			}
+			s.fallbackGetFromDB()
+			return nil, nil
 		}
 		duration := s.leaseWaitDurations[0]
 		s.leaseWaitDurations = s.leaseWaitDurations[1:]
 
-		s.leaseGetFn = s.root.pipeline.LeaseGet(s.key)
+		// lease get after waiting, an aborted retry never holds a lease
+		sess.addDelayedCall(s.ctx, duration, func() {
+			s.leaseGetFn = s.root.pipeline.LeaseGet(s.key)
+			sess.addNextCall(func() {
+				s.handleLeaseGet()
+			})
+		}, s.abort)
+		return nil, nil
+	}
+
+	data, found, err := unmarshalStoreValue(output.Data)
+	if err != nil {
+		// values in an unknown format (e.g. written by older versions) are deleted and treated as cache misses
+		if s.invalidValueDeleted {
+			return nil, err
+		}
+		s.invalidValueDeleted = true
 
-		sess.addDelayedCall(duration, func() {
+		s.root.pipeline.Delete(s.key)
+		s.leaseGetFn = s.root.pipeline.LeaseGet(s.key)
+		s.root.sess.addNextCall(func() {
 			s.handleLeaseGet()
 		})
 		return nil, nil
 	}
+	if !found {
+		return nil, ErrNotFound
+	}
+	return data, nil
+}
+
+// fallbackGetFromDB does NOT set the cache because the lease is held by another client
+func (s *storeGetAction) fallbackGetFromDB() {
+	sess := s.root.sess
+	dbFn := s.root.db.Get(s.ctx, s.key)
+
+	sess.addNextCall(func() {
+		defer sess.releaseFallback()
 
-	return output.Data, nil
+		data, err := dbFn()
+		if errors.Is(err, ErrNotFound) {
+			err = ErrNotFound
+		}
+		s.data, s.err = data, err
+	})
 }
 
 // Get ...
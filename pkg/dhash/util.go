@@ -3,6 +3,7 @@ package dhash
 import (
 	"bytes"
 	"encoding/binary"
+	"errors"
 	"fmt"
 )
 
@@ -74,15 +75,60 @@ func marshalEntries(entries []Entry) []byte {
 	return buf.Bytes()
 }
 
+// store values are prefixed by a byte to distinguish not found keys from empty data
+const (
+	storeValueNotFound byte = 0
+	storeValueFound    byte = 1
+)
+
+func marshalStoreValue(data []byte, found bool) []byte {
+	if !found {
+		return []byte{storeValueNotFound}
+	}
+	result := make([]byte, 0, len(data)+1)
+	result = append(result, storeValueFound)
+	return append(result, data...)
+}
+
+func unmarshalStoreValue(value []byte) (data []byte, found bool, err error) {
+	if len(value) == 0 {
+		return nil, false, errors.New("unmarshal store value: missing prefix")
+	}
+	switch value[0] {
+	case storeValueNotFound:
+		return nil, false, nil
+	case storeValueFound:
+		return value[1:], true, nil
+	default:
+		return nil, false, fmt.Errorf("unmarshal store value: invalid prefix %d", value[0])
+	}
+}
+
 const maxUint32 = 0xffffffff
 
+// maxSizeLog is the size log of hash tables with one bucket for each hash value.
+// A hash table has 2^sizeLog buckets, size log = 0 means a single bucket for the whole hash space
+const maxSizeLog = 32
+
+func invalidSizeLogError(sizeLog int64) error {
+	return fmt.Errorf("invalid size log: %d", sizeLog)
+}
+
+// startOfSlot returns the first hash value of the bucket containing the hash
 func startOfSlot(hash uint32, sizeLog int) uint32 {
+	if sizeLog == 0 {
+		return 0
+	}
 	mask := uint32(maxUint32 << (32 - sizeLog))
 	return hash & mask
 }
 
-// TODO Unit Test
+// nextSlot returns the first hash value of the next bucket, null when the hash is in the last bucket
 func nextSlot(hash uint32, sizeLog int) NullUint32 {
+	if sizeLog == 0 {
+		return NullUint32{}
+	}
+
 	bitOffset := 32 - sizeLog
 	index := hash >> bitOffset
 	max := uint32(maxUint32) >> bitOffset
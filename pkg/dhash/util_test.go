@@ -87,6 +87,28 @@ func TestUnmarshal_Error__Missing_Data(t *testing.T) {
 	assert.Equal(t, errors.New("unmarshal entries: missing data"), err)
 }
 
+func TestMarshalUnmarshal_Store_Value(t *testing.T) {
+	data, found, err := unmarshalStoreValue(marshalStoreValue([]byte{10, 12}, true))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, found)
+	assert.Equal(t, []byte{10, 12}, data)
+
+	data, found, err = unmarshalStoreValue(marshalStoreValue(nil, true))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, found)
+	assert.Equal(t, []byte{}, data)
+
+	data, found, err = unmarshalStoreValue(marshalStoreValue(nil, false))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, false, found)
+	assert.Nil(t, data)
+}
+
+func TestUnmarshal_Store_Value_Error__Missing_Prefix(t *testing.T) {
+	_, _, err := unmarshalStoreValue(nil)
+	assert.Equal(t, errors.New("unmarshal store value: missing prefix"), err)
+}
+
 func TestStartOfSlot(t *testing.T) {
 	hash := startOfSlot(0xf2345678, 2)
 	assert.Equal(t, uint32(0xc0000000), hash)
@@ -96,12 +118,84 @@ func TestStartOfSlot(t *testing.T) {
 
 	hash = startOfSlot(0xf2345678, 0)
 	assert.Equal(t, uint32(0), hash)
+
+	hash = startOfSlot(0xf2345678, 1)
+	assert.Equal(t, uint32(0x80000000), hash)
+
+	hash = startOfSlot(0xf2345678, 32)
+	assert.Equal(t, uint32(0xf2345678), hash)
+}
+
+func TestNextSlot(t *testing.T) {
+	table := []struct {
+		name    string
+		hash    uint32
+		sizeLog int
+		next    NullUint32
+	}{
+		{
+			name:    "size-log-zero",
+			hash:    0x12345678,
+			sizeLog: 0,
+			next:    NullUint32{},
+		},
+		{
+			name:    "size-log-zero-max-hash",
+			hash:    0xffffffff,
+			sizeLog: 0,
+			next:    NullUint32{},
+		},
+		{
+			name:    "size-log-one-first-half",
+			hash:    0x12345678,
+			sizeLog: 1,
+			next:    newNullUint32(0x80000000),
+		},
+		{
+			name:    "size-log-one-second-half",
+			hash:    0x92345678,
+			sizeLog: 1,
+			next:    NullUint32{},
+		},
+		{
+			name:    "middle-slot",
+			hash:    0xd2345678,
+			sizeLog: 4,
+			next:    newNullUint32(0xe0000000),
+		},
+		{
+			name:    "last-slot",
+			hash:    0xf2345678,
+			sizeLog: 4,
+			next:    NullUint32{},
+		},
+		{
+			name:    "max-size-log",
+			hash:    0xf2345678,
+			sizeLog: 32,
+			next:    newNullUint32(0xf2345679),
+		},
+		{
+			name:    "max-size-log-max-hash",
+			hash:    0xffffffff,
+			sizeLog: 32,
+			next:    NullUint32{},
+		},
+	}
+	for _, e := range table {
+		t.Run(e.name, func(t *testing.T) {
+			assert.Equal(t, e.next, nextSlot(e.hash, e.sizeLog))
+		})
+	}
 }
 
 func TestComputeBudgetKey(t *testing.T) {
 	key := computeBucketKey("ns", 0, 0)
 	assert.Equal(t, "ns:0:00000000", key)
 
+	key = computeBucketKey("ns", 0, 0x1234abcd)
+	assert.Equal(t, "ns:0:00000000", key)
+
 	key = computeBucketKey("ns", 14, 0x1234abcd)
 	assert.Equal(t, "ns:14:12340000", key)
 }
@@ -1,8 +1,35 @@
 package util
 
-import "github.com/twmb/murmur3"
+import (
+	"github.com/twmb/murmur3"
+	"strconv"
+)
 
 // HashFunc ...
 func HashFunc(s string) uint32 {
 	return murmur3.Sum32([]byte(s))
 }
+
+func campaignCodeHash(campaignID int64, code string) uint32 {
+	return HashFunc(strconv.FormatInt(campaignID, 10) + ":" + code)
+}
+
+// CampaignMerchantHash computes the hash of the pair (campaign id, merchant code)
+func CampaignMerchantHash(campaignID int64, merchantCode string) uint32 {
+	return campaignCodeHash(campaignID, merchantCode)
+}
+
+// CampaignBankHash computes the hash of the pair (campaign id, bank code)
+func CampaignBankHash(campaignID int64, bankCode string) uint32 {
+	return campaignCodeHash(campaignID, bankCode)
+}
+
+// CampaignCustomerHash computes the hash of the pair (campaign id, phone)
+func CampaignCustomerHash(campaignID int64, phone string) uint32 {
+	return campaignCodeHash(campaignID, phone)
+}
+
+// BlacklistTerminalHash computes the hash of the pair (merchant code, terminal code)
+func BlacklistTerminalHash(merchantCode string, terminalCode string) uint32 {
+	return HashFunc(merchantCode + ":" + terminalCode)
+}
@@ -8,6 +8,7 @@ package promopb
 
 import (
 	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	wrappers "github.com/golang/protobuf/ptypes/wrappers"
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
@@ -22,6 +23,108 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// CheckReason ...
+type CheckReason int32
+
+const (
+	CheckReason_CHECK_REASON_UNSPECIFIED        CheckReason = 0
+	CheckReason_OK                              CheckReason = 1
+	CheckReason_CUSTOMER_BLACKLISTED            CheckReason = 2
+	CheckReason_MERCHANT_BLACKLISTED            CheckReason = 3
+	CheckReason_TERMINAL_BLACKLISTED            CheckReason = 4
+	CheckReason_VOUCHER_NOT_FOUND               CheckReason = 5
+	CheckReason_CAMPAIGN_INACTIVE               CheckReason = 6
+	CheckReason_CAMPAIGN_NOT_STARTED            CheckReason = 7
+	CheckReason_CAMPAIGN_ENDED                  CheckReason = 8
+	CheckReason_NO_APPLICABLE_BENEFIT           CheckReason = 9
+	CheckReason_MERCHANT_NOT_ELIGIBLE           CheckReason = 10
+	CheckReason_TERMINAL_NOT_ELIGIBLE           CheckReason = 11
+	CheckReason_BANK_NOT_ELIGIBLE               CheckReason = 12
+	CheckReason_CUSTOMER_NOT_ELIGIBLE           CheckReason = 13
+	CheckReason_BUDGET_EXHAUSTED                CheckReason = 14
+	CheckReason_CAMPAIGN_USAGE_EXHAUSTED        CheckReason = 15
+	CheckReason_CUSTOMER_USAGE_EXHAUSTED        CheckReason = 16
+	CheckReason_CUSTOMER_PERIOD_USAGE_EXHAUSTED CheckReason = 17
+	// infrastructure failures, the input should be retried
+	CheckReason_INTERNAL_ERROR   CheckReason = 18
+	CheckReason_INVALID_ARGUMENT CheckReason = 19
+)
+
+// Enum value maps for CheckReason.
+var (
+	CheckReason_name = map[int32]string{
+		0:  "CHECK_REASON_UNSPECIFIED",
+		1:  "OK",
+		2:  "CUSTOMER_BLACKLISTED",
+		3:  "MERCHANT_BLACKLISTED",
+		4:  "TERMINAL_BLACKLISTED",
+		5:  "VOUCHER_NOT_FOUND",
+		6:  "CAMPAIGN_INACTIVE",
+		7:  "CAMPAIGN_NOT_STARTED",
+		8:  "CAMPAIGN_ENDED",
+		9:  "NO_APPLICABLE_BENEFIT",
+		10: "MERCHANT_NOT_ELIGIBLE",
+		11: "TERMINAL_NOT_ELIGIBLE",
+		12: "BANK_NOT_ELIGIBLE",
+		13: "CUSTOMER_NOT_ELIGIBLE",
+		14: "BUDGET_EXHAUSTED",
+		15: "CAMPAIGN_USAGE_EXHAUSTED",
+		16: "CUSTOMER_USAGE_EXHAUSTED",
+		17: "CUSTOMER_PERIOD_USAGE_EXHAUSTED",
+		18: "INTERNAL_ERROR",
+		19: "INVALID_ARGUMENT",
+	}
+	CheckReason_value = map[string]int32{
+		"CHECK_REASON_UNSPECIFIED":        0,
+		"OK":                              1,
+		"CUSTOMER_BLACKLISTED":            2,
+		"MERCHANT_BLACKLISTED":            3,
+		"TERMINAL_BLACKLISTED":            4,
+		"VOUCHER_NOT_FOUND":               5,
+		"CAMPAIGN_INACTIVE":               6,
+		"CAMPAIGN_NOT_STARTED":            7,
+		"CAMPAIGN_ENDED":                  8,
+		"NO_APPLICABLE_BENEFIT":           9,
+		"MERCHANT_NOT_ELIGIBLE":           10,
+		"TERMINAL_NOT_ELIGIBLE":           11,
+		"BANK_NOT_ELIGIBLE":               12,
+		"CUSTOMER_NOT_ELIGIBLE":           13,
+		"BUDGET_EXHAUSTED":                14,
+		"CAMPAIGN_USAGE_EXHAUSTED":        15,
+		"CUSTOMER_USAGE_EXHAUSTED":        16,
+		"CUSTOMER_PERIOD_USAGE_EXHAUSTED": 17,
+		"INTERNAL_ERROR":                  18,
+		"INVALID_ARGUMENT":                19,
+	}
+)
+
+func (x CheckReason) Enum() *CheckReason {
+	p := new(CheckReason)
+	*p = x
+	return p
+}
+
+func (x CheckReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CheckReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_promo_proto_enumTypes[0].Descriptor()
+}
+
+func (CheckReason) Type() protoreflect.EnumType {
+	return &file_promo_proto_enumTypes[0]
+}
+
+func (x CheckReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CheckReason.Descriptor instead.
+func (CheckReason) EnumDescriptor() ([]byte, []int) {
+	return file_promo_proto_rawDescGZIP(), []int{0}
+}
+
 // BlacklistCustomerData ...
 type BlacklistCustomerData struct {
 	state         protoimpl.MessageState
@@ -182,20 +285,1006 @@ func (x *BlacklistMerchantData) GetEndTime() *timestamp.Timestamp {
 	return nil
 }
 
+// BlacklistTerminalData ...
+type BlacklistTerminalData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hash         uint32               `protobuf:"varint,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	MerchantCode string               `protobuf:"bytes,2,opt,name=merchant_code,json=merchantCode,proto3" json:"merchant_code,omitempty"`
+	TerminalCode string               `protobuf:"bytes,3,opt,name=terminal_code,json=terminalCode,proto3" json:"terminal_code,omitempty"`
+	Status       uint32               `protobuf:"varint,4,opt,name=status,proto3" json:"status,omitempty"`
+	StartTime    *timestamp.Timestamp `protobuf:"bytes,5,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime      *timestamp.Timestamp `protobuf:"bytes,6,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+}
+
+func (x *BlacklistTerminalData) Reset() {
+	*x = BlacklistTerminalData{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_promo_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlacklistTerminalData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlacklistTerminalData) ProtoMessage() {}
+
+func (x *BlacklistTerminalData) ProtoReflect() protoreflect.Message {
+	mi := &file_promo_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlacklistTerminalData.ProtoReflect.Descriptor instead.
+func (*BlacklistTerminalData) Descriptor() ([]byte, []int) {
+	return file_promo_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *BlacklistTerminalData) GetHash() uint32 {
+	if x != nil {
+		return x.Hash
+	}
+	return 0
+}
+
+func (x *BlacklistTerminalData) GetMerchantCode() string {
+	if x != nil {
+		return x.MerchantCode
+	}
+	return ""
+}
+
+func (x *BlacklistTerminalData) GetTerminalCode() string {
+	if x != nil {
+		return x.TerminalCode
+	}
+	return ""
+}
+
+func (x *BlacklistTerminalData) GetStatus() uint32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *BlacklistTerminalData) GetStartTime() *timestamp.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *BlacklistTerminalData) GetEndTime() *timestamp.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+// CampaignData ...
+type CampaignData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id               int64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name             string                `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Status           uint32                `protobuf:"varint,3,opt,name=status,proto3" json:"status,omitempty"`
+	Type             uint32                `protobuf:"varint,4,opt,name=type,proto3" json:"type,omitempty"`
+	VoucherHash      uint32                `protobuf:"varint,5,opt,name=voucher_hash,json=voucherHash,proto3" json:"voucher_hash,omitempty"`
+	VoucherCode      string                `protobuf:"bytes,6,opt,name=voucher_code,json=voucherCode,proto3" json:"voucher_code,omitempty"`
+	StartTime        *timestamp.Timestamp  `protobuf:"bytes,7,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime          *timestamp.Timestamp  `protobuf:"bytes,8,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	BudgetMax        *wrappers.StringValue `protobuf:"bytes,9,opt,name=budget_max,json=budgetMax,proto3" json:"budget_max,omitempty"`
+	CampaignUsageMax *wrappers.Int64Value  `protobuf:"bytes,10,opt,name=campaign_usage_max,json=campaignUsageMax,proto3" json:"campaign_usage_max,omitempty"`
+	// zero means unlimited
+	CustomerUsageMax       int64                `protobuf:"varint,11,opt,name=customer_usage_max,json=customerUsageMax,proto3" json:"customer_usage_max,omitempty"`
+	PeriodUsageType        uint32               `protobuf:"varint,12,opt,name=period_usage_type,json=periodUsageType,proto3" json:"period_usage_type,omitempty"`
+	PeriodCustomerUsageMax *wrappers.Int64Value `protobuf:"bytes,13,opt,name=period_customer_usage_max,json=periodCustomerUsageMax,proto3" json:"period_customer_usage_max,omitempty"`
+	PeriodTermType         uint32               `protobuf:"varint,14,opt,name=period_term_type,json=periodTermType,proto3" json:"period_term_type,omitempty"`
+	AllMerchants           bool                 `protobuf:"varint,15,opt,name=all_merchants,json=allMerchants,proto3" json:"all_merchants,omitempty"`
+}
+
+func (x *CampaignData) Reset() {
+	*x = CampaignData{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_promo_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CampaignData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CampaignData) ProtoMessage() {}
+
+func (x *CampaignData) ProtoReflect() protoreflect.Message {
+	mi := &file_promo_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CampaignData.ProtoReflect.Descriptor instead.
+func (*CampaignData) Descriptor() ([]byte, []int) {
+	return file_promo_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CampaignData) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *CampaignData) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CampaignData) GetStatus() uint32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *CampaignData) GetType() uint32 {
+	if x != nil {
+		return x.Type
+	}
+	return 0
+}
+
+func (x *CampaignData) GetVoucherHash() uint32 {
+	if x != nil {
+		return x.VoucherHash
+	}
+	return 0
+}
+
+func (x *CampaignData) GetVoucherCode() string {
+	if x != nil {
+		return x.VoucherCode
+	}
+	return ""
+}
+
+func (x *CampaignData) GetStartTime() *timestamp.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *CampaignData) GetEndTime() *timestamp.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *CampaignData) GetBudgetMax() *wrappers.StringValue {
+	if x != nil {
+		return x.BudgetMax
+	}
+	return nil
+}
+
+func (x *CampaignData) GetCampaignUsageMax() *wrappers.Int64Value {
+	if x != nil {
+		return x.CampaignUsageMax
+	}
+	return nil
+}
+
+func (x *CampaignData) GetCustomerUsageMax() int64 {
+	if x != nil {
+		return x.CustomerUsageMax
+	}
+	return 0
+}
+
+func (x *CampaignData) GetPeriodUsageType() uint32 {
+	if x != nil {
+		return x.PeriodUsageType
+	}
+	return 0
+}
+
+func (x *CampaignData) GetPeriodCustomerUsageMax() *wrappers.Int64Value {
+	if x != nil {
+		return x.PeriodCustomerUsageMax
+	}
+	return nil
+}
+
+func (x *CampaignData) GetPeriodTermType() uint32 {
+	if x != nil {
+		return x.PeriodTermType
+	}
+	return 0
+}
+
+func (x *CampaignData) GetAllMerchants() bool {
+	if x != nil {
+		return x.AllMerchants
+	}
+	return false
+}
+
+// CampaignBenefitData ...
+type CampaignBenefitData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id                int64                `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	CampaignId        int64                `protobuf:"varint,2,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
+	StartTime         *timestamp.Timestamp `protobuf:"bytes,3,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime           *timestamp.Timestamp `protobuf:"bytes,4,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	TxnMinAmount      string               `protobuf:"bytes,5,opt,name=txn_min_amount,json=txnMinAmount,proto3" json:"txn_min_amount,omitempty"`
+	DiscountPercent   string               `protobuf:"bytes,6,opt,name=discount_percent,json=discountPercent,proto3" json:"discount_percent,omitempty"`
+	MaxDiscountAmount string               `protobuf:"bytes,7,opt,name=max_discount_amount,json=maxDiscountAmount,proto3" json:"max_discount_amount,omitempty"`
+}
+
+func (x *CampaignBenefitData) Reset() {
+	*x = CampaignBenefitData{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_promo_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CampaignBenefitData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CampaignBenefitData) ProtoMessage() {}
+
+func (x *CampaignBenefitData) ProtoReflect() protoreflect.Message {
+	mi := &file_promo_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CampaignBenefitData.ProtoReflect.Descriptor instead.
+func (*CampaignBenefitData) Descriptor() ([]byte, []int) {
+	return file_promo_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CampaignBenefitData) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *CampaignBenefitData) GetCampaignId() int64 {
+	if x != nil {
+		return x.CampaignId
+	}
+	return 0
+}
+
+func (x *CampaignBenefitData) GetStartTime() *timestamp.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *CampaignBenefitData) GetEndTime() *timestamp.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *CampaignBenefitData) GetTxnMinAmount() string {
+	if x != nil {
+		return x.TxnMinAmount
+	}
+	return ""
+}
+
+func (x *CampaignBenefitData) GetDiscountPercent() string {
+	if x != nil {
+		return x.DiscountPercent
+	}
+	return ""
+}
+
+func (x *CampaignBenefitData) GetMaxDiscountAmount() string {
+	if x != nil {
+		return x.MaxDiscountAmount
+	}
+	return ""
+}
+
+// CampaignBenefitListData ...
+type CampaignBenefitListData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Benefits []*CampaignBenefitData `protobuf:"bytes,1,rep,name=benefits,proto3" json:"benefits,omitempty"`
+}
+
+func (x *CampaignBenefitListData) Reset() {
+	*x = CampaignBenefitListData{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_promo_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CampaignBenefitListData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CampaignBenefitListData) ProtoMessage() {}
+
+func (x *CampaignBenefitListData) ProtoReflect() protoreflect.Message {
+	mi := &file_promo_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CampaignBenefitListData.ProtoReflect.Descriptor instead.
+func (*CampaignBenefitListData) Descriptor() ([]byte, []int) {
+	return file_promo_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CampaignBenefitListData) GetBenefits() []*CampaignBenefitData {
+	if x != nil {
+		return x.Benefits
+	}
+	return nil
+}
+
+// CampaignUsageData ...
+type CampaignUsageData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CampaignId   int64  `protobuf:"varint,1,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
+	BudgetUsed   string `protobuf:"bytes,2,opt,name=budget_used,json=budgetUsed,proto3" json:"budget_used,omitempty"`
+	CampaignUsed int64  `protobuf:"varint,3,opt,name=campaign_used,json=campaignUsed,proto3" json:"campaign_used,omitempty"`
+}
+
+func (x *CampaignUsageData) Reset() {
+	*x = CampaignUsageData{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_promo_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CampaignUsageData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CampaignUsageData) ProtoMessage() {}
+
+func (x *CampaignUsageData) ProtoReflect() protoreflect.Message {
+	mi := &file_promo_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CampaignUsageData.ProtoReflect.Descriptor instead.
+func (*CampaignUsageData) Descriptor() ([]byte, []int) {
+	return file_promo_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CampaignUsageData) GetCampaignId() int64 {
+	if x != nil {
+		return x.CampaignId
+	}
+	return 0
+}
+
+func (x *CampaignUsageData) GetBudgetUsed() string {
+	if x != nil {
+		return x.BudgetUsed
+	}
+	return ""
+}
+
+func (x *CampaignUsageData) GetCampaignUsed() int64 {
+	if x != nil {
+		return x.CampaignUsed
+	}
+	return 0
+}
+
+// CampaignCustomerUsageData ...
+type CampaignCustomerUsageData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CampaignId int64  `protobuf:"varint,1,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
+	Hash       uint32 `protobuf:"varint,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	Phone      string `protobuf:"bytes,3,opt,name=phone,proto3" json:"phone,omitempty"`
+	UsageNum   int64  `protobuf:"varint,4,opt,name=usage_num,json=usageNum,proto3" json:"usage_num,omitempty"`
+}
+
+func (x *CampaignCustomerUsageData) Reset() {
+	*x = CampaignCustomerUsageData{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_promo_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CampaignCustomerUsageData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CampaignCustomerUsageData) ProtoMessage() {}
+
+func (x *CampaignCustomerUsageData) ProtoReflect() protoreflect.Message {
+	mi := &file_promo_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CampaignCustomerUsageData.ProtoReflect.Descriptor instead.
+func (*CampaignCustomerUsageData) Descriptor() ([]byte, []int) {
+	return file_promo_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CampaignCustomerUsageData) GetCampaignId() int64 {
+	if x != nil {
+		return x.CampaignId
+	}
+	return 0
+}
+
+func (x *CampaignCustomerUsageData) GetHash() uint32 {
+	if x != nil {
+		return x.Hash
+	}
+	return 0
+}
+
+func (x *CampaignCustomerUsageData) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *CampaignCustomerUsageData) GetUsageNum() int64 {
+	if x != nil {
+		return x.UsageNum
+	}
+	return 0
+}
+
+// CampaignPeriodUsageData ...
+type CampaignPeriodUsageData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CampaignId int64                `protobuf:"varint,1,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
+	Hash       uint32               `protobuf:"varint,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	Phone      string               `protobuf:"bytes,3,opt,name=phone,proto3" json:"phone,omitempty"`
+	TermCode   string               `protobuf:"bytes,4,opt,name=term_code,json=termCode,proto3" json:"term_code,omitempty"`
+	UsageNum   int64                `protobuf:"varint,5,opt,name=usage_num,json=usageNum,proto3" json:"usage_num,omitempty"`
+	ExpiredOn  *timestamp.Timestamp `protobuf:"bytes,6,opt,name=expired_on,json=expiredOn,proto3" json:"expired_on,omitempty"`
+}
+
+func (x *CampaignPeriodUsageData) Reset() {
+	*x = CampaignPeriodUsageData{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_promo_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CampaignPeriodUsageData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CampaignPeriodUsageData) ProtoMessage() {}
+
+func (x *CampaignPeriodUsageData) ProtoReflect() protoreflect.Message {
+	mi := &file_promo_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CampaignPeriodUsageData.ProtoReflect.Descriptor instead.
+func (*CampaignPeriodUsageData) Descriptor() ([]byte, []int) {
+	return file_promo_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CampaignPeriodUsageData) GetCampaignId() int64 {
+	if x != nil {
+		return x.CampaignId
+	}
+	return 0
+}
+
+func (x *CampaignPeriodUsageData) GetHash() uint32 {
+	if x != nil {
+		return x.Hash
+	}
+	return 0
+}
+
+func (x *CampaignPeriodUsageData) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *CampaignPeriodUsageData) GetTermCode() string {
+	if x != nil {
+		return x.TermCode
+	}
+	return ""
+}
+
+func (x *CampaignPeriodUsageData) GetUsageNum() int64 {
+	if x != nil {
+		return x.UsageNum
+	}
+	return 0
+}
+
+func (x *CampaignPeriodUsageData) GetExpiredOn() *timestamp.Timestamp {
+	if x != nil {
+		return x.ExpiredOn
+	}
+	return nil
+}
+
+// CampaignMerchantData ...
+type CampaignMerchantData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CampaignId   int64                `protobuf:"varint,1,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
+	Hash         uint32               `protobuf:"varint,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	MerchantCode string               `protobuf:"bytes,3,opt,name=merchant_code,json=merchantCode,proto3" json:"merchant_code,omitempty"`
+	Status       uint32               `protobuf:"varint,4,opt,name=status,proto3" json:"status,omitempty"`
+	StartTime    *timestamp.Timestamp `protobuf:"bytes,5,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime      *timestamp.Timestamp `protobuf:"bytes,6,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	AllTerminals bool                 `protobuf:"varint,7,opt,name=all_terminals,json=allTerminals,proto3" json:"all_terminals,omitempty"`
+}
+
+func (x *CampaignMerchantData) Reset() {
+	*x = CampaignMerchantData{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_promo_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CampaignMerchantData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CampaignMerchantData) ProtoMessage() {}
+
+func (x *CampaignMerchantData) ProtoReflect() protoreflect.Message {
+	mi := &file_promo_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CampaignMerchantData.ProtoReflect.Descriptor instead.
+func (*CampaignMerchantData) Descriptor() ([]byte, []int) {
+	return file_promo_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CampaignMerchantData) GetCampaignId() int64 {
+	if x != nil {
+		return x.CampaignId
+	}
+	return 0
+}
+
+func (x *CampaignMerchantData) GetHash() uint32 {
+	if x != nil {
+		return x.Hash
+	}
+	return 0
+}
+
+func (x *CampaignMerchantData) GetMerchantCode() string {
+	if x != nil {
+		return x.MerchantCode
+	}
+	return ""
+}
+
+func (x *CampaignMerchantData) GetStatus() uint32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *CampaignMerchantData) GetStartTime() *timestamp.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *CampaignMerchantData) GetEndTime() *timestamp.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *CampaignMerchantData) GetAllTerminals() bool {
+	if x != nil {
+		return x.AllTerminals
+	}
+	return false
+}
+
+// CampaignTerminalData ...
+type CampaignTerminalData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CampaignId   int64                `protobuf:"varint,1,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
+	Hash         uint32               `protobuf:"varint,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	MerchantCode string               `protobuf:"bytes,3,opt,name=merchant_code,json=merchantCode,proto3" json:"merchant_code,omitempty"`
+	TerminalCode string               `protobuf:"bytes,4,opt,name=terminal_code,json=terminalCode,proto3" json:"terminal_code,omitempty"`
+	Status       uint32               `protobuf:"varint,5,opt,name=status,proto3" json:"status,omitempty"`
+	StartTime    *timestamp.Timestamp `protobuf:"bytes,6,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime      *timestamp.Timestamp `protobuf:"bytes,7,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+}
+
+func (x *CampaignTerminalData) Reset() {
+	*x = CampaignTerminalData{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_promo_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CampaignTerminalData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CampaignTerminalData) ProtoMessage() {}
+
+func (x *CampaignTerminalData) ProtoReflect() protoreflect.Message {
+	mi := &file_promo_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CampaignTerminalData.ProtoReflect.Descriptor instead.
+func (*CampaignTerminalData) Descriptor() ([]byte, []int) {
+	return file_promo_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CampaignTerminalData) GetCampaignId() int64 {
+	if x != nil {
+		return x.CampaignId
+	}
+	return 0
+}
+
+func (x *CampaignTerminalData) GetHash() uint32 {
+	if x != nil {
+		return x.Hash
+	}
+	return 0
+}
+
+func (x *CampaignTerminalData) GetMerchantCode() string {
+	if x != nil {
+		return x.MerchantCode
+	}
+	return ""
+}
+
+func (x *CampaignTerminalData) GetTerminalCode() string {
+	if x != nil {
+		return x.TerminalCode
+	}
+	return ""
+}
+
+func (x *CampaignTerminalData) GetStatus() uint32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *CampaignTerminalData) GetStartTime() *timestamp.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *CampaignTerminalData) GetEndTime() *timestamp.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+// CampaignBankData ...
+type CampaignBankData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CampaignId int64                `protobuf:"varint,1,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
+	Hash       uint32               `protobuf:"varint,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	BankCode   string               `protobuf:"bytes,3,opt,name=bank_code,json=bankCode,proto3" json:"bank_code,omitempty"`
+	Status     uint32               `protobuf:"varint,4,opt,name=status,proto3" json:"status,omitempty"`
+	StartTime  *timestamp.Timestamp `protobuf:"bytes,5,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime    *timestamp.Timestamp `protobuf:"bytes,6,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+}
+
+func (x *CampaignBankData) Reset() {
+	*x = CampaignBankData{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_promo_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CampaignBankData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CampaignBankData) ProtoMessage() {}
+
+func (x *CampaignBankData) ProtoReflect() protoreflect.Message {
+	mi := &file_promo_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CampaignBankData.ProtoReflect.Descriptor instead.
+func (*CampaignBankData) Descriptor() ([]byte, []int) {
+	return file_promo_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CampaignBankData) GetCampaignId() int64 {
+	if x != nil {
+		return x.CampaignId
+	}
+	return 0
+}
+
+func (x *CampaignBankData) GetHash() uint32 {
+	if x != nil {
+		return x.Hash
+	}
+	return 0
+}
+
+func (x *CampaignBankData) GetBankCode() string {
+	if x != nil {
+		return x.BankCode
+	}
+	return ""
+}
+
+func (x *CampaignBankData) GetStatus() uint32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *CampaignBankData) GetStartTime() *timestamp.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *CampaignBankData) GetEndTime() *timestamp.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+// CampaignCustomerData ...
+type CampaignCustomerData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CampaignId int64                `protobuf:"varint,1,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
+	Hash       uint32               `protobuf:"varint,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	Phone      string               `protobuf:"bytes,3,opt,name=phone,proto3" json:"phone,omitempty"`
+	Status     uint32               `protobuf:"varint,4,opt,name=status,proto3" json:"status,omitempty"`
+	StartTime  *timestamp.Timestamp `protobuf:"bytes,5,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime    *timestamp.Timestamp `protobuf:"bytes,6,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+}
+
+func (x *CampaignCustomerData) Reset() {
+	*x = CampaignCustomerData{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_promo_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CampaignCustomerData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CampaignCustomerData) ProtoMessage() {}
+
+func (x *CampaignCustomerData) ProtoReflect() protoreflect.Message {
+	mi := &file_promo_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CampaignCustomerData.ProtoReflect.Descriptor instead.
+func (*CampaignCustomerData) Descriptor() ([]byte, []int) {
+	return file_promo_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CampaignCustomerData) GetCampaignId() int64 {
+	if x != nil {
+		return x.CampaignId
+	}
+	return 0
+}
+
+func (x *CampaignCustomerData) GetHash() uint32 {
+	if x != nil {
+		return x.Hash
+	}
+	return 0
+}
+
+func (x *CampaignCustomerData) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *CampaignCustomerData) GetStatus() uint32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *CampaignCustomerData) GetStartTime() *timestamp.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *CampaignCustomerData) GetEndTime() *timestamp.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
 // PromoServiceCheckRequest ...
 type PromoServiceCheckRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Inputs  []*PromoServiceCheckInput `protobuf:"bytes,1,rep,name=inputs,proto3" json:"inputs,omitempty"`
-	ReqTime *timestamp.Timestamp      `protobuf:"bytes,2,opt,name=req_time,json=reqTime,proto3" json:"req_time,omitempty"`
+	Inputs []*PromoServiceCheckInput `protobuf:"bytes,1,rep,name=inputs,proto3" json:"inputs,omitempty"`
+	// default req_time of inputs, the server clock is used when both are unset
+	ReqTime *timestamp.Timestamp `protobuf:"bytes,2,opt,name=req_time,json=reqTime,proto3" json:"req_time,omitempty"`
 }
 
 func (x *PromoServiceCheckRequest) Reset() {
 	*x = PromoServiceCheckRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_promo_proto_msgTypes[2]
+		mi := &file_promo_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -208,7 +1297,7 @@ func (x *PromoServiceCheckRequest) String() string {
 func (*PromoServiceCheckRequest) ProtoMessage() {}
 
 func (x *PromoServiceCheckRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_promo_proto_msgTypes[2]
+	mi := &file_promo_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -221,7 +1310,7 @@ func (x *PromoServiceCheckRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PromoServiceCheckRequest.ProtoReflect.Descriptor instead.
 func (*PromoServiceCheckRequest) Descriptor() ([]byte, []int) {
-	return file_promo_proto_rawDescGZIP(), []int{2}
+	return file_promo_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *PromoServiceCheckRequest) GetInputs() []*PromoServiceCheckInput {
@@ -248,12 +1337,17 @@ type PromoServiceCheckInput struct {
 	MerchantCode string `protobuf:"bytes,2,opt,name=merchant_code,json=merchantCode,proto3" json:"merchant_code,omitempty"`
 	TerminalCode string `protobuf:"bytes,3,opt,name=terminal_code,json=terminalCode,proto3" json:"terminal_code,omitempty"`
 	Phone        string `protobuf:"bytes,4,opt,name=phone,proto3" json:"phone,omitempty"`
+	BankCode     string `protobuf:"bytes,5,opt,name=bank_code,json=bankCode,proto3" json:"bank_code,omitempty"`
+	// decimal string, e.g. "100000.50", empty means zero
+	Amount string `protobuf:"bytes,6,opt,name=amount,proto3" json:"amount,omitempty"`
+	// overrides the req_time of the request
+	ReqTime *timestamp.Timestamp `protobuf:"bytes,7,opt,name=req_time,json=reqTime,proto3" json:"req_time,omitempty"`
 }
 
 func (x *PromoServiceCheckInput) Reset() {
 	*x = PromoServiceCheckInput{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_promo_proto_msgTypes[3]
+		mi := &file_promo_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -266,7 +1360,7 @@ func (x *PromoServiceCheckInput) String() string {
 func (*PromoServiceCheckInput) ProtoMessage() {}
 
 func (x *PromoServiceCheckInput) ProtoReflect() protoreflect.Message {
-	mi := &file_promo_proto_msgTypes[3]
+	mi := &file_promo_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -279,7 +1373,7 @@ func (x *PromoServiceCheckInput) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PromoServiceCheckInput.ProtoReflect.Descriptor instead.
 func (*PromoServiceCheckInput) Descriptor() ([]byte, []int) {
-	return file_promo_proto_rawDescGZIP(), []int{3}
+	return file_promo_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *PromoServiceCheckInput) GetVoucherCode() string {
@@ -310,20 +1404,45 @@ func (x *PromoServiceCheckInput) GetPhone() string {
 	return ""
 }
 
+func (x *PromoServiceCheckInput) GetBankCode() string {
+	if x != nil {
+		return x.BankCode
+	}
+	return ""
+}
+
+func (x *PromoServiceCheckInput) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *PromoServiceCheckInput) GetReqTime() *timestamp.Timestamp {
+	if x != nil {
+		return x.ReqTime
+	}
+	return nil
+}
+
 // PromoServiceCheckOutput ...
 type PromoServiceCheckOutput struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	DiscountAmount float64 `protobuf:"fixed64,1,opt,name=discount_amount,json=discountAmount,proto3" json:"discount_amount,omitempty"`
-	Status         int32   `protobuf:"varint,2,opt,name=status,proto3" json:"status,omitempty"`
+	// decimal string, e.g. "10000.25"
+	DiscountAmount string `protobuf:"bytes,6,opt,name=discount_amount,json=discountAmount,proto3" json:"discount_amount,omitempty"`
+	// the chosen campaign, or the campaign causing the rejection
+	CampaignId int64       `protobuf:"varint,3,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
+	Reason     CheckReason `protobuf:"varint,4,opt,name=reason,proto3,enum=promo.v1.CheckReason" json:"reason,omitempty"`
+	Message    string      `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
 }
 
 func (x *PromoServiceCheckOutput) Reset() {
 	*x = PromoServiceCheckOutput{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_promo_proto_msgTypes[4]
+		mi := &file_promo_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -336,7 +1455,7 @@ func (x *PromoServiceCheckOutput) String() string {
 func (*PromoServiceCheckOutput) ProtoMessage() {}
 
 func (x *PromoServiceCheckOutput) ProtoReflect() protoreflect.Message {
-	mi := &file_promo_proto_msgTypes[4]
+	mi := &file_promo_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -349,23 +1468,37 @@ func (x *PromoServiceCheckOutput) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PromoServiceCheckOutput.ProtoReflect.Descriptor instead.
 func (*PromoServiceCheckOutput) Descriptor() ([]byte, []int) {
-	return file_promo_proto_rawDescGZIP(), []int{4}
+	return file_promo_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *PromoServiceCheckOutput) GetDiscountAmount() float64 {
+func (x *PromoServiceCheckOutput) GetDiscountAmount() string {
 	if x != nil {
 		return x.DiscountAmount
 	}
-	return 0
+	return ""
 }
 
-func (x *PromoServiceCheckOutput) GetStatus() int32 {
+func (x *PromoServiceCheckOutput) GetCampaignId() int64 {
 	if x != nil {
-		return x.Status
+		return x.CampaignId
 	}
 	return 0
 }
 
+func (x *PromoServiceCheckOutput) GetReason() CheckReason {
+	if x != nil {
+		return x.Reason
+	}
+	return CheckReason_CHECK_REASON_UNSPECIFIED
+}
+
+func (x *PromoServiceCheckOutput) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
 // PromoServiceCheckResponse ...
 type PromoServiceCheckResponse struct {
 	state         protoimpl.MessageState
@@ -378,7 +1511,7 @@ type PromoServiceCheckResponse struct {
 func (x *PromoServiceCheckResponse) Reset() {
 	*x = PromoServiceCheckResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_promo_proto_msgTypes[5]
+		mi := &file_promo_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -391,7 +1524,7 @@ func (x *PromoServiceCheckResponse) String() string {
 func (*PromoServiceCheckResponse) ProtoMessage() {}
 
 func (x *PromoServiceCheckResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_promo_proto_msgTypes[5]
+	mi := &file_promo_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -404,7 +1537,7 @@ func (x *PromoServiceCheckResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PromoServiceCheckResponse.ProtoReflect.Descriptor instead.
 func (*PromoServiceCheckResponse) Descriptor() ([]byte, []int) {
-	return file_promo_proto_rawDescGZIP(), []int{5}
+	return file_promo_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *PromoServiceCheckResponse) GetOutputs() []*PromoServiceCheckOutput {
@@ -414,6 +1547,119 @@ func (x *PromoServiceCheckResponse) GetOutputs() []*PromoServiceCheckOutput {
 	return nil
 }
 
+// PromoServiceCheckStreamRequest ...
+type PromoServiceCheckStreamRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// client supplied id for matching with the response
+	RequestId string                  `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	Input     *PromoServiceCheckInput `protobuf:"bytes,2,opt,name=input,proto3" json:"input,omitempty"`
+}
+
+func (x *PromoServiceCheckStreamRequest) Reset() {
+	*x = PromoServiceCheckStreamRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_promo_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PromoServiceCheckStreamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromoServiceCheckStreamRequest) ProtoMessage() {}
+
+func (x *PromoServiceCheckStreamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_promo_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromoServiceCheckStreamRequest.ProtoReflect.Descriptor instead.
+func (*PromoServiceCheckStreamRequest) Descriptor() ([]byte, []int) {
+	return file_promo_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *PromoServiceCheckStreamRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *PromoServiceCheckStreamRequest) GetInput() *PromoServiceCheckInput {
+	if x != nil {
+		return x.Input
+	}
+	return nil
+}
+
+// PromoServiceCheckStreamResponse ...
+type PromoServiceCheckStreamResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RequestId string                   `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	Output    *PromoServiceCheckOutput `protobuf:"bytes,2,opt,name=output,proto3" json:"output,omitempty"`
+}
+
+func (x *PromoServiceCheckStreamResponse) Reset() {
+	*x = PromoServiceCheckStreamResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_promo_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PromoServiceCheckStreamResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromoServiceCheckStreamResponse) ProtoMessage() {}
+
+func (x *PromoServiceCheckStreamResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_promo_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromoServiceCheckStreamResponse.ProtoReflect.Descriptor instead.
+func (*PromoServiceCheckStreamResponse) Descriptor() ([]byte, []int) {
+	return file_promo_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *PromoServiceCheckStreamResponse) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *PromoServiceCheckStreamResponse) GetOutput() *PromoServiceCheckOutput {
+	if x != nil {
+		return x.Output
+	}
+	return nil
+}
+
 var File_promo_proto protoreflect.FileDescriptor
 
 var file_promo_proto_rawDesc = []byte{
@@ -422,6 +1668,8 @@ var file_promo_proto_rawDesc = []byte{
 	0x61, 0x70, 0x69, 0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e,
 	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72,
 	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x77, 0x72, 0x61, 0x70, 0x70, 0x65, 0x72, 0x73,
 	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xcb, 0x01, 0x0a, 0x15, 0x42, 0x6c, 0x61, 0x63, 0x6b,
 	0x6c, 0x69, 0x73, 0x74, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61,
 	0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04,
@@ -449,48 +1697,295 @@ var file_promo_proto_rawDesc = []byte{
 	0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
 	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
 	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d,
-	0x65, 0x22, 0x8b, 0x01, 0x0a, 0x18, 0x50, 0x72, 0x6f, 0x6d, 0x6f, 0x53, 0x65, 0x72, 0x76, 0x69,
-	0x63, 0x65, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x38,
-	0x0a, 0x06, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20,
-	0x2e, 0x70, 0x72, 0x6f, 0x6d, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x6d, 0x6f, 0x53,
-	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x49, 0x6e, 0x70, 0x75, 0x74,
-	0x52, 0x06, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x12, 0x35, 0x0a, 0x08, 0x72, 0x65, 0x71, 0x5f,
-	0x74, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x65, 0x22, 0xff, 0x01, 0x0a, 0x15, 0x42, 0x6c, 0x61, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x54,
+	0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x6c, 0x44, 0x61, 0x74, 0x61, 0x12, 0x12, 0x0a, 0x04, 0x68,
+	0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x12,
+	0x23, 0x0a, 0x0d, 0x6d, 0x65, 0x72, 0x63, 0x68, 0x61, 0x6e, 0x74, 0x5f, 0x63, 0x6f, 0x64, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6d, 0x65, 0x72, 0x63, 0x68, 0x61, 0x6e, 0x74,
+	0x43, 0x6f, 0x64, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x74, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x6c,
+	0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x74, 0x65, 0x72,
+	0x6d, 0x69, 0x6e, 0x61, 0x6c, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x12, 0x39, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x35, 0x0a, 0x08,
+	0x65, 0x6e, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x54,
+	0x69, 0x6d, 0x65, 0x22, 0x9f, 0x05, 0x0a, 0x0c, 0x43, 0x61, 0x6d, 0x70, 0x61, 0x69, 0x67, 0x6e,
+	0x44, 0x61, 0x74, 0x61, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x76, 0x6f, 0x75, 0x63, 0x68, 0x65, 0x72, 0x5f,
+	0x68, 0x61, 0x73, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x76, 0x6f, 0x75, 0x63,
+	0x68, 0x65, 0x72, 0x48, 0x61, 0x73, 0x68, 0x12, 0x21, 0x0a, 0x0c, 0x76, 0x6f, 0x75, 0x63, 0x68,
+	0x65, 0x72, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x76,
+	0x6f, 0x75, 0x63, 0x68, 0x65, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x39, 0x0a, 0x0a, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x35, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x5f, 0x74, 0x69, 0x6d,
+	0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x3b, 0x0a, 0x0a,
+	0x62, 0x75, 0x64, 0x67, 0x65, 0x74, 0x5f, 0x6d, 0x61, 0x78, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x09,
+	0x62, 0x75, 0x64, 0x67, 0x65, 0x74, 0x4d, 0x61, 0x78, 0x12, 0x49, 0x0a, 0x12, 0x63, 0x61, 0x6d,
+	0x70, 0x61, 0x69, 0x67, 0x6e, 0x5f, 0x75, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x6d, 0x61, 0x78, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x49, 0x6e, 0x74, 0x36, 0x34, 0x56, 0x61, 0x6c,
+	0x75, 0x65, 0x52, 0x10, 0x63, 0x61, 0x6d, 0x70, 0x61, 0x69, 0x67, 0x6e, 0x55, 0x73, 0x61, 0x67,
+	0x65, 0x4d, 0x61, 0x78, 0x12, 0x2c, 0x0a, 0x12, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72,
+	0x5f, 0x75, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x6d, 0x61, 0x78, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x10, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x55, 0x73, 0x61, 0x67, 0x65, 0x4d,
+	0x61, 0x78, 0x12, 0x2a, 0x0a, 0x11, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x5f, 0x75, 0x73, 0x61,
+	0x67, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x70,
+	0x65, 0x72, 0x69, 0x6f, 0x64, 0x55, 0x73, 0x61, 0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x56,
+	0x0a, 0x19, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x5f, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65,
+	0x72, 0x5f, 0x75, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x6d, 0x61, 0x78, 0x18, 0x0d, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1b, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x49, 0x6e, 0x74, 0x36, 0x34, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x16,
+	0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x55, 0x73,
+	0x61, 0x67, 0x65, 0x4d, 0x61, 0x78, 0x12, 0x28, 0x0a, 0x10, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64,
+	0x5f, 0x74, 0x65, 0x72, 0x6d, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x0e, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x54, 0x65, 0x72, 0x6d, 0x54, 0x79, 0x70, 0x65,
+	0x12, 0x23, 0x0a, 0x0d, 0x61, 0x6c, 0x6c, 0x5f, 0x6d, 0x65, 0x72, 0x63, 0x68, 0x61, 0x6e, 0x74,
+	0x73, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x61, 0x6c, 0x6c, 0x4d, 0x65, 0x72, 0x63,
+	0x68, 0x61, 0x6e, 0x74, 0x73, 0x22, 0xb9, 0x02, 0x0a, 0x13, 0x43, 0x61, 0x6d, 0x70, 0x61, 0x69,
+	0x67, 0x6e, 0x42, 0x65, 0x6e, 0x65, 0x66, 0x69, 0x74, 0x44, 0x61, 0x74, 0x61, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1f, 0x0a,
+	0x0b, 0x63, 0x61, 0x6d, 0x70, 0x61, 0x69, 0x67, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0a, 0x63, 0x61, 0x6d, 0x70, 0x61, 0x69, 0x67, 0x6e, 0x49, 0x64, 0x12, 0x39,
+	0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09,
+	0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x35, 0x0a, 0x08, 0x65, 0x6e, 0x64,
+	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65,
+	0x12, 0x24, 0x0a, 0x0e, 0x74, 0x78, 0x6e, 0x5f, 0x6d, 0x69, 0x6e, 0x5f, 0x61, 0x6d, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x74, 0x78, 0x6e, 0x4d, 0x69, 0x6e,
+	0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x29, 0x0a, 0x10, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0f, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e,
+	0x74, 0x12, 0x2e, 0x0a, 0x13, 0x6d, 0x61, 0x78, 0x5f, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11,
+	0x6d, 0x61, 0x78, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x41, 0x6d, 0x6f, 0x75, 0x6e,
+	0x74, 0x22, 0x54, 0x0a, 0x17, 0x43, 0x61, 0x6d, 0x70, 0x61, 0x69, 0x67, 0x6e, 0x42, 0x65, 0x6e,
+	0x65, 0x66, 0x69, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x61, 0x74, 0x61, 0x12, 0x39, 0x0a, 0x08,
+	0x62, 0x65, 0x6e, 0x65, 0x66, 0x69, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d,
+	0x2e, 0x70, 0x72, 0x6f, 0x6d, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6d, 0x70, 0x61, 0x69,
+	0x67, 0x6e, 0x42, 0x65, 0x6e, 0x65, 0x66, 0x69, 0x74, 0x44, 0x61, 0x74, 0x61, 0x52, 0x08, 0x62,
+	0x65, 0x6e, 0x65, 0x66, 0x69, 0x74, 0x73, 0x22, 0x7a, 0x0a, 0x11, 0x43, 0x61, 0x6d, 0x70, 0x61,
+	0x69, 0x67, 0x6e, 0x55, 0x73, 0x61, 0x67, 0x65, 0x44, 0x61, 0x74, 0x61, 0x12, 0x1f, 0x0a, 0x0b,
+	0x63, 0x61, 0x6d, 0x70, 0x61, 0x69, 0x67, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0a, 0x63, 0x61, 0x6d, 0x70, 0x61, 0x69, 0x67, 0x6e, 0x49, 0x64, 0x12, 0x1f, 0x0a,
+	0x0b, 0x62, 0x75, 0x64, 0x67, 0x65, 0x74, 0x5f, 0x75, 0x73, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x62, 0x75, 0x64, 0x67, 0x65, 0x74, 0x55, 0x73, 0x65, 0x64, 0x12, 0x23,
+	0x0a, 0x0d, 0x63, 0x61, 0x6d, 0x70, 0x61, 0x69, 0x67, 0x6e, 0x5f, 0x75, 0x73, 0x65, 0x64, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x63, 0x61, 0x6d, 0x70, 0x61, 0x69, 0x67, 0x6e, 0x55,
+	0x73, 0x65, 0x64, 0x22, 0x83, 0x01, 0x0a, 0x19, 0x43, 0x61, 0x6d, 0x70, 0x61, 0x69, 0x67, 0x6e,
+	0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x55, 0x73, 0x61, 0x67, 0x65, 0x44, 0x61, 0x74,
+	0x61, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x61, 0x6d, 0x70, 0x61, 0x69, 0x67, 0x6e, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x63, 0x61, 0x6d, 0x70, 0x61, 0x69, 0x67, 0x6e,
+	0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x68, 0x6f, 0x6e, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x70, 0x68, 0x6f, 0x6e, 0x65, 0x12, 0x1b, 0x0a, 0x09,
+	0x75, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x6e, 0x75, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x08, 0x75, 0x73, 0x61, 0x67, 0x65, 0x4e, 0x75, 0x6d, 0x22, 0xd9, 0x01, 0x0a, 0x17, 0x43, 0x61,
+	0x6d, 0x70, 0x61, 0x69, 0x67, 0x6e, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x55, 0x73, 0x61, 0x67,
+	0x65, 0x44, 0x61, 0x74, 0x61, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x61, 0x6d, 0x70, 0x61, 0x69, 0x67,
+	0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x63, 0x61, 0x6d, 0x70,
+	0x61, 0x69, 0x67, 0x6e, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x68,
+	0x6f, 0x6e, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x70, 0x68, 0x6f, 0x6e, 0x65,
+	0x12, 0x1b, 0x0a, 0x09, 0x74, 0x65, 0x72, 0x6d, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x65, 0x72, 0x6d, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x1b, 0x0a,
+	0x09, 0x75, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x6e, 0x75, 0x6d, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x08, 0x75, 0x73, 0x61, 0x67, 0x65, 0x4e, 0x75, 0x6d, 0x12, 0x39, 0x0a, 0x0a, 0x65, 0x78,
+	0x70, 0x69, 0x72, 0x65, 0x64, 0x5f, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x65, 0x78, 0x70, 0x69,
+	0x72, 0x65, 0x64, 0x4f, 0x6e, 0x22, 0x9f, 0x02, 0x0a, 0x14, 0x43, 0x61, 0x6d, 0x70, 0x61, 0x69,
+	0x67, 0x6e, 0x4d, 0x65, 0x72, 0x63, 0x68, 0x61, 0x6e, 0x74, 0x44, 0x61, 0x74, 0x61, 0x12, 0x1f,
+	0x0a, 0x0b, 0x63, 0x61, 0x6d, 0x70, 0x61, 0x69, 0x67, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0a, 0x63, 0x61, 0x6d, 0x70, 0x61, 0x69, 0x67, 0x6e, 0x49, 0x64, 0x12,
+	0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x68,
+	0x61, 0x73, 0x68, 0x12, 0x23, 0x0a, 0x0d, 0x6d, 0x65, 0x72, 0x63, 0x68, 0x61, 0x6e, 0x74, 0x5f,
+	0x63, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6d, 0x65, 0x72, 0x63,
+	0x68, 0x61, 0x6e, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x39, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x35, 0x0a, 0x08, 0x65,
+	0x6e, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x54, 0x69,
+	0x6d, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x61, 0x6c, 0x6c, 0x5f, 0x74, 0x65, 0x72, 0x6d, 0x69, 0x6e,
+	0x61, 0x6c, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x61, 0x6c, 0x6c, 0x54, 0x65,
+	0x72, 0x6d, 0x69, 0x6e, 0x61, 0x6c, 0x73, 0x22, 0x9f, 0x02, 0x0a, 0x14, 0x43, 0x61, 0x6d, 0x70,
+	0x61, 0x69, 0x67, 0x6e, 0x54, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x6c, 0x44, 0x61, 0x74, 0x61,
+	0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x61, 0x6d, 0x70, 0x61, 0x69, 0x67, 0x6e, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x63, 0x61, 0x6d, 0x70, 0x61, 0x69, 0x67, 0x6e, 0x49,
+	0x64, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x04, 0x68, 0x61, 0x73, 0x68, 0x12, 0x23, 0x0a, 0x0d, 0x6d, 0x65, 0x72, 0x63, 0x68, 0x61, 0x6e,
+	0x74, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6d, 0x65,
+	0x72, 0x63, 0x68, 0x61, 0x6e, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x74, 0x65,
+	0x72, 0x6d, 0x69, 0x6e, 0x61, 0x6c, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0c, 0x74, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x6c, 0x43, 0x6f, 0x64, 0x65, 0x12,
+	0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x39, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74,
+	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69,
+	0x6d, 0x65, 0x12, 0x35, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x07, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x22, 0xee, 0x01, 0x0a, 0x10, 0x43, 0x61,
+	0x6d, 0x70, 0x61, 0x69, 0x67, 0x6e, 0x42, 0x61, 0x6e, 0x6b, 0x44, 0x61, 0x74, 0x61, 0x12, 0x1f,
+	0x0a, 0x0b, 0x63, 0x61, 0x6d, 0x70, 0x61, 0x69, 0x67, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0a, 0x63, 0x61, 0x6d, 0x70, 0x61, 0x69, 0x67, 0x6e, 0x49, 0x64, 0x12,
+	0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x68,
+	0x61, 0x73, 0x68, 0x12, 0x1b, 0x0a, 0x09, 0x62, 0x61, 0x6e, 0x6b, 0x5f, 0x63, 0x6f, 0x64, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x62, 0x61, 0x6e, 0x6b, 0x43, 0x6f, 0x64, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x39, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54,
+	0x69, 0x6d, 0x65, 0x12, 0x35, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x22, 0xeb, 0x01, 0x0a, 0x14, 0x43,
+	0x61, 0x6d, 0x70, 0x61, 0x69, 0x67, 0x6e, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x44,
+	0x61, 0x74, 0x61, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x61, 0x6d, 0x70, 0x61, 0x69, 0x67, 0x6e, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x63, 0x61, 0x6d, 0x70, 0x61, 0x69,
+	0x67, 0x6e, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x68, 0x6f, 0x6e,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x70, 0x68, 0x6f, 0x6e, 0x65, 0x12, 0x16,
+	0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x39, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f,
+	0x74, 0x69, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
 	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
-	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x72, 0x65, 0x71, 0x54, 0x69, 0x6d, 0x65, 0x22,
-	0x9b, 0x01, 0x0a, 0x16, 0x50, 0x72, 0x6f, 0x6d, 0x6f, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
-	0x43, 0x68, 0x65, 0x63, 0x6b, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x76, 0x6f,
-	0x75, 0x63, 0x68, 0x65, 0x72, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0b, 0x76, 0x6f, 0x75, 0x63, 0x68, 0x65, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x23, 0x0a,
-	0x0d, 0x6d, 0x65, 0x72, 0x63, 0x68, 0x61, 0x6e, 0x74, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6d, 0x65, 0x72, 0x63, 0x68, 0x61, 0x6e, 0x74, 0x43, 0x6f,
-	0x64, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x74, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x6c, 0x5f, 0x63,
-	0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x74, 0x65, 0x72, 0x6d, 0x69,
-	0x6e, 0x61, 0x6c, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x68, 0x6f, 0x6e, 0x65,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x70, 0x68, 0x6f, 0x6e, 0x65, 0x22, 0x5a, 0x0a,
-	0x17, 0x50, 0x72, 0x6f, 0x6d, 0x6f, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x43, 0x68, 0x65,
-	0x63, 0x6b, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x64, 0x69, 0x73, 0x63,
-	0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x01, 0x52, 0x0e, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x41, 0x6d, 0x6f, 0x75, 0x6e,
-	0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x05, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x58, 0x0a, 0x19, 0x50, 0x72, 0x6f,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d,
+	0x65, 0x12, 0x35, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x07, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x22, 0x8b, 0x01, 0x0a, 0x18, 0x50, 0x72, 0x6f,
+	0x6d, 0x6f, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x38, 0x0a, 0x06, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x70, 0x72, 0x6f, 0x6d, 0x6f, 0x2e, 0x76, 0x31,
+	0x2e, 0x50, 0x72, 0x6f, 0x6d, 0x6f, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x43, 0x68, 0x65,
+	0x63, 0x6b, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x52, 0x06, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x12,
+	0x35, 0x0a, 0x08, 0x72, 0x65, 0x71, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x72,
+	0x65, 0x71, 0x54, 0x69, 0x6d, 0x65, 0x22, 0x87, 0x02, 0x0a, 0x16, 0x50, 0x72, 0x6f, 0x6d, 0x6f,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x49, 0x6e, 0x70, 0x75,
+	0x74, 0x12, 0x21, 0x0a, 0x0c, 0x76, 0x6f, 0x75, 0x63, 0x68, 0x65, 0x72, 0x5f, 0x63, 0x6f, 0x64,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x76, 0x6f, 0x75, 0x63, 0x68, 0x65, 0x72,
+	0x43, 0x6f, 0x64, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x6d, 0x65, 0x72, 0x63, 0x68, 0x61, 0x6e, 0x74,
+	0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6d, 0x65, 0x72,
+	0x63, 0x68, 0x61, 0x6e, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x74, 0x65, 0x72,
+	0x6d, 0x69, 0x6e, 0x61, 0x6c, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0c, 0x74, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x6c, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x70, 0x68, 0x6f, 0x6e, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x70,
+	0x68, 0x6f, 0x6e, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x62, 0x61, 0x6e, 0x6b, 0x5f, 0x63, 0x6f, 0x64,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x62, 0x61, 0x6e, 0x6b, 0x43, 0x6f, 0x64,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x35, 0x0a, 0x08, 0x72, 0x65, 0x71,
+	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x72, 0x65, 0x71, 0x54, 0x69, 0x6d, 0x65,
+	0x22, 0xc0, 0x01, 0x0a, 0x17, 0x50, 0x72, 0x6f, 0x6d, 0x6f, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x27, 0x0a, 0x0f,
+	0x64, 0x69, 0x73, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x41,
+	0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x61, 0x6d, 0x70, 0x61, 0x69, 0x67,
+	0x6e, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x63, 0x61, 0x6d, 0x70,
+	0x61, 0x69, 0x67, 0x6e, 0x49, 0x64, 0x12, 0x2d, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x70, 0x72, 0x6f, 0x6d, 0x6f, 0x2e, 0x76,
+	0x31, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x52, 0x06, 0x72,
+	0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4a,
+	0x04, 0x08, 0x01, 0x10, 0x02, 0x4a, 0x04, 0x08, 0x02, 0x10, 0x03, 0x52, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x22, 0x58, 0x0a, 0x19, 0x50, 0x72, 0x6f, 0x6d, 0x6f, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x3b, 0x0a, 0x07, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x21, 0x2e, 0x70, 0x72, 0x6f, 0x6d, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f,
+	0x6d, 0x6f, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x4f, 0x75,
+	0x74, 0x70, 0x75, 0x74, 0x52, 0x07, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x22, 0x77, 0x0a,
+	0x1e, 0x50, 0x72, 0x6f, 0x6d, 0x6f, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x43, 0x68, 0x65,
+	0x63, 0x6b, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x12, 0x36,
+	0x0a, 0x05, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e,
+	0x70, 0x72, 0x6f, 0x6d, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x6d, 0x6f, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x52,
+	0x05, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x22, 0x7b, 0x0a, 0x1f, 0x50, 0x72, 0x6f, 0x6d, 0x6f, 0x53,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x53, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x12, 0x39, 0x0a, 0x06, 0x6f, 0x75, 0x74, 0x70,
+	0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x70, 0x72, 0x6f, 0x6d, 0x6f,
+	0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x6d, 0x6f, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x43, 0x68, 0x65, 0x63, 0x6b, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x06, 0x6f, 0x75, 0x74,
+	0x70, 0x75, 0x74, 0x2a, 0x81, 0x04, 0x0a, 0x0b, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x61,
+	0x73, 0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x18, 0x43, 0x48, 0x45, 0x43, 0x4b, 0x5f, 0x52, 0x45, 0x41,
+	0x53, 0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10,
+	0x00, 0x12, 0x06, 0x0a, 0x02, 0x4f, 0x4b, 0x10, 0x01, 0x12, 0x18, 0x0a, 0x14, 0x43, 0x55, 0x53,
+	0x54, 0x4f, 0x4d, 0x45, 0x52, 0x5f, 0x42, 0x4c, 0x41, 0x43, 0x4b, 0x4c, 0x49, 0x53, 0x54, 0x45,
+	0x44, 0x10, 0x02, 0x12, 0x18, 0x0a, 0x14, 0x4d, 0x45, 0x52, 0x43, 0x48, 0x41, 0x4e, 0x54, 0x5f,
+	0x42, 0x4c, 0x41, 0x43, 0x4b, 0x4c, 0x49, 0x53, 0x54, 0x45, 0x44, 0x10, 0x03, 0x12, 0x18, 0x0a,
+	0x14, 0x54, 0x45, 0x52, 0x4d, 0x49, 0x4e, 0x41, 0x4c, 0x5f, 0x42, 0x4c, 0x41, 0x43, 0x4b, 0x4c,
+	0x49, 0x53, 0x54, 0x45, 0x44, 0x10, 0x04, 0x12, 0x15, 0x0a, 0x11, 0x56, 0x4f, 0x55, 0x43, 0x48,
+	0x45, 0x52, 0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x46, 0x4f, 0x55, 0x4e, 0x44, 0x10, 0x05, 0x12, 0x15,
+	0x0a, 0x11, 0x43, 0x41, 0x4d, 0x50, 0x41, 0x49, 0x47, 0x4e, 0x5f, 0x49, 0x4e, 0x41, 0x43, 0x54,
+	0x49, 0x56, 0x45, 0x10, 0x06, 0x12, 0x18, 0x0a, 0x14, 0x43, 0x41, 0x4d, 0x50, 0x41, 0x49, 0x47,
+	0x4e, 0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x53, 0x54, 0x41, 0x52, 0x54, 0x45, 0x44, 0x10, 0x07, 0x12,
+	0x12, 0x0a, 0x0e, 0x43, 0x41, 0x4d, 0x50, 0x41, 0x49, 0x47, 0x4e, 0x5f, 0x45, 0x4e, 0x44, 0x45,
+	0x44, 0x10, 0x08, 0x12, 0x19, 0x0a, 0x15, 0x4e, 0x4f, 0x5f, 0x41, 0x50, 0x50, 0x4c, 0x49, 0x43,
+	0x41, 0x42, 0x4c, 0x45, 0x5f, 0x42, 0x45, 0x4e, 0x45, 0x46, 0x49, 0x54, 0x10, 0x09, 0x12, 0x19,
+	0x0a, 0x15, 0x4d, 0x45, 0x52, 0x43, 0x48, 0x41, 0x4e, 0x54, 0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x45,
+	0x4c, 0x49, 0x47, 0x49, 0x42, 0x4c, 0x45, 0x10, 0x0a, 0x12, 0x19, 0x0a, 0x15, 0x54, 0x45, 0x52,
+	0x4d, 0x49, 0x4e, 0x41, 0x4c, 0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x45, 0x4c, 0x49, 0x47, 0x49, 0x42,
+	0x4c, 0x45, 0x10, 0x0b, 0x12, 0x15, 0x0a, 0x11, 0x42, 0x41, 0x4e, 0x4b, 0x5f, 0x4e, 0x4f, 0x54,
+	0x5f, 0x45, 0x4c, 0x49, 0x47, 0x49, 0x42, 0x4c, 0x45, 0x10, 0x0c, 0x12, 0x19, 0x0a, 0x15, 0x43,
+	0x55, 0x53, 0x54, 0x4f, 0x4d, 0x45, 0x52, 0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x45, 0x4c, 0x49, 0x47,
+	0x49, 0x42, 0x4c, 0x45, 0x10, 0x0d, 0x12, 0x14, 0x0a, 0x10, 0x42, 0x55, 0x44, 0x47, 0x45, 0x54,
+	0x5f, 0x45, 0x58, 0x48, 0x41, 0x55, 0x53, 0x54, 0x45, 0x44, 0x10, 0x0e, 0x12, 0x1c, 0x0a, 0x18,
+	0x43, 0x41, 0x4d, 0x50, 0x41, 0x49, 0x47, 0x4e, 0x5f, 0x55, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x45,
+	0x58, 0x48, 0x41, 0x55, 0x53, 0x54, 0x45, 0x44, 0x10, 0x0f, 0x12, 0x1c, 0x0a, 0x18, 0x43, 0x55,
+	0x53, 0x54, 0x4f, 0x4d, 0x45, 0x52, 0x5f, 0x55, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x45, 0x58, 0x48,
+	0x41, 0x55, 0x53, 0x54, 0x45, 0x44, 0x10, 0x10, 0x12, 0x23, 0x0a, 0x1f, 0x43, 0x55, 0x53, 0x54,
+	0x4f, 0x4d, 0x45, 0x52, 0x5f, 0x50, 0x45, 0x52, 0x49, 0x4f, 0x44, 0x5f, 0x55, 0x53, 0x41, 0x47,
+	0x45, 0x5f, 0x45, 0x58, 0x48, 0x41, 0x55, 0x53, 0x54, 0x45, 0x44, 0x10, 0x11, 0x12, 0x12, 0x0a,
+	0x0e, 0x49, 0x4e, 0x54, 0x45, 0x52, 0x4e, 0x41, 0x4c, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10,
+	0x12, 0x12, 0x14, 0x0a, 0x10, 0x49, 0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x5f, 0x41, 0x52, 0x47,
+	0x55, 0x4d, 0x45, 0x4e, 0x54, 0x10, 0x13, 0x32, 0xe2, 0x01, 0x0a, 0x0c, 0x50, 0x72, 0x6f, 0x6d,
+	0x6f, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x6a, 0x0a, 0x05, 0x43, 0x68, 0x65, 0x63,
+	0x6b, 0x12, 0x22, 0x2e, 0x70, 0x72, 0x6f, 0x6d, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f,
 	0x6d, 0x6f, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3b, 0x0a, 0x07, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74,
-	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x70, 0x72, 0x6f, 0x6d, 0x6f, 0x2e,
-	0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x6d, 0x6f, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x43,
-	0x68, 0x65, 0x63, 0x6b, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x07, 0x6f, 0x75, 0x74, 0x70,
-	0x75, 0x74, 0x73, 0x32, 0x7a, 0x0a, 0x0c, 0x50, 0x72, 0x6f, 0x6d, 0x6f, 0x53, 0x65, 0x72, 0x76,
-	0x69, 0x63, 0x65, 0x12, 0x6a, 0x0a, 0x05, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x12, 0x22, 0x2e, 0x70,
-	0x72, 0x6f, 0x6d, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x6d, 0x6f, 0x53, 0x65, 0x72,
-	0x76, 0x69, 0x63, 0x65, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x23, 0x2e, 0x70, 0x72, 0x6f, 0x6d, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x6d,
-	0x6f, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x18, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x12, 0x22, 0x0d, 0x2f,
-	0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x3a, 0x01, 0x2a, 0x42,
-	0x37, 0x5a, 0x35, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x51, 0x75,
-	0x61, 0x6e, 0x67, 0x54, 0x75, 0x6e, 0x67, 0x39, 0x37, 0x2f, 0x70, 0x72, 0x6f, 0x6d, 0x6f, 0x2d,
-	0x72, 0x65, 0x61, 0x64, 0x6f, 0x6e, 0x6c, 0x79, 0x2f, 0x70, 0x72, 0x6f, 0x6d, 0x6f, 0x70, 0x62,
-	0x3b, 0x70, 0x72, 0x6f, 0x6d, 0x6f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x70, 0x72, 0x6f, 0x6d, 0x6f, 0x2e, 0x76, 0x31,
+	0x2e, 0x50, 0x72, 0x6f, 0x6d, 0x6f, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x43, 0x68, 0x65,
+	0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x18, 0x82, 0xd3, 0xe4, 0x93,
+	0x02, 0x12, 0x22, 0x0d, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x63, 0x68, 0x65, 0x63,
+	0x6b, 0x3a, 0x01, 0x2a, 0x12, 0x66, 0x0a, 0x0b, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x53, 0x74, 0x72,
+	0x65, 0x61, 0x6d, 0x12, 0x28, 0x2e, 0x70, 0x72, 0x6f, 0x6d, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x50,
+	0x72, 0x6f, 0x6d, 0x6f, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x43, 0x68, 0x65, 0x63, 0x6b,
+	0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x29, 0x2e,
+	0x70, 0x72, 0x6f, 0x6d, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x6d, 0x6f, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x28, 0x01, 0x30, 0x01, 0x42, 0x37, 0x5a, 0x35,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x51, 0x75, 0x61, 0x6e, 0x67,
+	0x54, 0x75, 0x6e, 0x67, 0x39, 0x37, 0x2f, 0x70, 0x72, 0x6f, 0x6d, 0x6f, 0x2d, 0x72, 0x65, 0x61,
+	0x64, 0x6f, 0x6e, 0x6c, 0x79, 0x2f, 0x70, 0x72, 0x6f, 0x6d, 0x6f, 0x70, 0x62, 0x3b, 0x70, 0x72,
+	0x6f, 0x6d, 0x6f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -505,31 +2000,73 @@ func file_promo_proto_rawDescGZIP() []byte {
 	return file_promo_proto_rawDescData
 }
 
-var file_promo_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_promo_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_promo_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
 var file_promo_proto_goTypes = []interface{}{
-	(*BlacklistCustomerData)(nil),     // 0: promo.v1.BlacklistCustomerData
-	(*BlacklistMerchantData)(nil),     // 1: promo.v1.BlacklistMerchantData
-	(*PromoServiceCheckRequest)(nil),  // 2: promo.v1.PromoServiceCheckRequest
-	(*PromoServiceCheckInput)(nil),    // 3: promo.v1.PromoServiceCheckInput
-	(*PromoServiceCheckOutput)(nil),   // 4: promo.v1.PromoServiceCheckOutput
-	(*PromoServiceCheckResponse)(nil), // 5: promo.v1.PromoServiceCheckResponse
-	(*timestamp.Timestamp)(nil),       // 6: google.protobuf.Timestamp
+	(CheckReason)(0),                        // 0: promo.v1.CheckReason
+	(*BlacklistCustomerData)(nil),           // 1: promo.v1.BlacklistCustomerData
+	(*BlacklistMerchantData)(nil),           // 2: promo.v1.BlacklistMerchantData
+	(*BlacklistTerminalData)(nil),           // 3: promo.v1.BlacklistTerminalData
+	(*CampaignData)(nil),                    // 4: promo.v1.CampaignData
+	(*CampaignBenefitData)(nil),             // 5: promo.v1.CampaignBenefitData
+	(*CampaignBenefitListData)(nil),         // 6: promo.v1.CampaignBenefitListData
+	(*CampaignUsageData)(nil),               // 7: promo.v1.CampaignUsageData
+	(*CampaignCustomerUsageData)(nil),       // 8: promo.v1.CampaignCustomerUsageData
+	(*CampaignPeriodUsageData)(nil),         // 9: promo.v1.CampaignPeriodUsageData
+	(*CampaignMerchantData)(nil),            // 10: promo.v1.CampaignMerchantData
+	(*CampaignTerminalData)(nil),            // 11: promo.v1.CampaignTerminalData
+	(*CampaignBankData)(nil),                // 12: promo.v1.CampaignBankData
+	(*CampaignCustomerData)(nil),            // 13: promo.v1.CampaignCustomerData
+	(*PromoServiceCheckRequest)(nil),        // 14: promo.v1.PromoServiceCheckRequest
+	(*PromoServiceCheckInput)(nil),          // 15: promo.v1.PromoServiceCheckInput
+	(*PromoServiceCheckOutput)(nil),         // 16: promo.v1.PromoServiceCheckOutput
+	(*PromoServiceCheckResponse)(nil),       // 17: promo.v1.PromoServiceCheckResponse
+	(*PromoServiceCheckStreamRequest)(nil),  // 18: promo.v1.PromoServiceCheckStreamRequest
+	(*PromoServiceCheckStreamResponse)(nil), // 19: promo.v1.PromoServiceCheckStreamResponse
+	(*timestamp.Timestamp)(nil),             // 20: google.protobuf.Timestamp
+	(*wrappers.StringValue)(nil),            // 21: google.protobuf.StringValue
+	(*wrappers.Int64Value)(nil),             // 22: google.protobuf.Int64Value
 }
 var file_promo_proto_depIdxs = []int32{
-	6, // 0: promo.v1.BlacklistCustomerData.start_time:type_name -> google.protobuf.Timestamp
-	6, // 1: promo.v1.BlacklistCustomerData.end_time:type_name -> google.protobuf.Timestamp
-	6, // 2: promo.v1.BlacklistMerchantData.start_time:type_name -> google.protobuf.Timestamp
-	6, // 3: promo.v1.BlacklistMerchantData.end_time:type_name -> google.protobuf.Timestamp
-	3, // 4: promo.v1.PromoServiceCheckRequest.inputs:type_name -> promo.v1.PromoServiceCheckInput
-	6, // 5: promo.v1.PromoServiceCheckRequest.req_time:type_name -> google.protobuf.Timestamp
-	4, // 6: promo.v1.PromoServiceCheckResponse.outputs:type_name -> promo.v1.PromoServiceCheckOutput
-	2, // 7: promo.v1.PromoService.Check:input_type -> promo.v1.PromoServiceCheckRequest
-	5, // 8: promo.v1.PromoService.Check:output_type -> promo.v1.PromoServiceCheckResponse
-	8, // [8:9] is the sub-list for method output_type
-	7, // [7:8] is the sub-list for method input_type
-	7, // [7:7] is the sub-list for extension type_name
-	7, // [7:7] is the sub-list for extension extendee
-	0, // [0:7] is the sub-list for field type_name
+	20, // 0: promo.v1.BlacklistCustomerData.start_time:type_name -> google.protobuf.Timestamp
+	20, // 1: promo.v1.BlacklistCustomerData.end_time:type_name -> google.protobuf.Timestamp
+	20, // 2: promo.v1.BlacklistMerchantData.start_time:type_name -> google.protobuf.Timestamp
+	20, // 3: promo.v1.BlacklistMerchantData.end_time:type_name -> google.protobuf.Timestamp
+	20, // 4: promo.v1.BlacklistTerminalData.start_time:type_name -> google.protobuf.Timestamp
+	20, // 5: promo.v1.BlacklistTerminalData.end_time:type_name -> google.protobuf.Timestamp
+	20, // 6: promo.v1.CampaignData.start_time:type_name -> google.protobuf.Timestamp
+	20, // 7: promo.v1.CampaignData.end_time:type_name -> google.protobuf.Timestamp
+	21, // 8: promo.v1.CampaignData.budget_max:type_name -> google.protobuf.StringValue
+	22, // 9: promo.v1.CampaignData.campaign_usage_max:type_name -> google.protobuf.Int64Value
+	22, // 10: promo.v1.CampaignData.period_customer_usage_max:type_name -> google.protobuf.Int64Value
+	20, // 11: promo.v1.CampaignBenefitData.start_time:type_name -> google.protobuf.Timestamp
+	20, // 12: promo.v1.CampaignBenefitData.end_time:type_name -> google.protobuf.Timestamp
+	5,  // 13: promo.v1.CampaignBenefitListData.benefits:type_name -> promo.v1.CampaignBenefitData
+	20, // 14: promo.v1.CampaignPeriodUsageData.expired_on:type_name -> google.protobuf.Timestamp
+	20, // 15: promo.v1.CampaignMerchantData.start_time:type_name -> google.protobuf.Timestamp
+	20, // 16: promo.v1.CampaignMerchantData.end_time:type_name -> google.protobuf.Timestamp
+	20, // 17: promo.v1.CampaignTerminalData.start_time:type_name -> google.protobuf.Timestamp
+	20, // 18: promo.v1.CampaignTerminalData.end_time:type_name -> google.protobuf.Timestamp
+	20, // 19: promo.v1.CampaignBankData.start_time:type_name -> google.protobuf.Timestamp
+	20, // 20: promo.v1.CampaignBankData.end_time:type_name -> google.protobuf.Timestamp
+	20, // 21: promo.v1.CampaignCustomerData.start_time:type_name -> google.protobuf.Timestamp
+	20, // 22: promo.v1.CampaignCustomerData.end_time:type_name -> google.protobuf.Timestamp
+	15, // 23: promo.v1.PromoServiceCheckRequest.inputs:type_name -> promo.v1.PromoServiceCheckInput
+	20, // 24: promo.v1.PromoServiceCheckRequest.req_time:type_name -> google.protobuf.Timestamp
+	20, // 25: promo.v1.PromoServiceCheckInput.req_time:type_name -> google.protobuf.Timestamp
+	0,  // 26: promo.v1.PromoServiceCheckOutput.reason:type_name -> promo.v1.CheckReason
+	16, // 27: promo.v1.PromoServiceCheckResponse.outputs:type_name -> promo.v1.PromoServiceCheckOutput
+	15, // 28: promo.v1.PromoServiceCheckStreamRequest.input:type_name -> promo.v1.PromoServiceCheckInput
+	16, // 29: promo.v1.PromoServiceCheckStreamResponse.output:type_name -> promo.v1.PromoServiceCheckOutput
+	14, // 30: promo.v1.PromoService.Check:input_type -> promo.v1.PromoServiceCheckRequest
+	18, // 31: promo.v1.PromoService.CheckStream:input_type -> promo.v1.PromoServiceCheckStreamRequest
+	17, // 32: promo.v1.PromoService.Check:output_type -> promo.v1.PromoServiceCheckResponse
+	19, // 33: promo.v1.PromoService.CheckStream:output_type -> promo.v1.PromoServiceCheckStreamResponse
+	32, // [32:34] is the sub-list for method output_type
+	30, // [30:32] is the sub-list for method input_type
+	30, // [30:30] is the sub-list for extension type_name
+	30, // [30:30] is the sub-list for extension extendee
+	0,  // [0:30] is the sub-list for field type_name
 }
 
 func init() { file_promo_proto_init() }
@@ -563,7 +2100,7 @@ func file_promo_proto_init() {
 			}
 		}
 		file_promo_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PromoServiceCheckRequest); i {
+			switch v := v.(*BlacklistTerminalData); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -575,7 +2112,7 @@ func file_promo_proto_init() {
 			}
 		}
 		file_promo_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PromoServiceCheckInput); i {
+			switch v := v.(*CampaignData); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -587,7 +2124,7 @@ func file_promo_proto_init() {
 			}
 		}
 		file_promo_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PromoServiceCheckOutput); i {
+			switch v := v.(*CampaignBenefitData); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -599,6 +2136,138 @@ func file_promo_proto_init() {
 			}
 		}
 		file_promo_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CampaignBenefitListData); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_promo_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CampaignUsageData); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_promo_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CampaignCustomerUsageData); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_promo_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CampaignPeriodUsageData); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_promo_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CampaignMerchantData); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_promo_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CampaignTerminalData); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_promo_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CampaignBankData); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_promo_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CampaignCustomerData); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_promo_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PromoServiceCheckRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_promo_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PromoServiceCheckInput); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_promo_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PromoServiceCheckOutput); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_promo_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*PromoServiceCheckResponse); i {
 			case 0:
 				return &v.state
@@ -610,19 +2279,44 @@ func file_promo_proto_init() {
 				return nil
 			}
 		}
+		file_promo_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PromoServiceCheckStreamRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_promo_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PromoServiceCheckStreamResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_promo_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   6,
+			NumEnums:      1,
+			NumMessages:   19,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_promo_proto_goTypes,
 		DependencyIndexes: file_promo_proto_depIdxs,
+		EnumInfos:         file_promo_proto_enumTypes,
 		MessageInfos:      file_promo_proto_msgTypes,
 	}.Build()
 	File_promo_proto = out.File
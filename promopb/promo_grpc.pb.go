@@ -19,6 +19,8 @@ const _ = grpc.SupportPackageIsVersion7
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type PromoServiceClient interface {
 	Check(ctx context.Context, in *PromoServiceCheckRequest, opts ...grpc.CallOption) (*PromoServiceCheckResponse, error)
+	// CheckStream micro-batches inputs of the stream, outputs are returned in input order
+	CheckStream(ctx context.Context, opts ...grpc.CallOption) (PromoService_CheckStreamClient, error)
 }
 
 type promoServiceClient struct {
@@ -38,11 +40,44 @@ func (c *promoServiceClient) Check(ctx context.Context, in *PromoServiceCheckReq
 	return out, nil
 }
 
+func (c *promoServiceClient) CheckStream(ctx context.Context, opts ...grpc.CallOption) (PromoService_CheckStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &PromoService_ServiceDesc.Streams[0], "/promo.v1.PromoService/CheckStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &promoServiceCheckStreamClient{stream}
+	return x, nil
+}
+
+type PromoService_CheckStreamClient interface {
+	Send(*PromoServiceCheckStreamRequest) error
+	Recv() (*PromoServiceCheckStreamResponse, error)
+	grpc.ClientStream
+}
+
+type promoServiceCheckStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *promoServiceCheckStreamClient) Send(m *PromoServiceCheckStreamRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *promoServiceCheckStreamClient) Recv() (*PromoServiceCheckStreamResponse, error) {
+	m := new(PromoServiceCheckStreamResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // PromoServiceServer is the server API for PromoService service.
 // All implementations must embed UnimplementedPromoServiceServer
 // for forward compatibility
 type PromoServiceServer interface {
 	Check(context.Context, *PromoServiceCheckRequest) (*PromoServiceCheckResponse, error)
+	// CheckStream micro-batches inputs of the stream, outputs are returned in input order
+	CheckStream(PromoService_CheckStreamServer) error
 	mustEmbedUnimplementedPromoServiceServer()
 }
 
@@ -53,6 +88,9 @@ type UnimplementedPromoServiceServer struct {
 func (UnimplementedPromoServiceServer) Check(context.Context, *PromoServiceCheckRequest) (*PromoServiceCheckResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Check not implemented")
 }
+func (UnimplementedPromoServiceServer) CheckStream(PromoService_CheckStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method CheckStream not implemented")
+}
 func (UnimplementedPromoServiceServer) mustEmbedUnimplementedPromoServiceServer() {}
 
 // UnsafePromoServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -84,6 +122,32 @@ func _PromoService_Check_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PromoService_CheckStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(PromoServiceServer).CheckStream(&promoServiceCheckStreamServer{stream})
+}
+
+type PromoService_CheckStreamServer interface {
+	Send(*PromoServiceCheckStreamResponse) error
+	Recv() (*PromoServiceCheckStreamRequest, error)
+	grpc.ServerStream
+}
+
+type promoServiceCheckStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *promoServiceCheckStreamServer) Send(m *PromoServiceCheckStreamResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *promoServiceCheckStreamServer) Recv() (*PromoServiceCheckStreamRequest, error) {
+	m := new(PromoServiceCheckStreamRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // PromoService_ServiceDesc is the grpc.ServiceDesc for PromoService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -96,6 +160,13 @@ var PromoService_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _PromoService_Check_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "CheckStream",
+			Handler:       _PromoService_CheckStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
 	Metadata: "promo.proto",
 }
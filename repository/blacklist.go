@@ -25,6 +25,7 @@ type Blacklist interface {
 	UpsertBlacklistMerchants(ctx context.Context, merchants []model.BlacklistMerchant) error
 
 	GetBlacklistTerminals(ctx context.Context, keys []BlacklistTerminalKey) ([]model.BlacklistTerminal, error)
+	SelectBlacklistTerminals(ctx context.Context, ranges []HashRange) ([]model.BlacklistTerminal, error)
 	UpsertBlacklistTerminals(ctx context.Context, terminals []model.BlacklistTerminal) error
 }
 
@@ -292,6 +293,44 @@ FROM blacklist_terminal WHERE (hash, merchant_code, terminal_code) IN (%s)
 	return result, err
 }
 
+// SelectBlacklistTerminals ...
+func (b *blacklistRepo) SelectBlacklistTerminals(
+	ctx context.Context, ranges []HashRange,
+) ([]model.BlacklistTerminal, error) {
+	if len(ranges) == 0 {
+		return nil, nil
+	}
+
+	var buf strings.Builder
+	query := `
+SELECT hash, merchant_code, terminal_code, status, start_time, end_time
+FROM blacklist_terminal WHERE hash >= ?%s
+`
+
+	withEndQuery := fmt.Sprintf(query, " AND hash < ?")
+	noEndQuery := fmt.Sprintf(query, "")
+
+	args := make([]interface{}, 0, 2*len(ranges))
+
+	for i, r := range ranges {
+		if i > 0 {
+			buf.WriteString("UNION ALL")
+		}
+		args = append(args, r.Begin)
+
+		if r.End.Valid {
+			buf.WriteString(withEndQuery)
+			args = append(args, r.End.Num)
+		} else {
+			buf.WriteString(noEndQuery)
+		}
+	}
+
+	var result []model.BlacklistTerminal
+	err := GetReadonly(ctx).SelectContext(ctx, &result, buf.String(), args...)
+	return result, err
+}
+
 // UpsertBlacklistTerminals ...
 func (b *blacklistRepo) UpsertBlacklistTerminals(ctx context.Context, terminals []model.BlacklistTerminal) error {
 	if len(terminals) == 0 {
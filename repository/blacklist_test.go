@@ -82,6 +82,10 @@ func TestBlacklist_Empty_Keys(t *testing.T) {
 	merchants, err = repo.SelectBlacklistMerchants(ctx, nil)
 	assert.Equal(t, nil, err)
 	assert.Equal(t, 0, len(merchants))
+
+	terminals, err = repo.SelectBlacklistTerminals(ctx, nil)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 0, len(terminals))
 }
 
 func TestBlacklist_Customers(t *testing.T) {
@@ -402,6 +406,33 @@ func TestBlacklist_Terminals(t *testing.T) {
 	terminals, err = repo.GetBlacklistTerminals(ctx, []BlacklistTerminalKey{key01, key02})
 	assert.Equal(t, nil, err)
 	assert.Equal(t, upsertTerminals, terminals)
+
+	//---------------------------------------
+	// Select Terminals
+	//---------------------------------------
+	terminals, err = repo.SelectBlacklistTerminals(ctx, []HashRange{
+		{
+			Begin: hash01,
+			End:   newNullUint32(hash01 + 1),
+		},
+		{
+			Begin: hash02,
+		},
+	})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, upsertTerminals, terminals)
+
+	//---------------------------------------
+	// Select Terminals 1
+	//---------------------------------------
+	terminals, err = repo.SelectBlacklistTerminals(ctx, []HashRange{
+		{
+			Begin: hash01 + 1,
+			End:   newNullUint32(hash02),
+		},
+	})
+	assert.Equal(t, nil, err)
+	assert.Nil(t, terminals)
 }
 
 func TestBlacklist_Config(t *testing.T) {
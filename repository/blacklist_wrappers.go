@@ -143,6 +143,19 @@ func (w *BlacklistWrapper) GetBlacklistTerminals(ctx context.Context, keys []Bla
 	return a, err
 }
 
+// SelectBlacklistTerminals ...
+func (w *BlacklistWrapper) SelectBlacklistTerminals(ctx context.Context, ranges []HashRange) (a []model.BlacklistTerminal, err error) {
+	ctx, span := w.tracer.Start(ctx, w.prefix+"SelectBlacklistTerminals")
+	defer span.End()
+
+	a, err = w.Blacklist.SelectBlacklistTerminals(ctx, ranges)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return a, err
+}
+
 // UpsertBlacklistTerminals ...
 func (w *BlacklistWrapper) UpsertBlacklistTerminals(ctx context.Context, terminals []model.BlacklistTerminal) (err error) {
 	ctx, span := w.tracer.Start(ctx, w.prefix+"UpsertBlacklistTerminals")
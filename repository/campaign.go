@@ -2,17 +2,102 @@ package repository
 
 import (
 	"context"
+	"fmt"
 	"github.com/QuangTung97/promo-readonly/model"
+	"strings"
 	"time"
 )
 
+//go:generate moq -rm -out campaign_mocks.go . Campaign
+//go:generate otelwrap --out campaign_wrappers.go . Campaign
+
 // Campaign ...
 type Campaign interface {
+	CountCampaigns(ctx context.Context) (int64, error)
+
 	FindCampaignsByVoucher(
 		ctx context.Context, voucherHash uint32, voucherCode string, now time.Time,
 	) ([]model.Campaign, error)
+	GetCampaignsByVouchers(ctx context.Context, keys []CampaignVoucherKey) ([]model.Campaign, error)
+	SelectCampaignsByHashRange(ctx context.Context, ranges []HashRange) ([]model.Campaign, error)
+
 	GetCampaignWithLock(ctx context.Context, campaignID int64) (model.Campaign, error)
 	UpsertCampaign(ctx context.Context, campaign model.Campaign) error
+
+	GetCampaignBenefits(ctx context.Context, campaignIDs []int64) ([]model.CampaignBenefit, error)
+	UpsertCampaignBenefits(ctx context.Context, benefits []model.CampaignBenefit) error
+
+	CountCampaignMerchants(ctx context.Context) (int64, error)
+	GetCampaignMerchants(ctx context.Context, keys []CampaignMerchantKey) ([]model.CampaignMerchant, error)
+	SelectCampaignMerchants(ctx context.Context, ranges []HashRange) ([]model.CampaignMerchant, error)
+	UpsertCampaignMerchants(ctx context.Context, merchants []model.CampaignMerchant) error
+
+	CountCampaignTerminals(ctx context.Context) (int64, error)
+	GetCampaignTerminals(ctx context.Context, keys []CampaignTerminalKey) ([]model.CampaignTerminal, error)
+	SelectCampaignTerminals(ctx context.Context, ranges []HashRange) ([]model.CampaignTerminal, error)
+	UpsertCampaignTerminals(ctx context.Context, terminals []model.CampaignTerminal) error
+
+	CountCampaignBanks(ctx context.Context) (int64, error)
+	GetCampaignBanks(ctx context.Context, keys []CampaignBankKey) ([]model.CampaignBank, error)
+	SelectCampaignBanks(ctx context.Context, ranges []HashRange) ([]model.CampaignBank, error)
+	UpsertCampaignBanks(ctx context.Context, banks []model.CampaignBank) error
+
+	CountCampaignCustomers(ctx context.Context) (int64, error)
+	GetCampaignCustomers(ctx context.Context, keys []CampaignCustomerKey) ([]model.CampaignCustomer, error)
+	SelectCampaignCustomers(ctx context.Context, ranges []HashRange) ([]model.CampaignCustomer, error)
+	UpsertCampaignCustomers(ctx context.Context, customers []model.CampaignCustomer) error
+
+	GetCampaignUsages(ctx context.Context, campaignIDs []int64) ([]model.CampaignUsage, error)
+	UpsertCampaignUsages(ctx context.Context, usages []model.CampaignUsage) error
+
+	GetCampaignCustomerUsages(ctx context.Context, keys []CampaignCustomerKey) ([]model.CampaignCustomerUsage, error)
+	UpsertCampaignCustomerUsages(ctx context.Context, usages []model.CampaignCustomerUsage) error
+
+	GetCampaignPeriodUsages(ctx context.Context, keys []CampaignPeriodUsageKey) ([]model.CampaignPeriodUsage, error)
+	UpsertCampaignPeriodUsages(ctx context.Context, usages []model.CampaignPeriodUsage) error
+}
+
+// CampaignVoucherKey ...
+type CampaignVoucherKey struct {
+	VoucherHash uint32
+	VoucherCode string
+}
+
+// CampaignMerchantKey ...
+type CampaignMerchantKey struct {
+	CampaignID   int64
+	Hash         uint32
+	MerchantCode string
+}
+
+// CampaignTerminalKey ...
+type CampaignTerminalKey struct {
+	CampaignID   int64
+	Hash         uint32
+	MerchantCode string
+	TerminalCode string
+}
+
+// CampaignBankKey ...
+type CampaignBankKey struct {
+	CampaignID int64
+	Hash       uint32
+	BankCode   string
+}
+
+// CampaignCustomerKey ...
+type CampaignCustomerKey struct {
+	CampaignID int64
+	Hash       uint32
+	Phone      string
+}
+
+// CampaignPeriodUsageKey ...
+type CampaignPeriodUsageKey struct {
+	CampaignID int64
+	Hash       uint32
+	Phone      string
+	TermCode   string
 }
 
 type campaignImpl struct {
@@ -23,12 +108,25 @@ func NewCampaign() Campaign {
 	return &campaignImpl{}
 }
 
+const campaignColumns = `id, name, status, type, voucher_hash, voucher_code, start_time, end_time,
+	budget_max, campaign_usage_max, customer_usage_max,
+	period_usage_type, period_customer_usage_max, period_term_type,
+	all_merchants`
+
+// CountCampaigns ...
+func (c *campaignImpl) CountCampaigns(ctx context.Context) (int64, error) {
+	query := `SELECT COUNT(*) FROM campaign`
+	var count int64
+	err := GetReadonly(ctx).GetContext(ctx, &count, query)
+	return count, err
+}
+
 // FindCampaignsByVoucher ...
 func (c *campaignImpl) FindCampaignsByVoucher(
 	ctx context.Context, voucherHash uint32, voucherCode string, now time.Time,
 ) ([]model.Campaign, error) {
 	query := `
-SELECT id, voucher_hash, voucher_code, start_time, end_time
+SELECT ` + campaignColumns + `
 FROM campaign
 WHERE voucher_hash = ? AND voucher_code = ? AND ? < end_time
 `
@@ -37,15 +135,80 @@ WHERE voucher_hash = ? AND voucher_code = ? AND ? < end_time
 	return result, err
 }
 
+// GetCampaignsByVouchers ...
+func (c *campaignImpl) GetCampaignsByVouchers(
+	ctx context.Context, keys []CampaignVoucherKey,
+) ([]model.Campaign, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	const placeholder = "(?, ?)"
+	var buf strings.Builder
+	buf.WriteString(placeholder)
+	for range keys[1:] {
+		buf.WriteString("," + placeholder)
+	}
+
+	query := fmt.Sprintf(`
+SELECT %s
+FROM campaign WHERE (voucher_hash, voucher_code) IN (%s)
+`, campaignColumns, buf.String())
+
+	args := make([]interface{}, 0, 2*len(keys))
+	for _, key := range keys {
+		args = append(args, key.VoucherHash, key.VoucherCode)
+	}
+
+	var result []model.Campaign
+	err := GetReadonly(ctx).SelectContext(ctx, &result, query, args...)
+	return result, err
+}
+
+// SelectCampaignsByHashRange ...
+func (c *campaignImpl) SelectCampaignsByHashRange(
+	ctx context.Context, ranges []HashRange,
+) ([]model.Campaign, error) {
+	if len(ranges) == 0 {
+		return nil, nil
+	}
+
+	var buf strings.Builder
+	query := `
+SELECT ` + campaignColumns + `
+FROM campaign WHERE voucher_hash >= ?%s
+`
+
+	withEndQuery := fmt.Sprintf(query, " AND voucher_hash < ?")
+	noEndQuery := fmt.Sprintf(query, "")
+
+	args := make([]interface{}, 0, 2*len(ranges))
+
+	for i, r := range ranges {
+		if i > 0 {
+			buf.WriteString("UNION ALL")
+		}
+		args = append(args, r.Begin)
+
+		if r.End.Valid {
+			buf.WriteString(withEndQuery)
+			args = append(args, r.End.Num)
+		} else {
+			buf.WriteString(noEndQuery)
+		}
+	}
+
+	var result []model.Campaign
+	err := GetReadonly(ctx).SelectContext(ctx, &result, buf.String(), args...)
+	return result, err
+}
+
 // GetCampaignWithLock ...
 func (c *campaignImpl) GetCampaignWithLock(ctx context.Context, campaignID int64) (model.Campaign, error) {
-	query := `
-SELECT id, name, status, type, voucher_hash, voucher_code, start_time, end_time,
-	budget_max, campaign_usage_max, customer_usage_max,
-	period_usage_type, period_customer_usage_max, period_term_type,
-	all_merchants
+	query := fmt.Sprintf(`
+SELECT %s
 FROM campaign WHERE id = ? FOR UPDATE
-`
+`, campaignColumns)
 	var result model.Campaign
 	err := GetTx(ctx).GetContext(ctx, &result, query, campaignID)
 	return result, err
@@ -88,3 +251,583 @@ ON DUPLICATE KEY UPDATE
 	_, err := GetTx(ctx).NamedExecContext(ctx, query, campaign)
 	return err
 }
+
+// GetCampaignBenefits ...
+func (c *campaignImpl) GetCampaignBenefits(
+	ctx context.Context, campaignIDs []int64,
+) ([]model.CampaignBenefit, error) {
+	if len(campaignIDs) == 0 {
+		return nil, nil
+	}
+
+	const placeholder = "?"
+	var buf strings.Builder
+	buf.WriteString(placeholder)
+	for range campaignIDs[1:] {
+		buf.WriteString("," + placeholder)
+	}
+
+	query := fmt.Sprintf(`
+SELECT id, campaign_id, start_time, end_time,
+	txn_min_amount, discount_percent, max_discount_amount
+FROM campaign_benefit WHERE campaign_id IN (%s)
+`, buf.String())
+
+	args := make([]interface{}, 0, len(campaignIDs))
+	for _, id := range campaignIDs {
+		args = append(args, id)
+	}
+
+	var result []model.CampaignBenefit
+	err := GetReadonly(ctx).SelectContext(ctx, &result, query, args...)
+	return result, err
+}
+
+// UpsertCampaignBenefits ...
+func (c *campaignImpl) UpsertCampaignBenefits(ctx context.Context, benefits []model.CampaignBenefit) error {
+	if len(benefits) == 0 {
+		return nil
+	}
+
+	query := `
+INSERT INTO campaign_benefit (
+	id, campaign_id, start_time, end_time,
+	txn_min_amount, discount_percent, max_discount_amount
+) VALUES (
+	:id, :campaign_id, :start_time, :end_time,
+	:txn_min_amount, :discount_percent, :max_discount_amount
+) AS NEW
+ON DUPLICATE KEY UPDATE
+	campaign_id = NEW.campaign_id,
+	start_time = NEW.start_time,
+	end_time = NEW.end_time,
+	txn_min_amount = NEW.txn_min_amount,
+	discount_percent = NEW.discount_percent,
+	max_discount_amount = NEW.max_discount_amount
+`
+	_, err := GetTx(ctx).NamedExecContext(ctx, query, benefits)
+	return err
+}
+
+// CountCampaignMerchants ...
+func (c *campaignImpl) CountCampaignMerchants(ctx context.Context) (int64, error) {
+	query := `SELECT COUNT(*) FROM campaign_merchant`
+	var count int64
+	err := GetReadonly(ctx).GetContext(ctx, &count, query)
+	return count, err
+}
+
+// GetCampaignMerchants ...
+func (c *campaignImpl) GetCampaignMerchants(
+	ctx context.Context, keys []CampaignMerchantKey,
+) ([]model.CampaignMerchant, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	const placeholder = "(?, ?, ?)"
+	var buf strings.Builder
+	buf.WriteString(placeholder)
+	for range keys[1:] {
+		buf.WriteString("," + placeholder)
+	}
+
+	query := fmt.Sprintf(`
+SELECT campaign_id, hash, merchant_code, status, start_time, end_time, all_terminals
+FROM campaign_merchant WHERE (campaign_id, hash, merchant_code) IN (%s)
+`, buf.String())
+
+	args := make([]interface{}, 0, 3*len(keys))
+	for _, key := range keys {
+		args = append(args, key.CampaignID, key.Hash, key.MerchantCode)
+	}
+
+	var result []model.CampaignMerchant
+	err := GetReadonly(ctx).SelectContext(ctx, &result, query, args...)
+	return result, err
+}
+
+// SelectCampaignMerchants ...
+func (c *campaignImpl) SelectCampaignMerchants(
+	ctx context.Context, ranges []HashRange,
+) ([]model.CampaignMerchant, error) {
+	if len(ranges) == 0 {
+		return nil, nil
+	}
+
+	var buf strings.Builder
+	query := `
+SELECT campaign_id, hash, merchant_code, status, start_time, end_time, all_terminals
+FROM campaign_merchant WHERE hash >= ?%s
+`
+
+	withEndQuery := fmt.Sprintf(query, " AND hash < ?")
+	noEndQuery := fmt.Sprintf(query, "")
+
+	args := make([]interface{}, 0, 2*len(ranges))
+
+	for i, r := range ranges {
+		if i > 0 {
+			buf.WriteString("UNION ALL")
+		}
+		args = append(args, r.Begin)
+
+		if r.End.Valid {
+			buf.WriteString(withEndQuery)
+			args = append(args, r.End.Num)
+		} else {
+			buf.WriteString(noEndQuery)
+		}
+	}
+
+	var result []model.CampaignMerchant
+	err := GetReadonly(ctx).SelectContext(ctx, &result, buf.String(), args...)
+	return result, err
+}
+
+// UpsertCampaignMerchants ...
+func (c *campaignImpl) UpsertCampaignMerchants(ctx context.Context, merchants []model.CampaignMerchant) error {
+	if len(merchants) == 0 {
+		return nil
+	}
+
+	query := `
+INSERT INTO campaign_merchant (
+	campaign_id, hash, merchant_code, status, start_time, end_time, all_terminals
+) VALUES (
+	:campaign_id, :hash, :merchant_code, :status, :start_time, :end_time, :all_terminals
+) AS NEW
+ON DUPLICATE KEY UPDATE
+	status = NEW.status,
+	start_time = NEW.start_time,
+	end_time = NEW.end_time,
+	all_terminals = NEW.all_terminals
+`
+	_, err := GetTx(ctx).NamedExecContext(ctx, query, merchants)
+	return err
+}
+
+// CountCampaignTerminals ...
+func (c *campaignImpl) CountCampaignTerminals(ctx context.Context) (int64, error) {
+	query := `SELECT COUNT(*) FROM campaign_terminal`
+	var count int64
+	err := GetReadonly(ctx).GetContext(ctx, &count, query)
+	return count, err
+}
+
+// GetCampaignTerminals ...
+func (c *campaignImpl) GetCampaignTerminals(
+	ctx context.Context, keys []CampaignTerminalKey,
+) ([]model.CampaignTerminal, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	const placeholder = "(?, ?, ?, ?)"
+	var buf strings.Builder
+	buf.WriteString(placeholder)
+	for range keys[1:] {
+		buf.WriteString("," + placeholder)
+	}
+
+	query := fmt.Sprintf(`
+SELECT campaign_id, hash, merchant_code, terminal_code, status, start_time, end_time
+FROM campaign_terminal WHERE (campaign_id, hash, merchant_code, terminal_code) IN (%s)
+`, buf.String())
+
+	args := make([]interface{}, 0, 4*len(keys))
+	for _, key := range keys {
+		args = append(args, key.CampaignID, key.Hash, key.MerchantCode, key.TerminalCode)
+	}
+
+	var result []model.CampaignTerminal
+	err := GetReadonly(ctx).SelectContext(ctx, &result, query, args...)
+	return result, err
+}
+
+// SelectCampaignTerminals ...
+func (c *campaignImpl) SelectCampaignTerminals(
+	ctx context.Context, ranges []HashRange,
+) ([]model.CampaignTerminal, error) {
+	if len(ranges) == 0 {
+		return nil, nil
+	}
+
+	var buf strings.Builder
+	query := `
+SELECT campaign_id, hash, merchant_code, terminal_code, status, start_time, end_time
+FROM campaign_terminal WHERE hash >= ?%s
+`
+
+	withEndQuery := fmt.Sprintf(query, " AND hash < ?")
+	noEndQuery := fmt.Sprintf(query, "")
+
+	args := make([]interface{}, 0, 2*len(ranges))
+
+	for i, r := range ranges {
+		if i > 0 {
+			buf.WriteString("UNION ALL")
+		}
+		args = append(args, r.Begin)
+
+		if r.End.Valid {
+			buf.WriteString(withEndQuery)
+			args = append(args, r.End.Num)
+		} else {
+			buf.WriteString(noEndQuery)
+		}
+	}
+
+	var result []model.CampaignTerminal
+	err := GetReadonly(ctx).SelectContext(ctx, &result, buf.String(), args...)
+	return result, err
+}
+
+// UpsertCampaignTerminals ...
+func (c *campaignImpl) UpsertCampaignTerminals(ctx context.Context, terminals []model.CampaignTerminal) error {
+	if len(terminals) == 0 {
+		return nil
+	}
+
+	query := `
+INSERT INTO campaign_terminal (
+	campaign_id, hash, merchant_code, terminal_code, status, start_time, end_time
+) VALUES (
+	:campaign_id, :hash, :merchant_code, :terminal_code, :status, :start_time, :end_time
+) AS NEW
+ON DUPLICATE KEY UPDATE
+	status = NEW.status,
+	start_time = NEW.start_time,
+	end_time = NEW.end_time
+`
+	_, err := GetTx(ctx).NamedExecContext(ctx, query, terminals)
+	return err
+}
+
+// CountCampaignBanks ...
+func (c *campaignImpl) CountCampaignBanks(ctx context.Context) (int64, error) {
+	query := `SELECT COUNT(*) FROM campaign_bank`
+	var count int64
+	err := GetReadonly(ctx).GetContext(ctx, &count, query)
+	return count, err
+}
+
+// GetCampaignBanks ...
+func (c *campaignImpl) GetCampaignBanks(
+	ctx context.Context, keys []CampaignBankKey,
+) ([]model.CampaignBank, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	const placeholder = "(?, ?, ?)"
+	var buf strings.Builder
+	buf.WriteString(placeholder)
+	for range keys[1:] {
+		buf.WriteString("," + placeholder)
+	}
+
+	query := fmt.Sprintf(`
+SELECT campaign_id, hash, bank_code, status, start_time, end_time
+FROM campaign_bank WHERE (campaign_id, hash, bank_code) IN (%s)
+`, buf.String())
+
+	args := make([]interface{}, 0, 3*len(keys))
+	for _, key := range keys {
+		args = append(args, key.CampaignID, key.Hash, key.BankCode)
+	}
+
+	var result []model.CampaignBank
+	err := GetReadonly(ctx).SelectContext(ctx, &result, query, args...)
+	return result, err
+}
+
+// SelectCampaignBanks ...
+func (c *campaignImpl) SelectCampaignBanks(
+	ctx context.Context, ranges []HashRange,
+) ([]model.CampaignBank, error) {
+	if len(ranges) == 0 {
+		return nil, nil
+	}
+
+	var buf strings.Builder
+	query := `
+SELECT campaign_id, hash, bank_code, status, start_time, end_time
+FROM campaign_bank WHERE hash >= ?%s
+`
+
+	withEndQuery := fmt.Sprintf(query, " AND hash < ?")
+	noEndQuery := fmt.Sprintf(query, "")
+
+	args := make([]interface{}, 0, 2*len(ranges))
+
+	for i, r := range ranges {
+		if i > 0 {
+			buf.WriteString("UNION ALL")
+		}
+		args = append(args, r.Begin)
+
+		if r.End.Valid {
+			buf.WriteString(withEndQuery)
+			args = append(args, r.End.Num)
+		} else {
+			buf.WriteString(noEndQuery)
+		}
+	}
+
+	var result []model.CampaignBank
+	err := GetReadonly(ctx).SelectContext(ctx, &result, buf.String(), args...)
+	return result, err
+}
+
+// UpsertCampaignBanks ...
+func (c *campaignImpl) UpsertCampaignBanks(ctx context.Context, banks []model.CampaignBank) error {
+	if len(banks) == 0 {
+		return nil
+	}
+
+	query := `
+INSERT INTO campaign_bank (campaign_id, hash, bank_code, status, start_time, end_time)
+VALUES (:campaign_id, :hash, :bank_code, :status, :start_time, :end_time) AS NEW
+ON DUPLICATE KEY UPDATE
+	status = NEW.status,
+	start_time = NEW.start_time,
+	end_time = NEW.end_time
+`
+	_, err := GetTx(ctx).NamedExecContext(ctx, query, banks)
+	return err
+}
+
+// CountCampaignCustomers ...
+func (c *campaignImpl) CountCampaignCustomers(ctx context.Context) (int64, error) {
+	query := `SELECT COUNT(*) FROM campaign_customer`
+	var count int64
+	err := GetReadonly(ctx).GetContext(ctx, &count, query)
+	return count, err
+}
+
+// GetCampaignCustomers ...
+func (c *campaignImpl) GetCampaignCustomers(
+	ctx context.Context, keys []CampaignCustomerKey,
+) ([]model.CampaignCustomer, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	const placeholder = "(?, ?, ?)"
+	var buf strings.Builder
+	buf.WriteString(placeholder)
+	for range keys[1:] {
+		buf.WriteString("," + placeholder)
+	}
+
+	query := fmt.Sprintf(`
+SELECT campaign_id, hash, phone, status, start_time, end_time
+FROM campaign_customer WHERE (campaign_id, hash, phone) IN (%s)
+`, buf.String())
+
+	args := make([]interface{}, 0, 3*len(keys))
+	for _, key := range keys {
+		args = append(args, key.CampaignID, key.Hash, key.Phone)
+	}
+
+	var result []model.CampaignCustomer
+	err := GetReadonly(ctx).SelectContext(ctx, &result, query, args...)
+	return result, err
+}
+
+// SelectCampaignCustomers ...
+func (c *campaignImpl) SelectCampaignCustomers(
+	ctx context.Context, ranges []HashRange,
+) ([]model.CampaignCustomer, error) {
+	if len(ranges) == 0 {
+		return nil, nil
+	}
+
+	var buf strings.Builder
+	query := `
+SELECT campaign_id, hash, phone, status, start_time, end_time
+FROM campaign_customer WHERE hash >= ?%s
+`
+
+	withEndQuery := fmt.Sprintf(query, " AND hash < ?")
+	noEndQuery := fmt.Sprintf(query, "")
+
+	args := make([]interface{}, 0, 2*len(ranges))
+
+	for i, r := range ranges {
+		if i > 0 {
+			buf.WriteString("UNION ALL")
+		}
+		args = append(args, r.Begin)
+
+		if r.End.Valid {
+			buf.WriteString(withEndQuery)
+			args = append(args, r.End.Num)
+		} else {
+			buf.WriteString(noEndQuery)
+		}
+	}
+
+	var result []model.CampaignCustomer
+	err := GetReadonly(ctx).SelectContext(ctx, &result, buf.String(), args...)
+	return result, err
+}
+
+// UpsertCampaignCustomers ...
+func (c *campaignImpl) UpsertCampaignCustomers(ctx context.Context, customers []model.CampaignCustomer) error {
+	if len(customers) == 0 {
+		return nil
+	}
+
+	query := `
+INSERT INTO campaign_customer (campaign_id, hash, phone, status, start_time, end_time)
+VALUES (:campaign_id, :hash, :phone, :status, :start_time, :end_time) AS NEW
+ON DUPLICATE KEY UPDATE
+	status = NEW.status,
+	start_time = NEW.start_time,
+	end_time = NEW.end_time
+`
+	_, err := GetTx(ctx).NamedExecContext(ctx, query, customers)
+	return err
+}
+
+// GetCampaignUsages ...
+func (c *campaignImpl) GetCampaignUsages(
+	ctx context.Context, campaignIDs []int64,
+) ([]model.CampaignUsage, error) {
+	if len(campaignIDs) == 0 {
+		return nil, nil
+	}
+
+	const placeholder = "?"
+	var buf strings.Builder
+	buf.WriteString(placeholder)
+	for range campaignIDs[1:] {
+		buf.WriteString("," + placeholder)
+	}
+
+	query := fmt.Sprintf(`
+SELECT campaign_id, budget_used, campaign_used
+FROM campaign_usage WHERE campaign_id IN (%s)
+`, buf.String())
+
+	args := make([]interface{}, 0, len(campaignIDs))
+	for _, id := range campaignIDs {
+		args = append(args, id)
+	}
+
+	var result []model.CampaignUsage
+	err := GetReadonly(ctx).SelectContext(ctx, &result, query, args...)
+	return result, err
+}
+
+// UpsertCampaignUsages ...
+func (c *campaignImpl) UpsertCampaignUsages(ctx context.Context, usages []model.CampaignUsage) error {
+	if len(usages) == 0 {
+		return nil
+	}
+
+	query := `
+INSERT INTO campaign_usage (campaign_id, budget_used, campaign_used)
+VALUES (:campaign_id, :budget_used, :campaign_used) AS NEW
+ON DUPLICATE KEY UPDATE
+	budget_used = NEW.budget_used,
+	campaign_used = NEW.campaign_used
+`
+	_, err := GetTx(ctx).NamedExecContext(ctx, query, usages)
+	return err
+}
+
+// GetCampaignCustomerUsages ...
+func (c *campaignImpl) GetCampaignCustomerUsages(
+	ctx context.Context, keys []CampaignCustomerKey,
+) ([]model.CampaignCustomerUsage, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	const placeholder = "(?, ?, ?)"
+	var buf strings.Builder
+	buf.WriteString(placeholder)
+	for range keys[1:] {
+		buf.WriteString("," + placeholder)
+	}
+
+	query := fmt.Sprintf(`
+SELECT campaign_id, hash, phone, usage_num
+FROM campaign_customer_usage WHERE (campaign_id, hash, phone) IN (%s)
+`, buf.String())
+
+	args := make([]interface{}, 0, 3*len(keys))
+	for _, key := range keys {
+		args = append(args, key.CampaignID, key.Hash, key.Phone)
+	}
+
+	var result []model.CampaignCustomerUsage
+	err := GetReadonly(ctx).SelectContext(ctx, &result, query, args...)
+	return result, err
+}
+
+// UpsertCampaignCustomerUsages ...
+func (c *campaignImpl) UpsertCampaignCustomerUsages(ctx context.Context, usages []model.CampaignCustomerUsage) error {
+	if len(usages) == 0 {
+		return nil
+	}
+
+	query := `
+INSERT INTO campaign_customer_usage (campaign_id, hash, phone, usage_num)
+VALUES (:campaign_id, :hash, :phone, :usage_num) AS NEW
+ON DUPLICATE KEY UPDATE
+	usage_num = NEW.usage_num
+`
+	_, err := GetTx(ctx).NamedExecContext(ctx, query, usages)
+	return err
+}
+
+// GetCampaignPeriodUsages ...
+func (c *campaignImpl) GetCampaignPeriodUsages(
+	ctx context.Context, keys []CampaignPeriodUsageKey,
+) ([]model.CampaignPeriodUsage, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	const placeholder = "(?, ?, ?, ?)"
+	var buf strings.Builder
+	buf.WriteString(placeholder)
+	for range keys[1:] {
+		buf.WriteString("," + placeholder)
+	}
+
+	query := fmt.Sprintf(`
+SELECT campaign_id, hash, phone, term_code, usage_num, expired_on
+FROM campaign_period_usage WHERE (campaign_id, hash, phone, term_code) IN (%s)
+`, buf.String())
+
+	args := make([]interface{}, 0, 4*len(keys))
+	for _, key := range keys {
+		args = append(args, key.CampaignID, key.Hash, key.Phone, key.TermCode)
+	}
+
+	var result []model.CampaignPeriodUsage
+	err := GetReadonly(ctx).SelectContext(ctx, &result, query, args...)
+	return result, err
+}
+
+// UpsertCampaignPeriodUsages ...
+func (c *campaignImpl) UpsertCampaignPeriodUsages(ctx context.Context, usages []model.CampaignPeriodUsage) error {
+	if len(usages) == 0 {
+		return nil
+	}
+
+	query := `
+INSERT INTO campaign_period_usage (campaign_id, hash, phone, term_code, usage_num, expired_on)
+VALUES (:campaign_id, :hash, :phone, :term_code, :usage_num, :expired_on) AS NEW
+ON DUPLICATE KEY UPDATE
+	usage_num = NEW.usage_num,
+	expired_on = NEW.expired_on
+`
+	_, err := GetTx(ctx).NamedExecContext(ctx, query, usages)
+	return err
+}
@@ -89,13 +89,7 @@ func TestCampaign(t *testing.T) {
 	assert.Equal(t, nil, err)
 
 	campaign01.ID = 1
-	assert.Equal(t, []model.Campaign{{
-		ID:          1,
-		VoucherHash: campaign01.VoucherHash,
-		VoucherCode: campaign01.VoucherCode,
-		StartTime:   campaign01.StartTime,
-		EndTime:     campaign01.EndTime,
-	}}, campaigns)
+	assert.Equal(t, []model.Campaign{campaign01}, campaigns)
 
 	var getCampaign model.Campaign
 	// Lock Campaign
@@ -137,3 +131,514 @@ func TestCampaign(t *testing.T) {
 	assert.Equal(t, nil, err)
 	assert.Equal(t, campaign01, getCampaign)
 }
+
+func TestCampaign_Empty_Keys(t *testing.T) {
+	repo := NewCampaign()
+	ctx := newContext()
+
+	campaigns, err := repo.GetCampaignsByVouchers(ctx, nil)
+	assert.Equal(t, nil, err)
+	assert.Nil(t, campaigns)
+
+	campaigns, err = repo.SelectCampaignsByHashRange(ctx, nil)
+	assert.Equal(t, nil, err)
+	assert.Nil(t, campaigns)
+}
+
+func TestCampaign_Get_And_Select_By_Vouchers(t *testing.T) {
+	tc := newCampaignTest()
+	tc.tc.Truncate("campaign")
+
+	repo := NewCampaign()
+
+	ctx := tc.provider.Readonly(newContext())
+
+	const hash01 = 3300
+	const voucherCode01 = "VOUCHER01"
+	const hash02 = 4400
+	const voucherCode02 = "VOUCHER02"
+
+	// Count 1
+	count, err := repo.CountCampaigns(ctx)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int64(0), count)
+
+	campaign01 := model.Campaign{
+		ID:     1,
+		Name:   "name 01",
+		Status: model.CampaignStatusActive,
+		Type:   model.CampaignTypeMerchant,
+
+		VoucherHash: hash01,
+		VoucherCode: voucherCode01,
+		StartTime:   newTime("2022-05-07T10:00:00+07:00"),
+		EndTime:     newTime("2022-05-14T10:00:00+07:00"),
+
+		BudgetMax:        newNullDecimal("120000.00"),
+		CampaignUsageMax: newNullInt64(200),
+		CustomerUsageMax: 5,
+
+		PeriodUsageType:        model.PeriodUsageTypeDaily,
+		PeriodCustomerUsageMax: newNullInt64(2),
+		PeriodTermType:         model.PeriodTermTypeCampaign,
+
+		AllMerchants: true,
+	}
+	campaign02 := model.Campaign{
+		ID:     2,
+		Name:   "name 02",
+		Status: model.CampaignStatusActive,
+		Type:   model.CampaignTypeBank,
+
+		VoucherHash: hash02,
+		VoucherCode: voucherCode02,
+		StartTime:   newTime("2022-05-08T10:00:00+07:00"),
+		EndTime:     newTime("2022-05-15T10:00:00+07:00"),
+
+		CustomerUsageMax: 3,
+		PeriodTermType:   model.PeriodTermTypeMerchant,
+	}
+
+	err = tc.provider.Transact(newContext(), func(ctx context.Context) error {
+		if err := repo.UpsertCampaign(ctx, campaign01); err != nil {
+			return err
+		}
+		return repo.UpsertCampaign(ctx, campaign02)
+	})
+	assert.Equal(t, nil, err)
+
+	// Count 2
+	count, err = repo.CountCampaigns(ctx)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int64(2), count)
+
+	// Get By Vouchers
+	campaigns, err := repo.GetCampaignsByVouchers(ctx, []CampaignVoucherKey{
+		{VoucherHash: hash01, VoucherCode: voucherCode01},
+		{VoucherHash: hash02, VoucherCode: voucherCode02},
+		{VoucherHash: hash02, VoucherCode: "VOUCHER03"},
+	})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []model.Campaign{campaign01, campaign02}, campaigns)
+
+	// Select Hash Range
+	campaigns, err = repo.SelectCampaignsByHashRange(ctx, []HashRange{
+		{
+			Begin: hash01,
+			End:   newNullUint32(hash01 + 1),
+		},
+	})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []model.Campaign{campaign01}, campaigns)
+
+	// Select Hash Range 2
+	campaigns, err = repo.SelectCampaignsByHashRange(ctx, []HashRange{
+		{
+			Begin: hash01,
+			End:   newNullUint32(hash01 + 1),
+		},
+		{
+			Begin: hash02,
+		},
+	})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []model.Campaign{campaign01, campaign02}, campaigns)
+}
+
+func TestCampaign_Benefits(t *testing.T) {
+	tc := newCampaignTest()
+	tc.tc.Truncate("campaign_benefit")
+
+	repo := NewCampaign()
+
+	ctx := tc.provider.Readonly(newContext())
+
+	// Get Empty
+	benefits, err := repo.GetCampaignBenefits(ctx, nil)
+	assert.Equal(t, nil, err)
+	assert.Nil(t, benefits)
+
+	benefit01 := model.CampaignBenefit{
+		ID:         1,
+		CampaignID: 11,
+		StartTime:  newTime("2022-05-07T10:00:00+07:00"),
+		EndTime:    newTime("2022-05-14T10:00:00+07:00"),
+
+		TxnMinAmount:      newDecimal("50000.00"),
+		DiscountPercent:   newDecimal("10.00"),
+		MaxDiscountAmount: newDecimal("20000.00"),
+	}
+	benefit02 := model.CampaignBenefit{
+		ID:         2,
+		CampaignID: 11,
+		StartTime:  newTime("2022-05-07T10:00:00+07:00"),
+		EndTime:    newTime("2022-05-14T10:00:00+07:00"),
+
+		TxnMinAmount:      newDecimal("100000.00"),
+		DiscountPercent:   newDecimal("15.00"),
+		MaxDiscountAmount: newDecimal("30000.00"),
+	}
+	benefit03 := model.CampaignBenefit{
+		ID:         3,
+		CampaignID: 12,
+		StartTime:  newTime("2022-05-08T10:00:00+07:00"),
+		EndTime:    newTime("2022-05-15T10:00:00+07:00"),
+
+		TxnMinAmount:      newDecimal("0.00"),
+		DiscountPercent:   newDecimal("5.00"),
+		MaxDiscountAmount: newDecimal("10000.00"),
+	}
+
+	err = tc.provider.Transact(newContext(), func(ctx context.Context) error {
+		return repo.UpsertCampaignBenefits(ctx, []model.CampaignBenefit{benefit01, benefit02, benefit03})
+	})
+	assert.Equal(t, nil, err)
+
+	// Get 1
+	benefits, err = repo.GetCampaignBenefits(ctx, []int64{11})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []model.CampaignBenefit{benefit01, benefit02}, benefits)
+
+	// Get 2
+	benefits, err = repo.GetCampaignBenefits(ctx, []int64{11, 12, 13})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []model.CampaignBenefit{benefit01, benefit02, benefit03}, benefits)
+}
+
+func TestCampaign_Merchants_And_Terminals(t *testing.T) {
+	tc := newCampaignTest()
+	tc.tc.Truncate("campaign_merchant")
+	tc.tc.Truncate("campaign_terminal")
+
+	repo := NewCampaign()
+
+	ctx := tc.provider.Readonly(newContext())
+
+	// Count Empty
+	count, err := repo.CountCampaignMerchants(ctx)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int64(0), count)
+
+	merchant01 := model.CampaignMerchant{
+		CampaignID:   11,
+		Hash:         3300,
+		MerchantCode: "MERCHANT01",
+		Status:       model.CampaignMerchantStatusActive,
+		StartTime:    newNullTime("2022-05-07T10:00:00+07:00"),
+		EndTime:      newNullTime("2022-05-14T10:00:00+07:00"),
+		AllTerminals: false,
+	}
+	merchant02 := model.CampaignMerchant{
+		CampaignID:   12,
+		Hash:         4400,
+		MerchantCode: "MERCHANT02",
+		Status:       model.CampaignMerchantStatusInactive,
+		AllTerminals: true,
+	}
+
+	terminal01 := model.CampaignTerminal{
+		CampaignID:   11,
+		Hash:         3300,
+		MerchantCode: "MERCHANT01",
+		TerminalCode: "TERMINAL01",
+		Status:       model.CampaignTerminalStatusActive,
+		StartTime:    newNullTime("2022-05-07T10:00:00+07:00"),
+	}
+	terminal02 := model.CampaignTerminal{
+		CampaignID:   11,
+		Hash:         3300,
+		MerchantCode: "MERCHANT01",
+		TerminalCode: "TERMINAL02",
+		Status:       model.CampaignTerminalStatusInactive,
+		EndTime:      newNullTime("2022-05-14T10:00:00+07:00"),
+	}
+
+	err = tc.provider.Transact(newContext(), func(ctx context.Context) error {
+		err := repo.UpsertCampaignMerchants(ctx, []model.CampaignMerchant{merchant01, merchant02})
+		if err != nil {
+			return err
+		}
+		return repo.UpsertCampaignTerminals(ctx, []model.CampaignTerminal{terminal01, terminal02})
+	})
+	assert.Equal(t, nil, err)
+
+	// Count
+	count, err = repo.CountCampaignMerchants(ctx)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int64(2), count)
+
+	count, err = repo.CountCampaignTerminals(ctx)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int64(2), count)
+
+	// Get Merchants
+	merchants, err := repo.GetCampaignMerchants(ctx, []CampaignMerchantKey{
+		{CampaignID: 11, Hash: 3300, MerchantCode: "MERCHANT01"},
+		{CampaignID: 12, Hash: 3300, MerchantCode: "MERCHANT01"},
+		{CampaignID: 12, Hash: 4400, MerchantCode: "MERCHANT02"},
+	})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []model.CampaignMerchant{merchant01, merchant02}, merchants)
+
+	// Select Merchants
+	merchants, err = repo.SelectCampaignMerchants(ctx, []HashRange{
+		{
+			Begin: 4000,
+		},
+	})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []model.CampaignMerchant{merchant02}, merchants)
+
+	// Get Terminals
+	terminals, err := repo.GetCampaignTerminals(ctx, []CampaignTerminalKey{
+		{CampaignID: 11, Hash: 3300, MerchantCode: "MERCHANT01", TerminalCode: "TERMINAL02"},
+		{CampaignID: 11, Hash: 3300, MerchantCode: "MERCHANT01", TerminalCode: "TERMINAL03"},
+	})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []model.CampaignTerminal{terminal02}, terminals)
+
+	// Select Terminals
+	terminals, err = repo.SelectCampaignTerminals(ctx, []HashRange{
+		{
+			Begin: 3300,
+			End:   newNullUint32(3301),
+		},
+	})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []model.CampaignTerminal{terminal01, terminal02}, terminals)
+}
+
+func TestCampaign_Banks(t *testing.T) {
+	tc := newCampaignTest()
+	tc.tc.Truncate("campaign_bank")
+
+	repo := NewCampaign()
+
+	ctx := tc.provider.Readonly(newContext())
+
+	bank01 := model.CampaignBank{
+		CampaignID: 11,
+		Hash:       3300,
+		BankCode:   "BANK01",
+		Status:     model.CampaignBankStatusActive,
+		StartTime:  newNullTime("2022-05-07T10:00:00+07:00"),
+		EndTime:    newNullTime("2022-05-14T10:00:00+07:00"),
+	}
+	bank02 := model.CampaignBank{
+		CampaignID: 12,
+		Hash:       4400,
+		BankCode:   "BANK02",
+		Status:     model.CampaignBankStatusInactive,
+	}
+
+	err := tc.provider.Transact(newContext(), func(ctx context.Context) error {
+		return repo.UpsertCampaignBanks(ctx, []model.CampaignBank{bank01, bank02})
+	})
+	assert.Equal(t, nil, err)
+
+	// Count
+	count, err := repo.CountCampaignBanks(ctx)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int64(2), count)
+
+	// Get
+	banks, err := repo.GetCampaignBanks(ctx, []CampaignBankKey{
+		{CampaignID: 11, Hash: 3300, BankCode: "BANK01"},
+		{CampaignID: 11, Hash: 3300, BankCode: "BANK02"},
+	})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []model.CampaignBank{bank01}, banks)
+
+	// Select
+	banks, err = repo.SelectCampaignBanks(ctx, []HashRange{
+		{
+			Begin: 3300,
+			End:   newNullUint32(3301),
+		},
+		{
+			Begin: 4000,
+		},
+	})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []model.CampaignBank{bank01, bank02}, banks)
+}
+
+func TestCampaign_Customers(t *testing.T) {
+	tc := newCampaignTest()
+	tc.tc.Truncate("campaign_customer")
+
+	repo := NewCampaign()
+
+	ctx := tc.provider.Readonly(newContext())
+
+	// Empty Keys
+	customers, err := repo.GetCampaignCustomers(ctx, nil)
+	assert.Equal(t, nil, err)
+	assert.Nil(t, customers)
+
+	customer01 := model.CampaignCustomer{
+		CampaignID: 11,
+		Hash:       3300,
+		Phone:      "0987000111",
+		Status:     model.CampaignCustomerStatusActive,
+		StartTime:  newNullTime("2022-05-07T10:00:00+07:00"),
+		EndTime:    newNullTime("2022-05-14T10:00:00+07:00"),
+	}
+	customer02 := model.CampaignCustomer{
+		CampaignID: 12,
+		Hash:       4400,
+		Phone:      "0987000222",
+		Status:     model.CampaignCustomerStatusInactive,
+	}
+
+	err = tc.provider.Transact(newContext(), func(ctx context.Context) error {
+		return repo.UpsertCampaignCustomers(ctx, []model.CampaignCustomer{customer01, customer02})
+	})
+	assert.Equal(t, nil, err)
+
+	// Count
+	count, err := repo.CountCampaignCustomers(ctx)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int64(2), count)
+
+	// Get
+	customers, err = repo.GetCampaignCustomers(ctx, []CampaignCustomerKey{
+		{CampaignID: 11, Hash: 3300, Phone: "0987000111"},
+		{CampaignID: 12, Hash: 4400, Phone: "0987000222"},
+		{CampaignID: 12, Hash: 4400, Phone: "0987000333"},
+	})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []model.CampaignCustomer{customer01, customer02}, customers)
+
+	// Select
+	customers, err = repo.SelectCampaignCustomers(ctx, []HashRange{
+		{
+			Begin: 3300,
+			End:   newNullUint32(4400),
+		},
+	})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []model.CampaignCustomer{customer01}, customers)
+}
+
+func TestCampaign_Usages(t *testing.T) {
+	tc := newCampaignTest()
+	tc.tc.Truncate("campaign_usage")
+
+	repo := NewCampaign()
+
+	ctx := tc.provider.Readonly(newContext())
+
+	// Get Empty
+	usages, err := repo.GetCampaignUsages(ctx, nil)
+	assert.Equal(t, nil, err)
+	assert.Nil(t, usages)
+
+	usage01 := model.CampaignUsage{
+		CampaignID:   11,
+		BudgetUsed:   newDecimal("150000.50"),
+		CampaignUsed: 12,
+	}
+	usage02 := model.CampaignUsage{
+		CampaignID:   12,
+		BudgetUsed:   newDecimal("0.00"),
+		CampaignUsed: 0,
+	}
+
+	err = tc.provider.Transact(newContext(), func(ctx context.Context) error {
+		return repo.UpsertCampaignUsages(ctx, []model.CampaignUsage{usage01, usage02})
+	})
+	assert.Equal(t, nil, err)
+
+	// Get 1
+	usages, err = repo.GetCampaignUsages(ctx, []int64{11})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []model.CampaignUsage{usage01}, usages)
+
+	// Get 2
+	usages, err = repo.GetCampaignUsages(ctx, []int64{11, 12, 13})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []model.CampaignUsage{usage01, usage02}, usages)
+}
+
+func TestCampaign_Customer_Usages(t *testing.T) {
+	tc := newCampaignTest()
+	tc.tc.Truncate("campaign_customer_usage")
+
+	repo := NewCampaign()
+
+	ctx := tc.provider.Readonly(newContext())
+
+	// Empty Keys
+	usages, err := repo.GetCampaignCustomerUsages(ctx, nil)
+	assert.Equal(t, nil, err)
+	assert.Nil(t, usages)
+
+	usage01 := model.CampaignCustomerUsage{
+		CampaignID: 11,
+		Hash:       3300,
+		Phone:      "0987000111",
+		UsageNum:   3,
+	}
+	usage02 := model.CampaignCustomerUsage{
+		CampaignID: 12,
+		Hash:       4400,
+		Phone:      "0987000222",
+		UsageNum:   1,
+	}
+
+	err = tc.provider.Transact(newContext(), func(ctx context.Context) error {
+		return repo.UpsertCampaignCustomerUsages(ctx, []model.CampaignCustomerUsage{usage01, usage02})
+	})
+	assert.Equal(t, nil, err)
+
+	usages, err = repo.GetCampaignCustomerUsages(ctx, []CampaignCustomerKey{
+		{CampaignID: 11, Hash: 3300, Phone: "0987000111"},
+		{CampaignID: 12, Hash: 4400, Phone: "0987000111"},
+	})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []model.CampaignCustomerUsage{usage01}, usages)
+}
+
+func TestCampaign_Period_Usages(t *testing.T) {
+	tc := newCampaignTest()
+	tc.tc.Truncate("campaign_period_usage")
+
+	repo := NewCampaign()
+
+	ctx := tc.provider.Readonly(newContext())
+
+	// Empty Keys
+	usages, err := repo.GetCampaignPeriodUsages(ctx, nil)
+	assert.Equal(t, nil, err)
+	assert.Nil(t, usages)
+
+	usage01 := model.CampaignPeriodUsage{
+		CampaignID: 11,
+		Hash:       3300,
+		Phone:      "0987000111",
+		TermCode:   "20220515",
+		UsageNum:   2,
+		ExpiredOn:  newTime("2022-05-16T00:00:00+07:00"),
+	}
+	usage02 := model.CampaignPeriodUsage{
+		CampaignID: 11,
+		Hash:       3300,
+		Phone:      "0987000111",
+		TermCode:   "20220516",
+		UsageNum:   1,
+		ExpiredOn:  newTime("2022-05-17T00:00:00+07:00"),
+	}
+
+	err = tc.provider.Transact(newContext(), func(ctx context.Context) error {
+		return repo.UpsertCampaignPeriodUsages(ctx, []model.CampaignPeriodUsage{usage01, usage02})
+	})
+	assert.Equal(t, nil, err)
+
+	usages, err = repo.GetCampaignPeriodUsages(ctx, []CampaignPeriodUsageKey{
+		{CampaignID: 11, Hash: 3300, Phone: "0987000111", TermCode: "20220516"},
+		{CampaignID: 11, Hash: 3300, Phone: "0987000111", TermCode: "20220517"},
+	})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []model.CampaignPeriodUsage{usage02}, usages)
+}
@@ -0,0 +1,418 @@
+// Code generated by otelwrap; DO NOT EDIT.
+// github.com/QuangTung97/otelwrap
+
+package repository
+
+import (
+	"context"
+	"github.com/QuangTung97/promo-readonly/model"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"time"
+)
+
+// CampaignWrapper wraps OpenTelemetry's span
+type CampaignWrapper struct {
+	Campaign
+	tracer trace.Tracer
+	prefix string
+}
+
+// NewCampaignWrapper creates a wrapper
+func NewCampaignWrapper(wrapped Campaign, tracer trace.Tracer, prefix string) *CampaignWrapper {
+	return &CampaignWrapper{
+		Campaign: wrapped,
+		tracer:   tracer,
+		prefix:   prefix,
+	}
+}
+
+// CountCampaigns ...
+func (w *CampaignWrapper) CountCampaigns(ctx context.Context) (a int64, err error) {
+	ctx, span := w.tracer.Start(ctx, w.prefix+"CountCampaigns")
+	defer span.End()
+
+	a, err = w.Campaign.CountCampaigns(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return a, err
+}
+
+// FindCampaignsByVoucher ...
+func (w *CampaignWrapper) FindCampaignsByVoucher(ctx context.Context, voucherHash uint32, voucherCode string, now time.Time) (a []model.Campaign, err error) {
+	ctx, span := w.tracer.Start(ctx, w.prefix+"FindCampaignsByVoucher")
+	defer span.End()
+
+	a, err = w.Campaign.FindCampaignsByVoucher(ctx, voucherHash, voucherCode, now)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return a, err
+}
+
+// GetCampaignsByVouchers ...
+func (w *CampaignWrapper) GetCampaignsByVouchers(ctx context.Context, keys []CampaignVoucherKey) (a []model.Campaign, err error) {
+	ctx, span := w.tracer.Start(ctx, w.prefix+"GetCampaignsByVouchers")
+	defer span.End()
+
+	a, err = w.Campaign.GetCampaignsByVouchers(ctx, keys)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return a, err
+}
+
+// SelectCampaignsByHashRange ...
+func (w *CampaignWrapper) SelectCampaignsByHashRange(ctx context.Context, ranges []HashRange) (a []model.Campaign, err error) {
+	ctx, span := w.tracer.Start(ctx, w.prefix+"SelectCampaignsByHashRange")
+	defer span.End()
+
+	a, err = w.Campaign.SelectCampaignsByHashRange(ctx, ranges)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return a, err
+}
+
+// GetCampaignWithLock ...
+func (w *CampaignWrapper) GetCampaignWithLock(ctx context.Context, campaignID int64) (a model.Campaign, err error) {
+	ctx, span := w.tracer.Start(ctx, w.prefix+"GetCampaignWithLock")
+	defer span.End()
+
+	a, err = w.Campaign.GetCampaignWithLock(ctx, campaignID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return a, err
+}
+
+// UpsertCampaign ...
+func (w *CampaignWrapper) UpsertCampaign(ctx context.Context, campaign model.Campaign) (err error) {
+	ctx, span := w.tracer.Start(ctx, w.prefix+"UpsertCampaign")
+	defer span.End()
+
+	err = w.Campaign.UpsertCampaign(ctx, campaign)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+// GetCampaignBenefits ...
+func (w *CampaignWrapper) GetCampaignBenefits(ctx context.Context, campaignIDs []int64) (a []model.CampaignBenefit, err error) {
+	ctx, span := w.tracer.Start(ctx, w.prefix+"GetCampaignBenefits")
+	defer span.End()
+
+	a, err = w.Campaign.GetCampaignBenefits(ctx, campaignIDs)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return a, err
+}
+
+// UpsertCampaignBenefits ...
+func (w *CampaignWrapper) UpsertCampaignBenefits(ctx context.Context, benefits []model.CampaignBenefit) (err error) {
+	ctx, span := w.tracer.Start(ctx, w.prefix+"UpsertCampaignBenefits")
+	defer span.End()
+
+	err = w.Campaign.UpsertCampaignBenefits(ctx, benefits)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+// CountCampaignMerchants ...
+func (w *CampaignWrapper) CountCampaignMerchants(ctx context.Context) (a int64, err error) {
+	ctx, span := w.tracer.Start(ctx, w.prefix+"CountCampaignMerchants")
+	defer span.End()
+
+	a, err = w.Campaign.CountCampaignMerchants(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return a, err
+}
+
+// GetCampaignMerchants ...
+func (w *CampaignWrapper) GetCampaignMerchants(ctx context.Context, keys []CampaignMerchantKey) (a []model.CampaignMerchant, err error) {
+	ctx, span := w.tracer.Start(ctx, w.prefix+"GetCampaignMerchants")
+	defer span.End()
+
+	a, err = w.Campaign.GetCampaignMerchants(ctx, keys)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return a, err
+}
+
+// SelectCampaignMerchants ...
+func (w *CampaignWrapper) SelectCampaignMerchants(ctx context.Context, ranges []HashRange) (a []model.CampaignMerchant, err error) {
+	ctx, span := w.tracer.Start(ctx, w.prefix+"SelectCampaignMerchants")
+	defer span.End()
+
+	a, err = w.Campaign.SelectCampaignMerchants(ctx, ranges)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return a, err
+}
+
+// UpsertCampaignMerchants ...
+func (w *CampaignWrapper) UpsertCampaignMerchants(ctx context.Context, merchants []model.CampaignMerchant) (err error) {
+	ctx, span := w.tracer.Start(ctx, w.prefix+"UpsertCampaignMerchants")
+	defer span.End()
+
+	err = w.Campaign.UpsertCampaignMerchants(ctx, merchants)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+// CountCampaignTerminals ...
+func (w *CampaignWrapper) CountCampaignTerminals(ctx context.Context) (a int64, err error) {
+	ctx, span := w.tracer.Start(ctx, w.prefix+"CountCampaignTerminals")
+	defer span.End()
+
+	a, err = w.Campaign.CountCampaignTerminals(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return a, err
+}
+
+// GetCampaignTerminals ...
+func (w *CampaignWrapper) GetCampaignTerminals(ctx context.Context, keys []CampaignTerminalKey) (a []model.CampaignTerminal, err error) {
+	ctx, span := w.tracer.Start(ctx, w.prefix+"GetCampaignTerminals")
+	defer span.End()
+
+	a, err = w.Campaign.GetCampaignTerminals(ctx, keys)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return a, err
+}
+
+// SelectCampaignTerminals ...
+func (w *CampaignWrapper) SelectCampaignTerminals(ctx context.Context, ranges []HashRange) (a []model.CampaignTerminal, err error) {
+	ctx, span := w.tracer.Start(ctx, w.prefix+"SelectCampaignTerminals")
+	defer span.End()
+
+	a, err = w.Campaign.SelectCampaignTerminals(ctx, ranges)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return a, err
+}
+
+// UpsertCampaignTerminals ...
+func (w *CampaignWrapper) UpsertCampaignTerminals(ctx context.Context, terminals []model.CampaignTerminal) (err error) {
+	ctx, span := w.tracer.Start(ctx, w.prefix+"UpsertCampaignTerminals")
+	defer span.End()
+
+	err = w.Campaign.UpsertCampaignTerminals(ctx, terminals)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+// CountCampaignBanks ...
+func (w *CampaignWrapper) CountCampaignBanks(ctx context.Context) (a int64, err error) {
+	ctx, span := w.tracer.Start(ctx, w.prefix+"CountCampaignBanks")
+	defer span.End()
+
+	a, err = w.Campaign.CountCampaignBanks(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return a, err
+}
+
+// GetCampaignBanks ...
+func (w *CampaignWrapper) GetCampaignBanks(ctx context.Context, keys []CampaignBankKey) (a []model.CampaignBank, err error) {
+	ctx, span := w.tracer.Start(ctx, w.prefix+"GetCampaignBanks")
+	defer span.End()
+
+	a, err = w.Campaign.GetCampaignBanks(ctx, keys)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return a, err
+}
+
+// SelectCampaignBanks ...
+func (w *CampaignWrapper) SelectCampaignBanks(ctx context.Context, ranges []HashRange) (a []model.CampaignBank, err error) {
+	ctx, span := w.tracer.Start(ctx, w.prefix+"SelectCampaignBanks")
+	defer span.End()
+
+	a, err = w.Campaign.SelectCampaignBanks(ctx, ranges)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return a, err
+}
+
+// UpsertCampaignBanks ...
+func (w *CampaignWrapper) UpsertCampaignBanks(ctx context.Context, banks []model.CampaignBank) (err error) {
+	ctx, span := w.tracer.Start(ctx, w.prefix+"UpsertCampaignBanks")
+	defer span.End()
+
+	err = w.Campaign.UpsertCampaignBanks(ctx, banks)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+// CountCampaignCustomers ...
+func (w *CampaignWrapper) CountCampaignCustomers(ctx context.Context) (a int64, err error) {
+	ctx, span := w.tracer.Start(ctx, w.prefix+"CountCampaignCustomers")
+	defer span.End()
+
+	a, err = w.Campaign.CountCampaignCustomers(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return a, err
+}
+
+// GetCampaignCustomers ...
+func (w *CampaignWrapper) GetCampaignCustomers(ctx context.Context, keys []CampaignCustomerKey) (a []model.CampaignCustomer, err error) {
+	ctx, span := w.tracer.Start(ctx, w.prefix+"GetCampaignCustomers")
+	defer span.End()
+
+	a, err = w.Campaign.GetCampaignCustomers(ctx, keys)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return a, err
+}
+
+// SelectCampaignCustomers ...
+func (w *CampaignWrapper) SelectCampaignCustomers(ctx context.Context, ranges []HashRange) (a []model.CampaignCustomer, err error) {
+	ctx, span := w.tracer.Start(ctx, w.prefix+"SelectCampaignCustomers")
+	defer span.End()
+
+	a, err = w.Campaign.SelectCampaignCustomers(ctx, ranges)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return a, err
+}
+
+// UpsertCampaignCustomers ...
+func (w *CampaignWrapper) UpsertCampaignCustomers(ctx context.Context, customers []model.CampaignCustomer) (err error) {
+	ctx, span := w.tracer.Start(ctx, w.prefix+"UpsertCampaignCustomers")
+	defer span.End()
+
+	err = w.Campaign.UpsertCampaignCustomers(ctx, customers)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+// GetCampaignUsages ...
+func (w *CampaignWrapper) GetCampaignUsages(ctx context.Context, campaignIDs []int64) (a []model.CampaignUsage, err error) {
+	ctx, span := w.tracer.Start(ctx, w.prefix+"GetCampaignUsages")
+	defer span.End()
+
+	a, err = w.Campaign.GetCampaignUsages(ctx, campaignIDs)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return a, err
+}
+
+// UpsertCampaignUsages ...
+func (w *CampaignWrapper) UpsertCampaignUsages(ctx context.Context, usages []model.CampaignUsage) (err error) {
+	ctx, span := w.tracer.Start(ctx, w.prefix+"UpsertCampaignUsages")
+	defer span.End()
+
+	err = w.Campaign.UpsertCampaignUsages(ctx, usages)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+// GetCampaignCustomerUsages ...
+func (w *CampaignWrapper) GetCampaignCustomerUsages(ctx context.Context, keys []CampaignCustomerKey) (a []model.CampaignCustomerUsage, err error) {
+	ctx, span := w.tracer.Start(ctx, w.prefix+"GetCampaignCustomerUsages")
+	defer span.End()
+
+	a, err = w.Campaign.GetCampaignCustomerUsages(ctx, keys)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return a, err
+}
+
+// UpsertCampaignCustomerUsages ...
+func (w *CampaignWrapper) UpsertCampaignCustomerUsages(ctx context.Context, usages []model.CampaignCustomerUsage) (err error) {
+	ctx, span := w.tracer.Start(ctx, w.prefix+"UpsertCampaignCustomerUsages")
+	defer span.End()
+
+	err = w.Campaign.UpsertCampaignCustomerUsages(ctx, usages)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+// GetCampaignPeriodUsages ...
+func (w *CampaignWrapper) GetCampaignPeriodUsages(ctx context.Context, keys []CampaignPeriodUsageKey) (a []model.CampaignPeriodUsage, err error) {
+	ctx, span := w.tracer.Start(ctx, w.prefix+"GetCampaignPeriodUsages")
+	defer span.End()
+
+	a, err = w.Campaign.GetCampaignPeriodUsages(ctx, keys)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return a, err
+}
+
+// UpsertCampaignPeriodUsages ...
+func (w *CampaignWrapper) UpsertCampaignPeriodUsages(ctx context.Context, usages []model.CampaignPeriodUsage) (err error) {
+	ctx, span := w.tracer.Start(ctx, w.prefix+"UpsertCampaignPeriodUsages")
+	defer span.End()
+
+	err = w.Campaign.UpsertCampaignPeriodUsages(ctx, usages)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
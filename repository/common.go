@@ -4,10 +4,41 @@ import (
 	"context"
 	"github.com/QuangTung97/promo-readonly/pkg/dhash"
 	"sort"
+	"sync"
 )
 
+const (
+	defaultSelectChunkSize     = 100
+	defaultSelectMaxConcurrent = 4
+)
+
+type hashDatabaseOptions struct {
+	selectChunkSize     int // max number of ranges in a single select query
+	selectMaxConcurrent int // max number of chunks queried at the same time
+}
+
+// HashDatabaseOption ...
+type HashDatabaseOption func(opts *hashDatabaseOptions)
+
+// WithSelectChunkSize limits the number of ranges in each select query, zero means default
+func WithSelectChunkSize(size int) HashDatabaseOption {
+	return func(opts *hashDatabaseOptions) {
+		opts.selectChunkSize = size
+	}
+}
+
+// WithSelectMaxConcurrent limits the number of chunks queried in parallel, zero means default.
+// Chunks are always queried sequentially when the context contains a transaction
+func WithSelectMaxConcurrent(n int) HashDatabaseOption {
+	return func(opts *hashDatabaseOptions) {
+		opts.selectMaxConcurrent = n
+	}
+}
+
 // HashDatabase ...
 type HashDatabase struct {
+	options hashDatabaseOptions
+
 	doFetchSizeLog  func(ctx context.Context) (uint64, error)
 	doSelectEntries func(ctx context.Context, inputs []HashRange) ([]dhash.Entry, error)
 
@@ -22,17 +53,120 @@ type HashDatabase struct {
 	err error
 }
 
-// NewHashDatabase ...
+// NewHashDatabase merges overlapping and adjacent ranges before calling selectEntries,
+// selectEntries can be called concurrently when the ranges are split into multiple chunks
+// and the context does not contain a transaction
 func NewHashDatabase(
 	fetchSizeLog func(ctx context.Context) (uint64, error),
 	selectEntries func(ctx context.Context, inputs []HashRange) ([]dhash.Entry, error),
+	options ...HashDatabaseOption,
 ) *HashDatabase {
+	opts := hashDatabaseOptions{}
+	for _, fn := range options {
+		fn(&opts)
+	}
+	if opts.selectChunkSize <= 0 {
+		opts.selectChunkSize = defaultSelectChunkSize
+	}
+	if opts.selectMaxConcurrent <= 0 {
+		opts.selectMaxConcurrent = defaultSelectMaxConcurrent
+	}
+
 	return &HashDatabase{
+		options:         opts,
 		doFetchSizeLog:  fetchSizeLog,
 		doSelectEntries: selectEntries,
 	}
 }
 
+// mergeHashRanges sorts the ranges and merges the overlapping or adjacent ones
+func mergeHashRanges(ranges []HashRange) []HashRange {
+	if len(ranges) == 0 {
+		return nil
+	}
+
+	sorted := make([]HashRange, len(ranges))
+	copy(sorted, ranges)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Begin < sorted[j].Begin
+	})
+
+	result := make([]HashRange, 0, len(sorted))
+	current := sorted[0]
+	for _, r := range sorted[1:] {
+		if current.End.Valid && r.Begin > current.End.Num {
+			result = append(result, current)
+			current = r
+			continue
+		}
+
+		if !r.End.Valid {
+			current.End = dhash.NullUint32{}
+		} else if current.End.Valid && r.End.Num > current.End.Num {
+			current.End = r.End
+		}
+	}
+	return append(result, current)
+}
+
+func splitHashRanges(ranges []HashRange, chunkSize int) [][]HashRange {
+	var chunks [][]HashRange
+	for len(ranges) > chunkSize {
+		chunks = append(chunks, ranges[:chunkSize])
+		ranges = ranges[chunkSize:]
+	}
+	return append(chunks, ranges)
+}
+
+func (h *HashDatabase) selectEntriesInChunks(ctx context.Context, inputs []HashRange) ([]dhash.Entry, error) {
+	chunks := splitHashRanges(mergeHashRanges(inputs), h.options.selectChunkSize)
+	if len(chunks) == 1 || h.options.selectMaxConcurrent == 1 || readonlyIsTx(ctx) {
+		return h.selectEntriesSequentially(ctx, chunks)
+	}
+
+	chunkEntries := make([][]dhash.Entry, len(chunks))
+	chunkErrors := make([]error, len(chunks))
+
+	semaphore := make(chan struct{}, h.options.selectMaxConcurrent)
+
+	var wg sync.WaitGroup
+	wg.Add(len(chunks))
+	for i := range chunks {
+		i := i
+		semaphore <- struct{}{}
+		go func() {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+			chunkEntries[i], chunkErrors[i] = h.doSelectEntries(ctx, chunks[i])
+		}()
+	}
+	wg.Wait()
+
+	var entries []dhash.Entry
+	for i := range chunks {
+		if chunkErrors[i] != nil {
+			return nil, chunkErrors[i]
+		}
+		entries = append(entries, chunkEntries[i]...)
+	}
+	return entries, nil
+}
+
+// selectEntriesSequentially for transactions, which can NOT be shared between goroutines
+func (h *HashDatabase) selectEntriesSequentially(ctx context.Context, chunks [][]HashRange) ([]dhash.Entry, error) {
+	var entries []dhash.Entry
+	for _, chunk := range chunks {
+		chunkEntries, err := h.doSelectEntries(ctx, chunk)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, chunkEntries...)
+	}
+	return entries, nil
+}
+
 func (h *HashDatabase) fetchData(ctx context.Context) error {
 	if h.err != nil {
 		return h.err
@@ -61,7 +195,7 @@ func (h *HashDatabase) fetchDataWithError(ctx context.Context) error {
 		inputs := h.selectInputs
 		h.selectInputs = nil
 
-		entries, err := h.doSelectEntries(ctx, inputs)
+		entries, err := h.selectEntriesInChunks(ctx, inputs)
 		if err != nil {
 			return err
 		}
@@ -115,3 +249,82 @@ func (h *HashDatabase) SelectEntries(
 		return result, nil
 	}
 }
+
+// StoreDatabase ...
+type StoreDatabase struct {
+	doGetValues func(ctx context.Context, keys []string) (map[string][]byte, error)
+
+	fetchNew bool
+
+	inputKeys   []string
+	inputKeySet map[string]struct{}
+	outputs     map[string][]byte
+
+	err error
+}
+
+// NewStoreDatabase returns dhash.ErrNotFound for keys not in the result of getValues
+func NewStoreDatabase(
+	getValues func(ctx context.Context, keys []string) (map[string][]byte, error),
+) *StoreDatabase {
+	return &StoreDatabase{
+		doGetValues: getValues,
+		inputKeySet: map[string]struct{}{},
+		outputs:     map[string][]byte{},
+	}
+}
+
+func (s *StoreDatabase) fetchData(ctx context.Context) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.err = s.fetchDataWithError(ctx)
+	return s.err
+}
+
+func (s *StoreDatabase) fetchDataWithError(ctx context.Context) error {
+	if !s.fetchNew {
+		return nil
+	}
+	s.fetchNew = false
+
+	if len(s.inputKeys) == 0 {
+		return nil
+	}
+
+	keys := s.inputKeys
+	s.inputKeys = nil
+	for _, key := range keys {
+		delete(s.inputKeySet, key)
+	}
+
+	values, err := s.doGetValues(ctx, keys)
+	if err != nil {
+		return err
+	}
+	for key, value := range values {
+		s.outputs[key] = value
+	}
+	return nil
+}
+
+// Get ...
+func (s *StoreDatabase) Get(ctx context.Context, key string) func() ([]byte, error) {
+	s.fetchNew = true
+
+	if _, existed := s.inputKeySet[key]; !existed {
+		s.inputKeySet[key] = struct{}{}
+		s.inputKeys = append(s.inputKeys, key)
+	}
+
+	return func() ([]byte, error) {
+		if err := s.fetchData(ctx); err != nil {
+			return nil, err
+		}
+		value, existed := s.outputs[key]
+		if !existed {
+			return nil, dhash.ErrNotFound
+		}
+		return value, nil
+	}
+}
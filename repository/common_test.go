@@ -0,0 +1,281 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/QuangTung97/promo-readonly/pkg/dhash"
+	"github.com/stretchr/testify/assert"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newHashRange(begin uint32, end uint32) HashRange {
+	return HashRange{
+		Begin: begin,
+		End:   dhash.NullUint32{Valid: true, Num: end},
+	}
+}
+
+func newHashRangeToEnd(begin uint32) HashRange {
+	return HashRange{Begin: begin}
+}
+
+func TestMergeHashRanges(t *testing.T) {
+	table := []struct {
+		name   string
+		ranges []HashRange
+		result []HashRange
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:   "single",
+			ranges: []HashRange{newHashRange(10, 20)},
+			result: []HashRange{newHashRange(10, 20)},
+		},
+		{
+			name:   "duplicated",
+			ranges: []HashRange{newHashRange(10, 20), newHashRange(10, 20)},
+			result: []HashRange{newHashRange(10, 20)},
+		},
+		{
+			name:   "adjacent-not-sorted",
+			ranges: []HashRange{newHashRange(20, 30), newHashRange(10, 20)},
+			result: []HashRange{newHashRange(10, 30)},
+		},
+		{
+			name:   "overlapped-and-contained",
+			ranges: []HashRange{newHashRange(10, 25), newHashRange(20, 30), newHashRange(22, 24)},
+			result: []HashRange{newHashRange(10, 30)},
+		},
+		{
+			name:   "separated",
+			ranges: []HashRange{newHashRange(40, 50), newHashRange(10, 20), newHashRange(21, 30)},
+			result: []HashRange{newHashRange(10, 20), newHashRange(21, 30), newHashRange(40, 50)},
+		},
+		{
+			name:   "to-the-end",
+			ranges: []HashRange{newHashRangeToEnd(30), newHashRange(10, 20), newHashRange(20, 30), newHashRange(40, 50)},
+			result: []HashRange{newHashRangeToEnd(10)},
+		},
+	}
+
+	for _, e := range table {
+		tc := e
+		t.Run(tc.name, func(t *testing.T) {
+			input := append([]HashRange(nil), tc.ranges...)
+			result := mergeHashRanges(tc.ranges)
+			assert.Equal(t, tc.result, result)
+			assert.Equal(t, input, tc.ranges)
+		})
+	}
+}
+
+func TestSplitHashRanges(t *testing.T) {
+	ranges := []HashRange{
+		newHashRange(10, 20),
+		newHashRange(30, 40),
+		newHashRange(50, 60),
+	}
+
+	assert.Equal(t, [][]HashRange{ranges}, splitHashRanges(ranges, 3))
+	assert.Equal(t, [][]HashRange{ranges[:2], ranges[2:]}, splitHashRanges(ranges, 2))
+	assert.Equal(t, [][]HashRange{ranges[:1], ranges[1:2], ranges[2:]}, splitHashRanges(ranges, 1))
+}
+
+type hashDatabaseTest struct {
+	mut    sync.Mutex
+	inputs [][]HashRange
+	err    error
+
+	running       int
+	maxConcurrent int
+
+	db *HashDatabase
+}
+
+func newHashDatabaseTest(entries []dhash.Entry, options ...HashDatabaseOption) *hashDatabaseTest {
+	h := &hashDatabaseTest{}
+	h.db = NewHashDatabase(func(ctx context.Context) (uint64, error) {
+		return 0, nil
+	}, func(ctx context.Context, inputs []HashRange) ([]dhash.Entry, error) {
+		h.mut.Lock()
+		h.inputs = append(h.inputs, inputs)
+		err := h.err
+		h.running++
+		if h.running > h.maxConcurrent {
+			h.maxConcurrent = h.running
+		}
+		h.mut.Unlock()
+
+		time.Sleep(time.Millisecond)
+
+		h.mut.Lock()
+		h.running--
+		h.mut.Unlock()
+
+		if err != nil {
+			return nil, err
+		}
+
+		var result []dhash.Entry
+		for _, r := range inputs {
+			for _, e := range entries {
+				if e.Hash >= r.Begin && (!r.End.Valid || e.Hash < r.End.Num) {
+					result = append(result, e)
+				}
+			}
+		}
+		return result, nil
+	}, options...)
+	return h
+}
+
+func (h *hashDatabaseTest) sortedInputs() [][]HashRange {
+	sort.Slice(h.inputs, func(i, j int) bool {
+		return h.inputs[i][0].Begin < h.inputs[j][0].Begin
+	})
+	return h.inputs
+}
+
+func newEntry(hash uint32) dhash.Entry {
+	return dhash.Entry{Hash: hash, Data: []byte("data")}
+}
+
+func TestHashDatabase_SelectEntries__Merge_Ranges_In_Single_Query(t *testing.T) {
+	h := newHashDatabaseTest([]dhash.Entry{newEntry(15), newEntry(25), newEntry(45)})
+
+	ctx := newContext()
+	fn1 := h.db.SelectEntries(ctx, 20, dhash.NullUint32{Valid: true, Num: 30})
+	fn2 := h.db.SelectEntries(ctx, 10, dhash.NullUint32{Valid: true, Num: 20})
+	fn3 := h.db.SelectEntries(ctx, 40, dhash.NullUint32{Valid: true, Num: 50})
+	fn4 := h.db.SelectEntries(ctx, 10, dhash.NullUint32{Valid: true, Num: 20})
+
+	entries, err := fn1()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []dhash.Entry{newEntry(25)}, entries)
+
+	entries, err = fn2()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []dhash.Entry{newEntry(15)}, entries)
+
+	entries, err = fn3()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []dhash.Entry{newEntry(45)}, entries)
+
+	entries, err = fn4()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []dhash.Entry{newEntry(15)}, entries)
+
+	assert.Equal(t, [][]HashRange{
+		{newHashRange(10, 30), newHashRange(40, 50)},
+	}, h.inputs)
+}
+
+func TestHashDatabase_SelectEntries__Split_Into_Chunks(t *testing.T) {
+	h := newHashDatabaseTest([]dhash.Entry{
+		newEntry(45), newEntry(15), newEntry(35), newEntry(25),
+	}, WithSelectChunkSize(2))
+
+	ctx := newContext()
+	fn1 := h.db.SelectEntries(ctx, 10, dhash.NullUint32{Valid: true, Num: 20})
+	fn2 := h.db.SelectEntries(ctx, 21, dhash.NullUint32{Valid: true, Num: 30})
+	fn3 := h.db.SelectEntries(ctx, 31, dhash.NullUint32{Valid: true, Num: 40})
+	fn4 := h.db.SelectEntries(ctx, 41, dhash.NullUint32{})
+
+	entries, err := fn1()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []dhash.Entry{newEntry(15)}, entries)
+
+	entries, err = fn2()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []dhash.Entry{newEntry(25)}, entries)
+
+	entries, err = fn3()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []dhash.Entry{newEntry(35)}, entries)
+
+	entries, err = fn4()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []dhash.Entry{newEntry(45)}, entries)
+
+	assert.Equal(t, [][]HashRange{
+		{newHashRange(10, 20), newHashRange(21, 30)},
+		{newHashRange(31, 40), newHashRangeToEnd(41)},
+	}, h.sortedInputs())
+}
+
+func TestHashDatabase_SelectEntries__Chunk_Error(t *testing.T) {
+	h := newHashDatabaseTest(nil, WithSelectChunkSize(1))
+	h.err = errors.New("select error")
+
+	ctx := newContext()
+	fn1 := h.db.SelectEntries(ctx, 10, dhash.NullUint32{Valid: true, Num: 20})
+	fn2 := h.db.SelectEntries(ctx, 30, dhash.NullUint32{Valid: true, Num: 40})
+
+	entries, err := fn1()
+	assert.Equal(t, errors.New("select error"), err)
+	assert.Nil(t, entries)
+
+	entries, err = fn2()
+	assert.Equal(t, errors.New("select error"), err)
+	assert.Nil(t, entries)
+
+	assert.Equal(t, 2, len(h.inputs))
+}
+
+func newSelectChunksTest(options ...HashDatabaseOption) *hashDatabaseTest {
+	h := newHashDatabaseTest(nil, append([]HashDatabaseOption{WithSelectChunkSize(1)}, options...)...)
+	return h
+}
+
+func (h *hashDatabaseTest) selectSeparatedRanges(ctx context.Context, n int) {
+	var fns []func() ([]dhash.Entry, error)
+	for i := 0; i < n; i++ {
+		begin := uint32(i * 10)
+		fns = append(fns, h.db.SelectEntries(ctx, begin, dhash.NullUint32{Valid: true, Num: begin + 5}))
+	}
+	for _, fn := range fns {
+		_, _ = fn()
+	}
+}
+
+func TestHashDatabase_SelectEntries__Limit_Max_Concurrent_Chunks(t *testing.T) {
+	h := newSelectChunksTest(WithSelectMaxConcurrent(2))
+
+	h.selectSeparatedRanges(newContext(), 8)
+
+	assert.Equal(t, 8, len(h.inputs))
+	assert.Equal(t, 2, h.maxConcurrent)
+}
+
+func TestHashDatabase_SelectEntries__Context_With_Transaction__Select_Sequentially(t *testing.T) {
+	h := newSelectChunksTest(WithSelectMaxConcurrent(4))
+
+	ctx := context.WithValue(newContext(), ctxTxKey, ctxTxValue{})
+	h.selectSeparatedRanges(ctx, 5)
+
+	assert.Equal(t, 1, h.maxConcurrent)
+	assert.Equal(t, [][]HashRange{
+		{newHashRange(0, 5)},
+		{newHashRange(10, 15)},
+		{newHashRange(20, 25)},
+		{newHashRange(30, 35)},
+		{newHashRange(40, 45)},
+	}, h.inputs)
+}
+
+func TestHashDatabase_SelectEntries__Context_With_Readonly_And_Transaction__Select_In_Parallel(t *testing.T) {
+	h := newSelectChunksTest(WithSelectMaxConcurrent(4))
+
+	ctx := context.WithValue(newContext(), ctxTxKey, ctxTxValue{})
+	ctx = context.WithValue(ctx, ctxReadonlyKey, ctxReadonlyValue{})
+	h.selectSeparatedRanges(ctx, 8)
+
+	assert.Equal(t, 8, len(h.inputs))
+	assert.Less(t, 1, h.maxConcurrent)
+	assert.GreaterOrEqual(t, 4, h.maxConcurrent)
+}
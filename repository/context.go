@@ -34,6 +34,15 @@ func GetReadonly(ctx context.Context) Readonly {
 	panic("Not found readonly repository")
 }
 
+// readonlyIsTx returns true when GetReadonly returns a transaction
+func readonlyIsTx(ctx context.Context) bool {
+	if _, ok := ctx.Value(ctxReadonlyKey).(ctxReadonlyValue); ok {
+		return false
+	}
+	_, ok := getTxFromContext(ctx)
+	return ok
+}
+
 type ctxTxKeyType struct {
 }
 
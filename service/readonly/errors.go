@@ -0,0 +1,148 @@
+package readonly
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Reason of rejecting a check input by business rules
+type Reason int
+
+const (
+	// ReasonCustomerBlacklisted ...
+	ReasonCustomerBlacklisted Reason = iota + 1
+
+	// ReasonMerchantBlacklisted ...
+	ReasonMerchantBlacklisted
+
+	// ReasonTerminalBlacklisted ...
+	ReasonTerminalBlacklisted
+
+	// ReasonVoucherNotFound ...
+	ReasonVoucherNotFound
+
+	// ReasonCampaignInactive ...
+	ReasonCampaignInactive
+
+	// ReasonCampaignNotStarted ...
+	ReasonCampaignNotStarted
+
+	// ReasonCampaignEnded ...
+	ReasonCampaignEnded
+
+	// ReasonNoApplicableBenefit ...
+	ReasonNoApplicableBenefit
+
+	// ReasonMerchantNotEligible ...
+	ReasonMerchantNotEligible
+
+	// ReasonTerminalNotEligible ...
+	ReasonTerminalNotEligible
+
+	// ReasonBankNotEligible ...
+	ReasonBankNotEligible
+
+	// ReasonCustomerNotEligible ...
+	ReasonCustomerNotEligible
+
+	// ReasonBudgetExhausted ...
+	ReasonBudgetExhausted
+
+	// ReasonCampaignUsageExhausted ...
+	ReasonCampaignUsageExhausted
+
+	// ReasonCustomerUsageExhausted ...
+	ReasonCustomerUsageExhausted
+
+	// ReasonCustomerPeriodUsageExhausted ...
+	ReasonCustomerPeriodUsageExhausted
+
+	// ReasonInvalidArgument ...
+	ReasonInvalidArgument
+)
+
+// BusinessError is a rejection by business rules, other errors are infrastructure failures
+type BusinessError struct {
+	Reason  Reason
+	Message string
+}
+
+func (e *BusinessError) Error() string {
+	return e.Message
+}
+
+func newBusinessError(reason Reason, message string) error {
+	return &BusinessError{
+		Reason:  reason,
+		Message: message,
+	}
+}
+
+// BusinessErrorReason returns the reason of a business error, ok = false for infrastructure failures
+func BusinessErrorReason(err error) (reason Reason, ok bool) {
+	var businessErr *BusinessError
+	if !errors.As(err, &businessErr) {
+		return 0, false
+	}
+	return businessErr.Reason, true
+}
+
+// ErrMerchantInBlacklist ...
+var ErrMerchantInBlacklist = newBusinessError(ReasonMerchantBlacklisted, "merchant in blacklist")
+
+// ErrCustomerInBlacklist ...
+var ErrCustomerInBlacklist = newBusinessError(ReasonCustomerBlacklisted, "customer in blacklist")
+
+// ErrTerminalInBlacklist ...
+var ErrTerminalInBlacklist = newBusinessError(ReasonTerminalBlacklisted, "terminal in blacklist")
+
+// ErrVoucherNotFound ...
+var ErrVoucherNotFound = newBusinessError(ReasonVoucherNotFound, "voucher not found")
+
+// ErrCampaignInactive ...
+var ErrCampaignInactive = newBusinessError(ReasonCampaignInactive, "campaign inactive")
+
+// ErrCampaignNotStarted ...
+var ErrCampaignNotStarted = newBusinessError(ReasonCampaignNotStarted, "campaign not started")
+
+// ErrCampaignEnded ...
+var ErrCampaignEnded = newBusinessError(ReasonCampaignEnded, "campaign ended")
+
+// ErrNoApplicableBenefit ...
+var ErrNoApplicableBenefit = newBusinessError(ReasonNoApplicableBenefit, "no applicable campaign benefit")
+
+// ErrMerchantNotEligible ...
+var ErrMerchantNotEligible = newBusinessError(ReasonMerchantNotEligible, "merchant not eligible for campaign")
+
+// ErrTerminalNotEligible ...
+var ErrTerminalNotEligible = newBusinessError(ReasonTerminalNotEligible, "terminal not eligible for campaign")
+
+// ErrBankNotEligible ...
+var ErrBankNotEligible = newBusinessError(ReasonBankNotEligible, "bank not eligible for campaign")
+
+// ErrCustomerNotEligible ...
+var ErrCustomerNotEligible = newBusinessError(ReasonCustomerNotEligible, "customer not eligible for campaign")
+
+// ErrCampaignBudgetExhausted ...
+var ErrCampaignBudgetExhausted = newBusinessError(ReasonBudgetExhausted, "campaign budget exhausted")
+
+// ErrCampaignUsageExhausted ...
+var ErrCampaignUsageExhausted = newBusinessError(ReasonCampaignUsageExhausted, "campaign usage exhausted")
+
+// ErrCustomerUsageExhausted ...
+var ErrCustomerUsageExhausted = newBusinessError(ReasonCustomerUsageExhausted, "customer usage exhausted")
+
+// ErrCustomerPeriodUsageExhausted ...
+var ErrCustomerPeriodUsageExhausted = newBusinessError(
+	ReasonCustomerPeriodUsageExhausted, "customer period usage exhausted",
+)
+
+// ErrInvalidAmount ...
+var ErrInvalidAmount = newBusinessError(ReasonInvalidArgument, "amount must be a non-negative decimal")
+
+// ErrInvalidReqTime ...
+var ErrInvalidReqTime = newBusinessError(ReasonInvalidArgument, "req_time is too far from the server time")
+
+func newInvalidArgumentError(format string, args ...interface{}) error {
+	return newBusinessError(ReasonInvalidArgument, fmt.Sprintf(format, args...))
+}
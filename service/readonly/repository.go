@@ -2,10 +2,12 @@ package readonly
 
 import (
 	"context"
+	"errors"
 	"github.com/QuangTung97/promo-readonly/model"
 	"github.com/QuangTung97/promo-readonly/pkg/dhash"
 	"github.com/QuangTung97/promo-readonly/pkg/util"
 	"github.com/QuangTung97/promo-readonly/repository"
+	"github.com/shopspring/decimal"
 	"math/bits"
 	"time"
 )
@@ -19,49 +21,112 @@ type IRepositoryProvider interface {
 type IRepository interface {
 	GetBlacklistCustomer(ctx context.Context, phone string) func() (model.NullBlacklistCustomer, error)
 	GetBlacklistMerchant(ctx context.Context, merchantCode string) func() (model.NullBlacklistMerchant, error)
-	// GetCampaigns(ctx context.Context, voucherCode string) func() ([]model.Campaign, error)
+	GetBlacklistTerminal(
+		ctx context.Context, merchantCode string, terminalCode string,
+	) func() (model.NullBlacklistTerminal, error)
+	GetCampaigns(ctx context.Context, voucherCode string) func() ([]model.Campaign, error)
+	GetCampaignBenefits(ctx context.Context, campaignID int64) func() ([]model.CampaignBenefit, error)
+	GetCampaignUsage(ctx context.Context, campaignID int64) func() (model.CampaignUsage, error)
+	GetCampaignCustomerUsage(
+		ctx context.Context, campaignID int64, phone string,
+	) func() (model.CampaignCustomerUsage, error)
+	GetCampaignPeriodUsage(
+		ctx context.Context, campaignID int64, phone string, termCode string,
+	) func() (model.CampaignPeriodUsage, error)
+	GetCampaignMerchant(
+		ctx context.Context, campaignID int64, merchantCode string,
+	) func() (model.NullCampaignMerchant, error)
+	GetCampaignTerminal(
+		ctx context.Context, campaignID int64, merchantCode string, terminalCode string,
+	) func() (model.NullCampaignTerminal, error)
+	GetCampaignBank(ctx context.Context, campaignID int64, bankCode string) func() (model.NullCampaignBank, error)
+	GetCampaignCustomer(ctx context.Context, campaignID int64, phone string) func() (model.NullCampaignCustomer, error)
 
 	Finish()
 }
 
 type repositoryProviderImpl struct {
-	dhashProvider dhash.Provider
-	blacklistRepo repository.Blacklist
+	dhashProvider  dhash.Provider
+	blacklistRepo  repository.Blacklist
+	campaignRepo   repository.Campaign
+	hashDBOptions  []repository.HashDatabaseOption
+	sessionOptions []dhash.SessionOption
 }
 
 var _ IRepositoryProvider = &repositoryProviderImpl{}
 
-// NewRepositoryProvider ...
-func NewRepositoryProvider(provider dhash.Provider, blacklistRepo repository.Blacklist) IRepositoryProvider {
+// NewRepositoryProvider with hashDBOptions applied to every hash database
+// and sessionOptions applied after the default wait lease durations
+func NewRepositoryProvider(
+	provider dhash.Provider, blacklistRepo repository.Blacklist, campaignRepo repository.Campaign,
+	hashDBOptions []repository.HashDatabaseOption, sessionOptions ...dhash.SessionOption,
+) IRepositoryProvider {
 	return &repositoryProviderImpl{
-		dhashProvider: provider,
-		blacklistRepo: blacklistRepo,
+		dhashProvider:  provider,
+		blacklistRepo:  blacklistRepo,
+		campaignRepo:   campaignRepo,
+		hashDBOptions:  hashDBOptions,
+		sessionOptions: sessionOptions,
 	}
 }
 
+// campaignUsageTTL is short because usages are changed by every applied voucher
+const campaignUsageTTL = 10 * time.Second
+
 // NewRepo ...
 func (p *repositoryProviderImpl) NewRepo() IRepository {
-	sess := p.dhashProvider.NewSession(dhash.WithWaitLeaseDurations([]time.Duration{
-		4 * time.Millisecond,
-		10 * time.Millisecond,
-		20 * time.Millisecond,
-		50 * time.Millisecond,
-	}))
+	options := []dhash.SessionOption{
+		dhash.WithWaitLeaseDurations([]time.Duration{
+			4 * time.Millisecond,
+			10 * time.Millisecond,
+			20 * time.Millisecond,
+			50 * time.Millisecond,
+		}),
+	}
+	sess := p.dhashProvider.NewSession(append(options, p.sessionOptions...)...)
+	dbOptions := p.hashDBOptions
 
 	return newRepository(sess,
-		sess.NewHash("bl:cst", newBlacklistCustomerHashDB(p.blacklistRepo)),
-		sess.NewHash("bl:mc", newBlacklistMerchantHashDB(p.blacklistRepo)),
+		sess.NewHash("bl:cst", newBlacklistCustomerHashDB(p.blacklistRepo, dbOptions...)),
+		sess.NewHash("bl:mc", newBlacklistMerchantHashDB(p.blacklistRepo, dbOptions...)),
+		sess.NewHash("bl:tm", newBlacklistTerminalHashDB(p.blacklistRepo, dbOptions...)),
+		sess.NewHash("campaign", newCampaignHashDB(p.campaignRepo, dbOptions...)),
+		sess.NewStore(newCampaignBenefitStoreDB(p.campaignRepo)),
+		sess.NewStore(newCampaignUsageStoreDB(p.campaignRepo), dhash.WithStoreTTL(campaignUsageTTL)),
+		sess.NewStore(newCampaignCustomerUsageStoreDB(p.campaignRepo), dhash.WithStoreTTL(campaignUsageTTL)),
+		sess.NewStore(newCampaignPeriodUsageStoreDB(p.campaignRepo), dhash.WithStoreTTL(campaignUsageTTL)),
+		sess.NewHash("cp:mc", newCampaignMerchantHashDB(p.campaignRepo, dbOptions...)),
+		sess.NewHash("cp:tm", newCampaignTerminalHashDB(p.campaignRepo, dbOptions...)),
+		sess.NewHash("cp:bnk", newCampaignBankHashDB(p.campaignRepo, dbOptions...)),
+		sess.NewHash("cp:cst", newCampaignCustomerHashDB(p.campaignRepo, dbOptions...)),
 	)
 }
 
 func newRepository(
 	sess dhash.Session, blacklistCustomerHash dhash.Hash, blacklistMerchantHash dhash.Hash,
+	blacklistTerminalHash dhash.Hash, campaignHash dhash.Hash,
+	campaignBenefitStore dhash.Store, campaignUsageStore dhash.Store,
+	customerUsageStore dhash.Store, periodUsageStore dhash.Store,
+	campaignMerchantHash dhash.Hash, campaignTerminalHash dhash.Hash, campaignBankHash dhash.Hash,
+	campaignCustomerHash dhash.Hash,
 ) IRepository {
 	return &repositoryImpl{
 		sess: sess,
 
 		blacklistCustomerHash: blacklistCustomerHash,
 		blacklistMerchantHash: blacklistMerchantHash,
+		blacklistTerminalHash: blacklistTerminalHash,
+		campaignHash:          campaignHash,
+		campaignBenefitStore:  campaignBenefitStore,
+		campaignUsageStore:    campaignUsageStore,
+		customerUsageStore:    customerUsageStore,
+		periodUsageStore:      periodUsageStore,
+		campaignMerchantHash:  campaignMerchantHash,
+		campaignTerminalHash:  campaignTerminalHash,
+		campaignBankHash:      campaignBankHash,
+		campaignCustomerHash:  campaignCustomerHash,
+
+		pendingBatches: map[hashBatchKey]*hashBatch{},
 	}
 }
 
@@ -69,10 +134,86 @@ type repositoryImpl struct {
 	sess                  dhash.Session
 	blacklistCustomerHash dhash.Hash
 	blacklistMerchantHash dhash.Hash
+	blacklistTerminalHash dhash.Hash
+	campaignHash          dhash.Hash
+	campaignBenefitStore  dhash.Store
+	campaignUsageStore    dhash.Store
+	customerUsageStore    dhash.Store
+	periodUsageStore      dhash.Store
+	campaignMerchantHash  dhash.Hash
+	campaignTerminalHash  dhash.Hash
+	campaignBankHash      dhash.Hash
+	campaignCustomerHash  dhash.Hash
+
+	pendingBatches map[hashBatchKey]*hashBatch
+	pendingOrder   []*hashBatch
 }
 
 var _ IRepository = &repositoryImpl{}
 
+type hashBatchKey struct {
+	hash dhash.Hash
+	ctx  context.Context
+}
+
+// hashBatch for hashes of a dhash.Hash requested before any result is needed,
+// they share the size log and buckets in a single SelectMultiEntries call
+type hashBatch struct {
+	hash   dhash.Hash
+	ctx    context.Context
+	hashes []uint32
+
+	selectFn func() ([][]dhash.Entry, error)
+
+	completed bool
+	results   [][]dhash.Entry
+	err       error
+}
+
+func (b *hashBatch) getEntries(index int) ([]dhash.Entry, error) {
+	if !b.completed {
+		b.completed = true
+		b.results, b.err = b.selectFn()
+	}
+	if b.err != nil {
+		return nil, b.err
+	}
+	return b.results[index], nil
+}
+
+// selectEntries adds the hash to the pending batch of the dhash.Hash,
+// pending batches of all hashes are started when the result of any of them is needed
+func (r *repositoryImpl) selectEntries(
+	ctx context.Context, h dhash.Hash, hashValue uint32,
+) func() ([]dhash.Entry, error) {
+	key := hashBatchKey{hash: h, ctx: ctx}
+	batch, existed := r.pendingBatches[key]
+	if !existed {
+		batch = &hashBatch{hash: h, ctx: ctx}
+		r.pendingBatches[key] = batch
+		r.pendingOrder = append(r.pendingOrder, batch)
+	}
+
+	index := len(batch.hashes)
+	batch.hashes = append(batch.hashes, hashValue)
+
+	return func() ([]dhash.Entry, error) {
+		r.startPendingBatches()
+		return batch.getEntries(index)
+	}
+}
+
+func (r *repositoryImpl) startPendingBatches() {
+	if len(r.pendingOrder) == 0 {
+		return
+	}
+	for _, batch := range r.pendingOrder {
+		batch.selectFn = batch.hash.SelectMultiEntries(batch.ctx, batch.hashes)
+	}
+	r.pendingOrder = nil
+	r.pendingBatches = map[hashBatchKey]*hashBatch{}
+}
+
 func log2Int(n int64) uint64 {
 	if n == 0 {
 		return 0
@@ -85,7 +226,7 @@ func (r *repositoryImpl) GetBlacklistCustomer(
 	ctx context.Context, phone string,
 ) func() (model.NullBlacklistCustomer, error) {
 	hashValue := util.HashFunc(phone)
-	fn := r.blacklistCustomerHash.SelectEntries(ctx, hashValue)
+	fn := r.selectEntries(ctx, r.blacklistCustomerHash, hashValue)
 	return func() (model.NullBlacklistCustomer, error) {
 		entries, err := fn()
 		if err != nil {
@@ -117,7 +258,7 @@ func (r *repositoryImpl) GetBlacklistMerchant(
 	ctx context.Context, merchantCode string,
 ) func() (model.NullBlacklistMerchant, error) {
 	hashValue := util.HashFunc(merchantCode)
-	fn := r.blacklistMerchantHash.SelectEntries(ctx, hashValue)
+	fn := r.selectEntries(ctx, r.blacklistMerchantHash, hashValue)
 	return func() (model.NullBlacklistMerchant, error) {
 		entries, err := fn()
 		if err != nil {
@@ -144,6 +285,272 @@ func (r *repositoryImpl) GetBlacklistMerchant(
 	}
 }
 
+// GetBlacklistTerminal ...
+func (r *repositoryImpl) GetBlacklistTerminal(
+	ctx context.Context, merchantCode string, terminalCode string,
+) func() (model.NullBlacklistTerminal, error) {
+	hashValue := util.BlacklistTerminalHash(merchantCode, terminalCode)
+	fn := r.selectEntries(ctx, r.blacklistTerminalHash, hashValue)
+	return func() (model.NullBlacklistTerminal, error) {
+		entries, err := fn()
+		if err != nil {
+			return model.NullBlacklistTerminal{}, err
+		}
+		for _, entry := range entries {
+			if entry.Hash != hashValue {
+				continue
+			}
+
+			terminal, err := unmarshalBlacklistTerminal(entry.Data)
+			if err != nil {
+				return model.NullBlacklistTerminal{}, err
+			}
+			if terminal.MerchantCode != merchantCode || terminal.TerminalCode != terminalCode {
+				continue
+			}
+			return model.NullBlacklistTerminal{
+				Valid:    true,
+				Terminal: terminal,
+			}, nil
+		}
+		return model.NullBlacklistTerminal{}, nil
+	}
+}
+
+// GetCampaigns ...
+func (r *repositoryImpl) GetCampaigns(
+	ctx context.Context, voucherCode string,
+) func() ([]model.Campaign, error) {
+	hashValue := util.HashFunc(voucherCode)
+	fn := r.selectEntries(ctx, r.campaignHash, hashValue)
+	return func() ([]model.Campaign, error) {
+		entries, err := fn()
+		if err != nil {
+			return nil, err
+		}
+
+		var result []model.Campaign
+		for _, entry := range entries {
+			if entry.Hash != hashValue {
+				continue
+			}
+
+			campaign, err := unmarshalCampaign(entry.Data)
+			if err != nil {
+				return nil, err
+			}
+			if campaign.VoucherCode != voucherCode {
+				continue
+			}
+			result = append(result, campaign)
+		}
+		return result, nil
+	}
+}
+
+// GetCampaignBenefits ...
+func (r *repositoryImpl) GetCampaignBenefits(
+	ctx context.Context, campaignID int64,
+) func() ([]model.CampaignBenefit, error) {
+	fn := r.campaignBenefitStore.Get(ctx, campaignBenefitKey(campaignID))
+	return func() ([]model.CampaignBenefit, error) {
+		data, err := fn()
+		if err != nil {
+			return nil, err
+		}
+		return unmarshalCampaignBenefits(data)
+	}
+}
+
+// GetCampaignUsage ...
+func (r *repositoryImpl) GetCampaignUsage(
+	ctx context.Context, campaignID int64,
+) func() (model.CampaignUsage, error) {
+	fn := r.campaignUsageStore.Get(ctx, campaignUsageKey(campaignID))
+	return func() (model.CampaignUsage, error) {
+		data, err := fn()
+		if errors.Is(err, dhash.ErrNotFound) {
+			return model.CampaignUsage{CampaignID: campaignID, BudgetUsed: decimal.Zero}, nil
+		}
+		if err != nil {
+			return model.CampaignUsage{}, err
+		}
+		return unmarshalCampaignUsage(data)
+	}
+}
+
+// GetCampaignCustomerUsage ...
+func (r *repositoryImpl) GetCampaignCustomerUsage(
+	ctx context.Context, campaignID int64, phone string,
+) func() (model.CampaignCustomerUsage, error) {
+	fn := r.customerUsageStore.Get(ctx, campaignCustomerUsageKey(campaignID, phone))
+	return func() (model.CampaignCustomerUsage, error) {
+		data, err := fn()
+		if errors.Is(err, dhash.ErrNotFound) {
+			return model.CampaignCustomerUsage{
+				CampaignID: campaignID,
+				Hash:       util.CampaignCustomerHash(campaignID, phone),
+				Phone:      phone,
+			}, nil
+		}
+		if err != nil {
+			return model.CampaignCustomerUsage{}, err
+		}
+		return unmarshalCampaignCustomerUsage(data)
+	}
+}
+
+// GetCampaignPeriodUsage ...
+func (r *repositoryImpl) GetCampaignPeriodUsage(
+	ctx context.Context, campaignID int64, phone string, termCode string,
+) func() (model.CampaignPeriodUsage, error) {
+	fn := r.periodUsageStore.Get(ctx, campaignPeriodUsageKey(campaignID, phone, termCode))
+	return func() (model.CampaignPeriodUsage, error) {
+		data, err := fn()
+		if errors.Is(err, dhash.ErrNotFound) {
+			return model.CampaignPeriodUsage{
+				CampaignID: campaignID,
+				Hash:       util.CampaignCustomerHash(campaignID, phone),
+				Phone:      phone,
+				TermCode:   termCode,
+			}, nil
+		}
+		if err != nil {
+			return model.CampaignPeriodUsage{}, err
+		}
+		return unmarshalCampaignPeriodUsage(data)
+	}
+}
+
+// GetCampaignMerchant ...
+func (r *repositoryImpl) GetCampaignMerchant(
+	ctx context.Context, campaignID int64, merchantCode string,
+) func() (model.NullCampaignMerchant, error) {
+	hashValue := util.CampaignMerchantHash(campaignID, merchantCode)
+	fn := r.selectEntries(ctx, r.campaignMerchantHash, hashValue)
+	return func() (model.NullCampaignMerchant, error) {
+		entries, err := fn()
+		if err != nil {
+			return model.NullCampaignMerchant{}, err
+		}
+		for _, entry := range entries {
+			if entry.Hash != hashValue {
+				continue
+			}
+
+			merchant, err := unmarshalCampaignMerchant(entry.Data)
+			if err != nil {
+				return model.NullCampaignMerchant{}, err
+			}
+			if merchant.CampaignID != campaignID || merchant.MerchantCode != merchantCode {
+				continue
+			}
+			return model.NullCampaignMerchant{
+				Valid:    true,
+				Merchant: merchant,
+			}, nil
+		}
+		return model.NullCampaignMerchant{}, nil
+	}
+}
+
+// GetCampaignTerminal ...
+func (r *repositoryImpl) GetCampaignTerminal(
+	ctx context.Context, campaignID int64, merchantCode string, terminalCode string,
+) func() (model.NullCampaignTerminal, error) {
+	hashValue := util.CampaignMerchantHash(campaignID, merchantCode)
+	fn := r.selectEntries(ctx, r.campaignTerminalHash, hashValue)
+	return func() (model.NullCampaignTerminal, error) {
+		entries, err := fn()
+		if err != nil {
+			return model.NullCampaignTerminal{}, err
+		}
+		for _, entry := range entries {
+			if entry.Hash != hashValue {
+				continue
+			}
+
+			terminal, err := unmarshalCampaignTerminal(entry.Data)
+			if err != nil {
+				return model.NullCampaignTerminal{}, err
+			}
+			if terminal.CampaignID != campaignID || terminal.MerchantCode != merchantCode ||
+				terminal.TerminalCode != terminalCode {
+				continue
+			}
+			return model.NullCampaignTerminal{
+				Valid:    true,
+				Terminal: terminal,
+			}, nil
+		}
+		return model.NullCampaignTerminal{}, nil
+	}
+}
+
+// GetCampaignBank ...
+func (r *repositoryImpl) GetCampaignBank(
+	ctx context.Context, campaignID int64, bankCode string,
+) func() (model.NullCampaignBank, error) {
+	hashValue := util.CampaignBankHash(campaignID, bankCode)
+	fn := r.selectEntries(ctx, r.campaignBankHash, hashValue)
+	return func() (model.NullCampaignBank, error) {
+		entries, err := fn()
+		if err != nil {
+			return model.NullCampaignBank{}, err
+		}
+		for _, entry := range entries {
+			if entry.Hash != hashValue {
+				continue
+			}
+
+			bank, err := unmarshalCampaignBank(entry.Data)
+			if err != nil {
+				return model.NullCampaignBank{}, err
+			}
+			if bank.CampaignID != campaignID || bank.BankCode != bankCode {
+				continue
+			}
+			return model.NullCampaignBank{
+				Valid: true,
+				Bank:  bank,
+			}, nil
+		}
+		return model.NullCampaignBank{}, nil
+	}
+}
+
+// GetCampaignCustomer ...
+func (r *repositoryImpl) GetCampaignCustomer(
+	ctx context.Context, campaignID int64, phone string,
+) func() (model.NullCampaignCustomer, error) {
+	hashValue := util.CampaignCustomerHash(campaignID, phone)
+	fn := r.selectEntries(ctx, r.campaignCustomerHash, hashValue)
+	return func() (model.NullCampaignCustomer, error) {
+		entries, err := fn()
+		if err != nil {
+			return model.NullCampaignCustomer{}, err
+		}
+		for _, entry := range entries {
+			if entry.Hash != hashValue {
+				continue
+			}
+
+			customer, err := unmarshalCampaignCustomer(entry.Data)
+			if err != nil {
+				return model.NullCampaignCustomer{}, err
+			}
+			if customer.CampaignID != campaignID || customer.Phone != phone {
+				continue
+			}
+			return model.NullCampaignCustomer{
+				Valid:    true,
+				Customer: customer,
+			}, nil
+		}
+		return model.NullCampaignCustomer{}, nil
+	}
+}
+
 // Finish ...
 func (r *repositoryImpl) Finish() {
 	r.sess.Finish()
@@ -151,14 +558,16 @@ func (r *repositoryImpl) Finish() {
 
 type dbRepoProviderImpl struct {
 	blacklistRepo repository.Blacklist
+	campaignRepo  repository.Campaign
 }
 
 var _ IRepositoryProvider = &dbRepoProviderImpl{}
 
 // NewDBRepoProvider ...
-func NewDBRepoProvider(blacklistRepo repository.Blacklist) IRepositoryProvider {
+func NewDBRepoProvider(blacklistRepo repository.Blacklist, campaignRepo repository.Campaign) IRepositoryProvider {
 	return &dbRepoProviderImpl{
 		blacklistRepo: blacklistRepo,
+		campaignRepo:  campaignRepo,
 	}
 }
 
@@ -166,17 +575,49 @@ func NewDBRepoProvider(blacklistRepo repository.Blacklist) IRepositoryProvider {
 func (p *dbRepoProviderImpl) NewRepo() IRepository {
 	return &dbRepoImpl{
 		blacklistRepo: p.blacklistRepo,
+		campaignRepo:  p.campaignRepo,
 
 		blacklistCustomerInputSet: map[string]struct{}{},
 		blacklistCustomerOutputs:  map[string]model.BlacklistCustomer{},
 
 		blacklistMerchantInputSet: map[string]struct{}{},
 		blacklistMerchantOutputs:  map[string]model.BlacklistMerchant{},
+
+		blacklistTerminalInputSet: map[repository.BlacklistTerminalKey]struct{}{},
+		blacklistTerminalOutputs:  map[repository.BlacklistTerminalKey]model.BlacklistTerminal{},
+
+		campaignInputSet: map[string]struct{}{},
+		campaignOutputs:  map[string][]model.Campaign{},
+
+		benefitInputSet: map[int64]struct{}{},
+		benefitOutputs:  map[int64][]model.CampaignBenefit{},
+
+		usageInputSet: map[int64]struct{}{},
+		usageOutputs:  map[int64]model.CampaignUsage{},
+
+		customerUsageInputSet: map[repository.CampaignCustomerKey]struct{}{},
+		customerUsageOutputs:  map[repository.CampaignCustomerKey]model.CampaignCustomerUsage{},
+
+		periodUsageInputSet: map[repository.CampaignPeriodUsageKey]struct{}{},
+		periodUsageOutputs:  map[repository.CampaignPeriodUsageKey]model.CampaignPeriodUsage{},
+
+		campaignMerchantInputSet: map[repository.CampaignMerchantKey]struct{}{},
+		campaignMerchantOutputs:  map[repository.CampaignMerchantKey]model.CampaignMerchant{},
+
+		campaignTerminalInputSet: map[repository.CampaignTerminalKey]struct{}{},
+		campaignTerminalOutputs:  map[repository.CampaignTerminalKey]model.CampaignTerminal{},
+
+		campaignBankInputSet: map[repository.CampaignBankKey]struct{}{},
+		campaignBankOutputs:  map[repository.CampaignBankKey]model.CampaignBank{},
+
+		campaignCustomerInputSet: map[repository.CampaignCustomerKey]struct{}{},
+		campaignCustomerOutputs:  map[repository.CampaignCustomerKey]model.CampaignCustomer{},
 	}
 }
 
 type dbRepoImpl struct {
 	blacklistRepo repository.Blacklist
+	campaignRepo  repository.Campaign
 
 	fetchNew bool
 
@@ -187,6 +628,46 @@ type dbRepoImpl struct {
 	blacklistMerchantInputs   []string
 	blacklistMerchantInputSet map[string]struct{}
 	blacklistMerchantOutputs  map[string]model.BlacklistMerchant
+
+	blacklistTerminalInputs   []repository.BlacklistTerminalKey
+	blacklistTerminalInputSet map[repository.BlacklistTerminalKey]struct{}
+	blacklistTerminalOutputs  map[repository.BlacklistTerminalKey]model.BlacklistTerminal
+
+	campaignInputs   []string
+	campaignInputSet map[string]struct{}
+	campaignOutputs  map[string][]model.Campaign
+
+	benefitInputs   []int64
+	benefitInputSet map[int64]struct{}
+	benefitOutputs  map[int64][]model.CampaignBenefit
+
+	usageInputs   []int64
+	usageInputSet map[int64]struct{}
+	usageOutputs  map[int64]model.CampaignUsage
+
+	customerUsageInputs   []repository.CampaignCustomerKey
+	customerUsageInputSet map[repository.CampaignCustomerKey]struct{}
+	customerUsageOutputs  map[repository.CampaignCustomerKey]model.CampaignCustomerUsage
+
+	periodUsageInputs   []repository.CampaignPeriodUsageKey
+	periodUsageInputSet map[repository.CampaignPeriodUsageKey]struct{}
+	periodUsageOutputs  map[repository.CampaignPeriodUsageKey]model.CampaignPeriodUsage
+
+	campaignMerchantInputs   []repository.CampaignMerchantKey
+	campaignMerchantInputSet map[repository.CampaignMerchantKey]struct{}
+	campaignMerchantOutputs  map[repository.CampaignMerchantKey]model.CampaignMerchant
+
+	campaignTerminalInputs   []repository.CampaignTerminalKey
+	campaignTerminalInputSet map[repository.CampaignTerminalKey]struct{}
+	campaignTerminalOutputs  map[repository.CampaignTerminalKey]model.CampaignTerminal
+
+	campaignBankInputs   []repository.CampaignBankKey
+	campaignBankInputSet map[repository.CampaignBankKey]struct{}
+	campaignBankOutputs  map[repository.CampaignBankKey]model.CampaignBank
+
+	campaignCustomerInputs   []repository.CampaignCustomerKey
+	campaignCustomerInputSet map[repository.CampaignCustomerKey]struct{}
+	campaignCustomerOutputs  map[repository.CampaignCustomerKey]model.CampaignCustomer
 }
 
 var _ IRepository = &dbRepoImpl{}
@@ -199,6 +680,7 @@ func (r *dbRepoImpl) fetchData(ctx context.Context) error {
 
 	if len(r.blacklistCustomerInputs) > 0 {
 		inputs := r.blacklistCustomerInputs
+		r.blacklistCustomerInputs = nil
 
 		keys := make([]repository.BlacklistCustomerKey, 0, len(inputs))
 		for _, phone := range inputs {
@@ -220,6 +702,7 @@ func (r *dbRepoImpl) fetchData(ctx context.Context) error {
 
 	if len(r.blacklistMerchantInputs) > 0 {
 		inputs := r.blacklistMerchantInputs
+		r.blacklistMerchantInputs = nil
 
 		keys := make([]repository.BlacklistMerchantKey, 0, len(inputs))
 		for _, code := range inputs {
@@ -238,6 +721,181 @@ func (r *dbRepoImpl) fetchData(ctx context.Context) error {
 		}
 	}
 
+	if len(r.blacklistTerminalInputs) > 0 {
+		inputs := r.blacklistTerminalInputs
+		r.blacklistTerminalInputs = nil
+
+		terminals, err := r.blacklistRepo.GetBlacklistTerminals(ctx, inputs)
+		if err != nil {
+			return err
+		}
+		for _, t := range terminals {
+			key := repository.BlacklistTerminalKey{
+				Hash:         t.Hash,
+				MerchantCode: t.MerchantCode,
+				TerminalCode: t.TerminalCode,
+			}
+			r.blacklistTerminalOutputs[key] = t
+		}
+	}
+
+	if len(r.campaignInputs) > 0 {
+		inputs := r.campaignInputs
+		r.campaignInputs = nil
+
+		keys := make([]repository.CampaignVoucherKey, 0, len(inputs))
+		for _, code := range inputs {
+			keys = append(keys, repository.CampaignVoucherKey{
+				VoucherHash: util.HashFunc(code),
+				VoucherCode: code,
+			})
+		}
+
+		campaigns, err := r.campaignRepo.GetCampaignsByVouchers(ctx, keys)
+		if err != nil {
+			return err
+		}
+		for _, c := range campaigns {
+			r.campaignOutputs[c.VoucherCode] = append(r.campaignOutputs[c.VoucherCode], c)
+		}
+	}
+
+	if len(r.benefitInputs) > 0 {
+		inputs := r.benefitInputs
+		r.benefitInputs = nil
+
+		benefits, err := r.campaignRepo.GetCampaignBenefits(ctx, inputs)
+		if err != nil {
+			return err
+		}
+		for _, b := range benefits {
+			r.benefitOutputs[b.CampaignID] = append(r.benefitOutputs[b.CampaignID], b)
+		}
+	}
+
+	if len(r.usageInputs) > 0 {
+		inputs := r.usageInputs
+		r.usageInputs = nil
+
+		usages, err := r.campaignRepo.GetCampaignUsages(ctx, inputs)
+		if err != nil {
+			return err
+		}
+		for _, u := range usages {
+			r.usageOutputs[u.CampaignID] = u
+		}
+	}
+
+	if len(r.customerUsageInputs) > 0 {
+		inputs := r.customerUsageInputs
+		r.customerUsageInputs = nil
+
+		usages, err := r.campaignRepo.GetCampaignCustomerUsages(ctx, inputs)
+		if err != nil {
+			return err
+		}
+		for _, u := range usages {
+			key := repository.CampaignCustomerKey{
+				CampaignID: u.CampaignID,
+				Hash:       u.Hash,
+				Phone:      u.Phone,
+			}
+			r.customerUsageOutputs[key] = u
+		}
+	}
+
+	if len(r.periodUsageInputs) > 0 {
+		inputs := r.periodUsageInputs
+		r.periodUsageInputs = nil
+
+		usages, err := r.campaignRepo.GetCampaignPeriodUsages(ctx, inputs)
+		if err != nil {
+			return err
+		}
+		for _, u := range usages {
+			key := repository.CampaignPeriodUsageKey{
+				CampaignID: u.CampaignID,
+				Hash:       u.Hash,
+				Phone:      u.Phone,
+				TermCode:   u.TermCode,
+			}
+			r.periodUsageOutputs[key] = u
+		}
+	}
+
+	if len(r.campaignMerchantInputs) > 0 {
+		inputs := r.campaignMerchantInputs
+		r.campaignMerchantInputs = nil
+
+		merchants, err := r.campaignRepo.GetCampaignMerchants(ctx, inputs)
+		if err != nil {
+			return err
+		}
+		for _, m := range merchants {
+			key := repository.CampaignMerchantKey{
+				CampaignID:   m.CampaignID,
+				Hash:         m.Hash,
+				MerchantCode: m.MerchantCode,
+			}
+			r.campaignMerchantOutputs[key] = m
+		}
+	}
+
+	if len(r.campaignTerminalInputs) > 0 {
+		inputs := r.campaignTerminalInputs
+		r.campaignTerminalInputs = nil
+
+		terminals, err := r.campaignRepo.GetCampaignTerminals(ctx, inputs)
+		if err != nil {
+			return err
+		}
+		for _, t := range terminals {
+			key := repository.CampaignTerminalKey{
+				CampaignID:   t.CampaignID,
+				Hash:         t.Hash,
+				MerchantCode: t.MerchantCode,
+				TerminalCode: t.TerminalCode,
+			}
+			r.campaignTerminalOutputs[key] = t
+		}
+	}
+
+	if len(r.campaignBankInputs) > 0 {
+		inputs := r.campaignBankInputs
+		r.campaignBankInputs = nil
+
+		banks, err := r.campaignRepo.GetCampaignBanks(ctx, inputs)
+		if err != nil {
+			return err
+		}
+		for _, b := range banks {
+			key := repository.CampaignBankKey{
+				CampaignID: b.CampaignID,
+				Hash:       b.Hash,
+				BankCode:   b.BankCode,
+			}
+			r.campaignBankOutputs[key] = b
+		}
+	}
+
+	if len(r.campaignCustomerInputs) > 0 {
+		inputs := r.campaignCustomerInputs
+		r.campaignCustomerInputs = nil
+
+		customers, err := r.campaignRepo.GetCampaignCustomers(ctx, inputs)
+		if err != nil {
+			return err
+		}
+		for _, c := range customers {
+			key := repository.CampaignCustomerKey{
+				CampaignID: c.CampaignID,
+				Hash:       c.Hash,
+				Phone:      c.Phone,
+			}
+			r.campaignCustomerOutputs[key] = c
+		}
+	}
+
 	return nil
 }
 
@@ -295,6 +953,297 @@ func (r *dbRepoImpl) GetBlacklistMerchant(
 	}
 }
 
+// GetBlacklistTerminal ...
+func (r *dbRepoImpl) GetBlacklistTerminal(
+	ctx context.Context, merchantCode string, terminalCode string,
+) func() (model.NullBlacklistTerminal, error) {
+	r.fetchNew = true
+
+	key := repository.BlacklistTerminalKey{
+		Hash:         util.BlacklistTerminalHash(merchantCode, terminalCode),
+		MerchantCode: merchantCode,
+		TerminalCode: terminalCode,
+	}
+	if _, existed := r.blacklistTerminalInputSet[key]; !existed {
+		r.blacklistTerminalInputSet[key] = struct{}{}
+		r.blacklistTerminalInputs = append(r.blacklistTerminalInputs, key)
+	}
+
+	return func() (model.NullBlacklistTerminal, error) {
+		if err := r.fetchData(ctx); err != nil {
+			return model.NullBlacklistTerminal{}, err
+		}
+
+		terminal, existed := r.blacklistTerminalOutputs[key]
+		if !existed {
+			return model.NullBlacklistTerminal{}, nil
+		}
+		return model.NullBlacklistTerminal{
+			Valid:    true,
+			Terminal: terminal,
+		}, nil
+	}
+}
+
+// GetCampaigns ...
+func (r *dbRepoImpl) GetCampaigns(
+	ctx context.Context, voucherCode string,
+) func() ([]model.Campaign, error) {
+	r.fetchNew = true
+
+	if _, existed := r.campaignInputSet[voucherCode]; !existed {
+		r.campaignInputSet[voucherCode] = struct{}{}
+		r.campaignInputs = append(r.campaignInputs, voucherCode)
+	}
+
+	return func() ([]model.Campaign, error) {
+		if err := r.fetchData(ctx); err != nil {
+			return nil, err
+		}
+		return r.campaignOutputs[voucherCode], nil
+	}
+}
+
+// GetCampaignBenefits ...
+func (r *dbRepoImpl) GetCampaignBenefits(
+	ctx context.Context, campaignID int64,
+) func() ([]model.CampaignBenefit, error) {
+	r.fetchNew = true
+
+	if _, existed := r.benefitInputSet[campaignID]; !existed {
+		r.benefitInputSet[campaignID] = struct{}{}
+		r.benefitInputs = append(r.benefitInputs, campaignID)
+	}
+
+	return func() ([]model.CampaignBenefit, error) {
+		if err := r.fetchData(ctx); err != nil {
+			return nil, err
+		}
+		return r.benefitOutputs[campaignID], nil
+	}
+}
+
+// GetCampaignUsage ...
+func (r *dbRepoImpl) GetCampaignUsage(
+	ctx context.Context, campaignID int64,
+) func() (model.CampaignUsage, error) {
+	r.fetchNew = true
+
+	if _, existed := r.usageInputSet[campaignID]; !existed {
+		r.usageInputSet[campaignID] = struct{}{}
+		r.usageInputs = append(r.usageInputs, campaignID)
+	}
+
+	return func() (model.CampaignUsage, error) {
+		if err := r.fetchData(ctx); err != nil {
+			return model.CampaignUsage{}, err
+		}
+
+		usage, existed := r.usageOutputs[campaignID]
+		if !existed {
+			return model.CampaignUsage{CampaignID: campaignID, BudgetUsed: decimal.Zero}, nil
+		}
+		return usage, nil
+	}
+}
+
+// GetCampaignCustomerUsage ...
+func (r *dbRepoImpl) GetCampaignCustomerUsage(
+	ctx context.Context, campaignID int64, phone string,
+) func() (model.CampaignCustomerUsage, error) {
+	r.fetchNew = true
+
+	key := repository.CampaignCustomerKey{
+		CampaignID: campaignID,
+		Hash:       util.CampaignCustomerHash(campaignID, phone),
+		Phone:      phone,
+	}
+	if _, existed := r.customerUsageInputSet[key]; !existed {
+		r.customerUsageInputSet[key] = struct{}{}
+		r.customerUsageInputs = append(r.customerUsageInputs, key)
+	}
+
+	return func() (model.CampaignCustomerUsage, error) {
+		if err := r.fetchData(ctx); err != nil {
+			return model.CampaignCustomerUsage{}, err
+		}
+
+		usage, existed := r.customerUsageOutputs[key]
+		if !existed {
+			return model.CampaignCustomerUsage{
+				CampaignID: key.CampaignID,
+				Hash:       key.Hash,
+				Phone:      key.Phone,
+			}, nil
+		}
+		return usage, nil
+	}
+}
+
+// GetCampaignPeriodUsage ...
+func (r *dbRepoImpl) GetCampaignPeriodUsage(
+	ctx context.Context, campaignID int64, phone string, termCode string,
+) func() (model.CampaignPeriodUsage, error) {
+	r.fetchNew = true
+
+	key := repository.CampaignPeriodUsageKey{
+		CampaignID: campaignID,
+		Hash:       util.CampaignCustomerHash(campaignID, phone),
+		Phone:      phone,
+		TermCode:   termCode,
+	}
+	if _, existed := r.periodUsageInputSet[key]; !existed {
+		r.periodUsageInputSet[key] = struct{}{}
+		r.periodUsageInputs = append(r.periodUsageInputs, key)
+	}
+
+	return func() (model.CampaignPeriodUsage, error) {
+		if err := r.fetchData(ctx); err != nil {
+			return model.CampaignPeriodUsage{}, err
+		}
+
+		usage, existed := r.periodUsageOutputs[key]
+		if !existed {
+			return model.CampaignPeriodUsage{
+				CampaignID: key.CampaignID,
+				Hash:       key.Hash,
+				Phone:      key.Phone,
+				TermCode:   key.TermCode,
+			}, nil
+		}
+		return usage, nil
+	}
+}
+
+// GetCampaignMerchant ...
+func (r *dbRepoImpl) GetCampaignMerchant(
+	ctx context.Context, campaignID int64, merchantCode string,
+) func() (model.NullCampaignMerchant, error) {
+	r.fetchNew = true
+
+	key := repository.CampaignMerchantKey{
+		CampaignID:   campaignID,
+		Hash:         util.CampaignMerchantHash(campaignID, merchantCode),
+		MerchantCode: merchantCode,
+	}
+	if _, existed := r.campaignMerchantInputSet[key]; !existed {
+		r.campaignMerchantInputSet[key] = struct{}{}
+		r.campaignMerchantInputs = append(r.campaignMerchantInputs, key)
+	}
+
+	return func() (model.NullCampaignMerchant, error) {
+		if err := r.fetchData(ctx); err != nil {
+			return model.NullCampaignMerchant{}, err
+		}
+
+		merchant, existed := r.campaignMerchantOutputs[key]
+		if !existed {
+			return model.NullCampaignMerchant{}, nil
+		}
+		return model.NullCampaignMerchant{
+			Valid:    true,
+			Merchant: merchant,
+		}, nil
+	}
+}
+
+// GetCampaignTerminal ...
+func (r *dbRepoImpl) GetCampaignTerminal(
+	ctx context.Context, campaignID int64, merchantCode string, terminalCode string,
+) func() (model.NullCampaignTerminal, error) {
+	r.fetchNew = true
+
+	key := repository.CampaignTerminalKey{
+		CampaignID:   campaignID,
+		Hash:         util.CampaignMerchantHash(campaignID, merchantCode),
+		MerchantCode: merchantCode,
+		TerminalCode: terminalCode,
+	}
+	if _, existed := r.campaignTerminalInputSet[key]; !existed {
+		r.campaignTerminalInputSet[key] = struct{}{}
+		r.campaignTerminalInputs = append(r.campaignTerminalInputs, key)
+	}
+
+	return func() (model.NullCampaignTerminal, error) {
+		if err := r.fetchData(ctx); err != nil {
+			return model.NullCampaignTerminal{}, err
+		}
+
+		terminal, existed := r.campaignTerminalOutputs[key]
+		if !existed {
+			return model.NullCampaignTerminal{}, nil
+		}
+		return model.NullCampaignTerminal{
+			Valid:    true,
+			Terminal: terminal,
+		}, nil
+	}
+}
+
+// GetCampaignBank ...
+func (r *dbRepoImpl) GetCampaignBank(
+	ctx context.Context, campaignID int64, bankCode string,
+) func() (model.NullCampaignBank, error) {
+	r.fetchNew = true
+
+	key := repository.CampaignBankKey{
+		CampaignID: campaignID,
+		Hash:       util.CampaignBankHash(campaignID, bankCode),
+		BankCode:   bankCode,
+	}
+	if _, existed := r.campaignBankInputSet[key]; !existed {
+		r.campaignBankInputSet[key] = struct{}{}
+		r.campaignBankInputs = append(r.campaignBankInputs, key)
+	}
+
+	return func() (model.NullCampaignBank, error) {
+		if err := r.fetchData(ctx); err != nil {
+			return model.NullCampaignBank{}, err
+		}
+
+		bank, existed := r.campaignBankOutputs[key]
+		if !existed {
+			return model.NullCampaignBank{}, nil
+		}
+		return model.NullCampaignBank{
+			Valid: true,
+			Bank:  bank,
+		}, nil
+	}
+}
+
+// GetCampaignCustomer ...
+func (r *dbRepoImpl) GetCampaignCustomer(
+	ctx context.Context, campaignID int64, phone string,
+) func() (model.NullCampaignCustomer, error) {
+	r.fetchNew = true
+
+	key := repository.CampaignCustomerKey{
+		CampaignID: campaignID,
+		Hash:       util.CampaignCustomerHash(campaignID, phone),
+		Phone:      phone,
+	}
+	if _, existed := r.campaignCustomerInputSet[key]; !existed {
+		r.campaignCustomerInputSet[key] = struct{}{}
+		r.campaignCustomerInputs = append(r.campaignCustomerInputs, key)
+	}
+
+	return func() (model.NullCampaignCustomer, error) {
+		if err := r.fetchData(ctx); err != nil {
+			return model.NullCampaignCustomer{}, err
+		}
+
+		customer, existed := r.campaignCustomerOutputs[key]
+		if !existed {
+			return model.NullCampaignCustomer{}, nil
+		}
+		return model.NullCampaignCustomer{
+			Valid:    true,
+			Customer: customer,
+		}, nil
+	}
+}
+
 // Finish ...
 func (r *dbRepoImpl) Finish() {
 }
@@ -3,13 +3,21 @@ package readonly
 import (
 	"context"
 	"database/sql"
+	"encoding/base64"
+	"fmt"
 	"github.com/QuangTung97/promo-readonly/model"
 	"github.com/QuangTung97/promo-readonly/pkg/dhash"
+	"github.com/QuangTung97/promo-readonly/pkg/util"
 	"github.com/QuangTung97/promo-readonly/promopb"
 	"github.com/QuangTung97/promo-readonly/repository"
 	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/shopspring/decimal"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	"strconv"
+	"strings"
 )
 
 func newTimestampNull(t sql.NullTime) *timestamp.Timestamp {
@@ -59,7 +67,7 @@ func unmarshalBlacklistCustomer(data []byte) (model.BlacklistCustomer, error) {
 	}, nil
 }
 
-func newBlacklistCustomerHashDB(repo repository.Blacklist) dhash.HashDatabase {
+func newBlacklistCustomerHashDB(repo repository.Blacklist, options ...repository.HashDatabaseOption) dhash.HashDatabase {
 	return repository.NewHashDatabase(func(ctx context.Context) (uint64, error) {
 		config, err := repo.GetConfig(ctx)
 		if err != nil {
@@ -80,11 +88,7 @@ func newBlacklistCustomerHashDB(repo repository.Blacklist) dhash.HashDatabase {
 			})
 		}
 		return entries, nil
-	})
-}
-
-func newCampaignHashDB(_ repository.Campaign) dhash.HashDatabase {
-	return nil
+	}, options...)
 }
 
 func marshalBlacklistMerchant(m model.BlacklistMerchant) []byte {
@@ -117,7 +121,7 @@ func unmarshalBlacklistMerchant(data []byte) (model.BlacklistMerchant, error) {
 	}, nil
 }
 
-func newBlacklistMerchantHashDB(repo repository.Blacklist) dhash.HashDatabase {
+func newBlacklistMerchantHashDB(repo repository.Blacklist, options ...repository.HashDatabaseOption) dhash.HashDatabase {
 	return repository.NewHashDatabase(func(ctx context.Context) (uint64, error) {
 		config, err := repo.GetConfig(ctx)
 		if err != nil {
@@ -138,5 +142,776 @@ func newBlacklistMerchantHashDB(repo repository.Blacklist) dhash.HashDatabase {
 			})
 		}
 		return entries, nil
+	}, options...)
+}
+
+func marshalBlacklistTerminal(t model.BlacklistTerminal) []byte {
+	msg := promopb.BlacklistTerminalData{
+		Hash:         t.Hash,
+		MerchantCode: t.MerchantCode,
+		TerminalCode: t.TerminalCode,
+		Status:       uint32(t.Status),
+		StartTime:    newTimestampNull(t.StartTime),
+		EndTime:      newTimestampNull(t.EndTime),
+	}
+	data, err := proto.Marshal(&msg)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+func unmarshalBlacklistTerminal(data []byte) (model.BlacklistTerminal, error) {
+	var msg promopb.BlacklistTerminalData
+	err := proto.Unmarshal(data, &msg)
+	if err != nil {
+		return model.BlacklistTerminal{}, err
+	}
+	return model.BlacklistTerminal{
+		Hash:         msg.Hash,
+		MerchantCode: msg.MerchantCode,
+		TerminalCode: msg.TerminalCode,
+		Status:       model.BlacklistTerminalStatus(msg.Status),
+		StartTime:    nullTimeFromTimestamp(msg.StartTime),
+		EndTime:      nullTimeFromTimestamp(msg.EndTime),
+	}, nil
+}
+
+func newBlacklistTerminalHashDB(repo repository.Blacklist, options ...repository.HashDatabaseOption) dhash.HashDatabase {
+	return repository.NewHashDatabase(func(ctx context.Context) (uint64, error) {
+		config, err := repo.GetConfig(ctx)
+		if err != nil {
+			return 0, err
+		}
+		return log2Int(config.TerminalCount), nil
+	}, func(ctx context.Context, inputs []repository.HashRange) ([]dhash.Entry, error) {
+		terminals, err := repo.SelectBlacklistTerminals(ctx, inputs)
+		if err != nil {
+			return nil, err
+		}
+
+		entries := make([]dhash.Entry, 0, len(terminals))
+		for _, t := range terminals {
+			entries = append(entries, dhash.Entry{
+				Hash: t.Hash,
+				Data: marshalBlacklistTerminal(t),
+			})
+		}
+		return entries, nil
+	}, options...)
+}
+
+func newStringValueNullDecimal(d decimal.NullDecimal) *wrappers.StringValue {
+	if !d.Valid {
+		return nil
+	}
+	return wrapperspb.String(marshalDecimal(d.Decimal))
+}
+
+func nullDecimalFromStringValue(v *wrappers.StringValue) (decimal.NullDecimal, error) {
+	if v == nil {
+		return decimal.NullDecimal{}, nil
+	}
+	d, err := decimal.NewFromString(v.Value)
+	if err != nil {
+		return decimal.NullDecimal{}, err
+	}
+	return decimal.NewNullDecimal(d), nil
+}
+
+// marshalDecimal keeps the number of decimal places (e.g. DECIMAL(19, 2) columns)
+func marshalDecimal(d decimal.Decimal) string {
+	if d.Exponent() < 0 {
+		return d.StringFixed(-d.Exponent())
+	}
+	return d.String()
+}
+
+func newInt64ValueNullInt64(n sql.NullInt64) *wrappers.Int64Value {
+	if !n.Valid {
+		return nil
+	}
+	return wrapperspb.Int64(n.Int64)
+}
+
+func nullInt64FromInt64Value(v *wrappers.Int64Value) sql.NullInt64 {
+	if v == nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{
+		Valid: true,
+		Int64: v.Value,
+	}
+}
+
+func marshalCampaign(c model.Campaign) []byte {
+	msg := promopb.CampaignData{
+		Id:     c.ID,
+		Name:   c.Name,
+		Status: uint32(c.Status),
+		Type:   uint32(c.Type),
+
+		VoucherHash: c.VoucherHash,
+		VoucherCode: c.VoucherCode,
+		StartTime:   timestamppb.New(c.StartTime),
+		EndTime:     timestamppb.New(c.EndTime),
+
+		BudgetMax:        newStringValueNullDecimal(c.BudgetMax),
+		CampaignUsageMax: newInt64ValueNullInt64(c.CampaignUsageMax),
+		CustomerUsageMax: c.CustomerUsageMax,
+
+		PeriodUsageType:        uint32(c.PeriodUsageType),
+		PeriodCustomerUsageMax: newInt64ValueNullInt64(c.PeriodCustomerUsageMax),
+		PeriodTermType:         uint32(c.PeriodTermType),
+
+		AllMerchants: c.AllMerchants,
+	}
+	data, err := proto.Marshal(&msg)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+func unmarshalCampaign(data []byte) (model.Campaign, error) {
+	var msg promopb.CampaignData
+	err := proto.Unmarshal(data, &msg)
+	if err != nil {
+		return model.Campaign{}, err
+	}
+
+	budgetMax, err := nullDecimalFromStringValue(msg.BudgetMax)
+	if err != nil {
+		return model.Campaign{}, err
+	}
+
+	return model.Campaign{
+		ID:     msg.Id,
+		Name:   msg.Name,
+		Status: model.CampaignStatus(msg.Status),
+		Type:   model.CampaignType(msg.Type),
+
+		VoucherHash: msg.VoucherHash,
+		VoucherCode: msg.VoucherCode,
+		StartTime:   msg.StartTime.AsTime(),
+		EndTime:     msg.EndTime.AsTime(),
+
+		BudgetMax:        budgetMax,
+		CampaignUsageMax: nullInt64FromInt64Value(msg.CampaignUsageMax),
+		CustomerUsageMax: msg.CustomerUsageMax,
+
+		PeriodUsageType:        model.PeriodUsageType(msg.PeriodUsageType),
+		PeriodCustomerUsageMax: nullInt64FromInt64Value(msg.PeriodCustomerUsageMax),
+		PeriodTermType:         model.PeriodTermType(msg.PeriodTermType),
+
+		AllMerchants: msg.AllMerchants,
+	}, nil
+}
+
+func newCampaignHashDB(repo repository.Campaign, options ...repository.HashDatabaseOption) dhash.HashDatabase {
+	return repository.NewHashDatabase(func(ctx context.Context) (uint64, error) {
+		count, err := repo.CountCampaigns(ctx)
+		if err != nil {
+			return 0, err
+		}
+		return log2Int(count), nil
+	}, func(ctx context.Context, inputs []repository.HashRange) ([]dhash.Entry, error) {
+		campaigns, err := repo.SelectCampaignsByHashRange(ctx, inputs)
+		if err != nil {
+			return nil, err
+		}
+
+		entries := make([]dhash.Entry, 0, len(campaigns))
+		for _, c := range campaigns {
+			entries = append(entries, dhash.Entry{
+				Hash: c.VoucherHash,
+				Data: marshalCampaign(c),
+			})
+		}
+		return entries, nil
+	}, options...)
+}
+
+func marshalCampaignBenefits(benefits []model.CampaignBenefit) []byte {
+	msg := promopb.CampaignBenefitListData{
+		Benefits: make([]*promopb.CampaignBenefitData, 0, len(benefits)),
+	}
+	for _, b := range benefits {
+		msg.Benefits = append(msg.Benefits, &promopb.CampaignBenefitData{
+			Id:         b.ID,
+			CampaignId: b.CampaignID,
+			StartTime:  timestamppb.New(b.StartTime),
+			EndTime:    timestamppb.New(b.EndTime),
+
+			TxnMinAmount:      marshalDecimal(b.TxnMinAmount),
+			DiscountPercent:   marshalDecimal(b.DiscountPercent),
+			MaxDiscountAmount: marshalDecimal(b.MaxDiscountAmount),
+		})
+	}
+	data, err := proto.Marshal(&msg)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+func unmarshalCampaignBenefits(data []byte) ([]model.CampaignBenefit, error) {
+	var msg promopb.CampaignBenefitListData
+	err := proto.Unmarshal(data, &msg)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(msg.Benefits) == 0 {
+		return nil, nil
+	}
+
+	result := make([]model.CampaignBenefit, 0, len(msg.Benefits))
+	for _, b := range msg.Benefits {
+		txnMinAmount, err := decimal.NewFromString(b.TxnMinAmount)
+		if err != nil {
+			return nil, err
+		}
+		discountPercent, err := decimal.NewFromString(b.DiscountPercent)
+		if err != nil {
+			return nil, err
+		}
+		maxDiscountAmount, err := decimal.NewFromString(b.MaxDiscountAmount)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, model.CampaignBenefit{
+			ID:         b.Id,
+			CampaignID: b.CampaignId,
+			StartTime:  b.StartTime.AsTime(),
+			EndTime:    b.EndTime.AsTime(),
+
+			TxnMinAmount:      txnMinAmount,
+			DiscountPercent:   discountPercent,
+			MaxDiscountAmount: maxDiscountAmount,
+		})
+	}
+	return result, nil
+}
+
+const campaignBenefitKeyPrefix = "cp:bnf:"
+
+func campaignBenefitKey(campaignID int64) string {
+	return campaignBenefitKeyPrefix + strconv.FormatInt(campaignID, 10)
+}
+
+func newCampaignBenefitStoreDB(repo repository.Campaign) dhash.StoreDatabase {
+	return repository.NewStoreDatabase(func(ctx context.Context, keys []string) (map[string][]byte, error) {
+		campaignIDs := make([]int64, 0, len(keys))
+		for _, key := range keys {
+			id, err := strconv.ParseInt(strings.TrimPrefix(key, campaignBenefitKeyPrefix), 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			campaignIDs = append(campaignIDs, id)
+		}
+
+		benefits, err := repo.GetCampaignBenefits(ctx, campaignIDs)
+		if err != nil {
+			return nil, err
+		}
+
+		benefitMap := map[int64][]model.CampaignBenefit{}
+		for _, b := range benefits {
+			benefitMap[b.CampaignID] = append(benefitMap[b.CampaignID], b)
+		}
+
+		result := make(map[string][]byte, len(campaignIDs))
+		for _, id := range campaignIDs {
+			result[campaignBenefitKey(id)] = marshalCampaignBenefits(benefitMap[id])
+		}
+		return result, nil
+	})
+}
+
+func marshalCampaignUsage(u model.CampaignUsage) []byte {
+	msg := promopb.CampaignUsageData{
+		CampaignId:   u.CampaignID,
+		BudgetUsed:   marshalDecimal(u.BudgetUsed),
+		CampaignUsed: u.CampaignUsed,
+	}
+	data, err := proto.Marshal(&msg)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+func unmarshalCampaignUsage(data []byte) (model.CampaignUsage, error) {
+	var msg promopb.CampaignUsageData
+	err := proto.Unmarshal(data, &msg)
+	if err != nil {
+		return model.CampaignUsage{}, err
+	}
+
+	budgetUsed, err := decimal.NewFromString(msg.BudgetUsed)
+	if err != nil {
+		return model.CampaignUsage{}, err
+	}
+
+	return model.CampaignUsage{
+		CampaignID:   msg.CampaignId,
+		BudgetUsed:   budgetUsed,
+		CampaignUsed: msg.CampaignUsed,
+	}, nil
+}
+
+const campaignUsageKeyPrefix = "cp:usg:"
+
+func campaignUsageKey(campaignID int64) string {
+	return campaignUsageKeyPrefix + strconv.FormatInt(campaignID, 10)
+}
+
+// newCampaignUsageStoreDB returns dhash.ErrNotFound for campaigns without campaign_usage row
+func newCampaignUsageStoreDB(repo repository.Campaign) dhash.StoreDatabase {
+	return repository.NewStoreDatabase(func(ctx context.Context, keys []string) (map[string][]byte, error) {
+		campaignIDs := make([]int64, 0, len(keys))
+		for _, key := range keys {
+			id, err := strconv.ParseInt(strings.TrimPrefix(key, campaignUsageKeyPrefix), 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			campaignIDs = append(campaignIDs, id)
+		}
+
+		usages, err := repo.GetCampaignUsages(ctx, campaignIDs)
+		if err != nil {
+			return nil, err
+		}
+
+		usageMap := map[int64]model.CampaignUsage{}
+		for _, u := range usages {
+			usageMap[u.CampaignID] = u
+		}
+
+		result := make(map[string][]byte, len(usages))
+		for _, usage := range usageMap {
+			result[campaignUsageKey(usage.CampaignID)] = marshalCampaignUsage(usage)
+		}
+		return result, nil
+	})
+}
+
+func marshalCampaignCustomerUsage(u model.CampaignCustomerUsage) []byte {
+	msg := promopb.CampaignCustomerUsageData{
+		CampaignId: u.CampaignID,
+		Hash:       u.Hash,
+		Phone:      u.Phone,
+		UsageNum:   u.UsageNum,
+	}
+	data, err := proto.Marshal(&msg)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+func unmarshalCampaignCustomerUsage(data []byte) (model.CampaignCustomerUsage, error) {
+	var msg promopb.CampaignCustomerUsageData
+	err := proto.Unmarshal(data, &msg)
+	if err != nil {
+		return model.CampaignCustomerUsage{}, err
+	}
+	return model.CampaignCustomerUsage{
+		CampaignID: msg.CampaignId,
+		Hash:       msg.Hash,
+		Phone:      msg.Phone,
+		UsageNum:   msg.UsageNum,
+	}, nil
+}
+
+const campaignCustomerUsageKeyPrefix = "cp:cu:"
+
+func campaignCustomerUsageKey(campaignID int64, phone string) string {
+	return campaignCustomerUsageKeyPrefix + strconv.FormatInt(campaignID, 10) + ":" + encodeStoreKeyPart(phone)
+}
+
+// encodeStoreKeyPart encodes a part of store key coming from request inputs,
+// spaces would break memcached commands and ':' would break parseCampaignStoreKey
+func encodeStoreKeyPart(part string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(part))
+}
+
+// parseCampaignStoreKey splits the key without prefix into the campaign id and n - 1 remaining decoded parts
+func parseCampaignStoreKey(key string, prefix string, n int) (int64, []string, error) {
+	parts := strings.Split(strings.TrimPrefix(key, prefix), ":")
+	if len(parts) != n {
+		return 0, nil, fmt.Errorf("invalid store key: %s", key)
+	}
+
+	id, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	result := make([]string, 0, n-1)
+	for _, part := range parts[1:] {
+		decoded, err := base64.RawURLEncoding.DecodeString(part)
+		if err != nil {
+			return 0, nil, fmt.Errorf("invalid store key: %s", key)
+		}
+		result = append(result, string(decoded))
+	}
+	return id, result, nil
+}
+
+// newCampaignCustomerUsageStoreDB returns dhash.ErrNotFound for customers without campaign_customer_usage row
+func newCampaignCustomerUsageStoreDB(repo repository.Campaign) dhash.StoreDatabase {
+	return repository.NewStoreDatabase(func(ctx context.Context, keys []string) (map[string][]byte, error) {
+		usageKeys := make([]repository.CampaignCustomerKey, 0, len(keys))
+		for _, key := range keys {
+			id, parts, err := parseCampaignStoreKey(key, campaignCustomerUsageKeyPrefix, 2)
+			if err != nil {
+				return nil, err
+			}
+			phone := parts[0]
+			usageKeys = append(usageKeys, repository.CampaignCustomerKey{
+				CampaignID: id,
+				Hash:       util.CampaignCustomerHash(id, phone),
+				Phone:      phone,
+			})
+		}
+
+		usages, err := repo.GetCampaignCustomerUsages(ctx, usageKeys)
+		if err != nil {
+			return nil, err
+		}
+
+		usageMap := map[repository.CampaignCustomerKey]model.CampaignCustomerUsage{}
+		for _, u := range usages {
+			usageMap[repository.CampaignCustomerKey{
+				CampaignID: u.CampaignID,
+				Hash:       u.Hash,
+				Phone:      u.Phone,
+			}] = u
+		}
+
+		result := make(map[string][]byte, len(usageMap))
+		for key, usage := range usageMap {
+			result[campaignCustomerUsageKey(key.CampaignID, key.Phone)] = marshalCampaignCustomerUsage(usage)
+		}
+		return result, nil
+	})
+}
+
+func marshalCampaignPeriodUsage(u model.CampaignPeriodUsage) []byte {
+	msg := promopb.CampaignPeriodUsageData{
+		CampaignId: u.CampaignID,
+		Hash:       u.Hash,
+		Phone:      u.Phone,
+		TermCode:   u.TermCode,
+		UsageNum:   u.UsageNum,
+		ExpiredOn:  timestamppb.New(u.ExpiredOn),
+	}
+	data, err := proto.Marshal(&msg)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+func unmarshalCampaignPeriodUsage(data []byte) (model.CampaignPeriodUsage, error) {
+	var msg promopb.CampaignPeriodUsageData
+	err := proto.Unmarshal(data, &msg)
+	if err != nil {
+		return model.CampaignPeriodUsage{}, err
+	}
+	return model.CampaignPeriodUsage{
+		CampaignID: msg.CampaignId,
+		Hash:       msg.Hash,
+		Phone:      msg.Phone,
+		TermCode:   msg.TermCode,
+		UsageNum:   msg.UsageNum,
+		ExpiredOn:  msg.ExpiredOn.AsTime(),
+	}, nil
+}
+
+const campaignPeriodUsageKeyPrefix = "cp:pu:"
+
+func campaignPeriodUsageKey(campaignID int64, phone string, termCode string) string {
+	return campaignPeriodUsageKeyPrefix + strconv.FormatInt(campaignID, 10) +
+		":" + encodeStoreKeyPart(phone) + ":" + encodeStoreKeyPart(termCode)
+}
+
+// newCampaignPeriodUsageStoreDB returns dhash.ErrNotFound for customers without campaign_period_usage row
+func newCampaignPeriodUsageStoreDB(repo repository.Campaign) dhash.StoreDatabase {
+	return repository.NewStoreDatabase(func(ctx context.Context, keys []string) (map[string][]byte, error) {
+		usageKeys := make([]repository.CampaignPeriodUsageKey, 0, len(keys))
+		for _, key := range keys {
+			id, parts, err := parseCampaignStoreKey(key, campaignPeriodUsageKeyPrefix, 3)
+			if err != nil {
+				return nil, err
+			}
+			phone := parts[0]
+			usageKeys = append(usageKeys, repository.CampaignPeriodUsageKey{
+				CampaignID: id,
+				Hash:       util.CampaignCustomerHash(id, phone),
+				Phone:      phone,
+				TermCode:   parts[1],
+			})
+		}
+
+		usages, err := repo.GetCampaignPeriodUsages(ctx, usageKeys)
+		if err != nil {
+			return nil, err
+		}
+
+		usageMap := map[repository.CampaignPeriodUsageKey]model.CampaignPeriodUsage{}
+		for _, u := range usages {
+			usageMap[repository.CampaignPeriodUsageKey{
+				CampaignID: u.CampaignID,
+				Hash:       u.Hash,
+				Phone:      u.Phone,
+				TermCode:   u.TermCode,
+			}] = u
+		}
+
+		result := make(map[string][]byte, len(usageMap))
+		for key, usage := range usageMap {
+			result[campaignPeriodUsageKey(key.CampaignID, key.Phone, key.TermCode)] = marshalCampaignPeriodUsage(usage)
+		}
+		return result, nil
 	})
 }
+
+func marshalCampaignMerchant(m model.CampaignMerchant) []byte {
+	msg := promopb.CampaignMerchantData{
+		CampaignId:   m.CampaignID,
+		Hash:         m.Hash,
+		MerchantCode: m.MerchantCode,
+
+		Status:       uint32(m.Status),
+		StartTime:    newTimestampNull(m.StartTime),
+		EndTime:      newTimestampNull(m.EndTime),
+		AllTerminals: m.AllTerminals,
+	}
+	data, err := proto.Marshal(&msg)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+func unmarshalCampaignMerchant(data []byte) (model.CampaignMerchant, error) {
+	var msg promopb.CampaignMerchantData
+	err := proto.Unmarshal(data, &msg)
+	if err != nil {
+		return model.CampaignMerchant{}, err
+	}
+	return model.CampaignMerchant{
+		CampaignID:   msg.CampaignId,
+		Hash:         msg.Hash,
+		MerchantCode: msg.MerchantCode,
+
+		Status:       model.CampaignMerchantStatus(msg.Status),
+		StartTime:    nullTimeFromTimestamp(msg.StartTime),
+		EndTime:      nullTimeFromTimestamp(msg.EndTime),
+		AllTerminals: msg.AllTerminals,
+	}, nil
+}
+
+func newCampaignMerchantHashDB(repo repository.Campaign, options ...repository.HashDatabaseOption) dhash.HashDatabase {
+	return repository.NewHashDatabase(func(ctx context.Context) (uint64, error) {
+		count, err := repo.CountCampaignMerchants(ctx)
+		if err != nil {
+			return 0, err
+		}
+		return log2Int(count), nil
+	}, func(ctx context.Context, inputs []repository.HashRange) ([]dhash.Entry, error) {
+		merchants, err := repo.SelectCampaignMerchants(ctx, inputs)
+		if err != nil {
+			return nil, err
+		}
+
+		entries := make([]dhash.Entry, 0, len(merchants))
+		for _, m := range merchants {
+			entries = append(entries, dhash.Entry{
+				Hash: m.Hash,
+				Data: marshalCampaignMerchant(m),
+			})
+		}
+		return entries, nil
+	}, options...)
+}
+
+func marshalCampaignTerminal(t model.CampaignTerminal) []byte {
+	msg := promopb.CampaignTerminalData{
+		CampaignId:   t.CampaignID,
+		Hash:         t.Hash,
+		MerchantCode: t.MerchantCode,
+		TerminalCode: t.TerminalCode,
+
+		Status:    uint32(t.Status),
+		StartTime: newTimestampNull(t.StartTime),
+		EndTime:   newTimestampNull(t.EndTime),
+	}
+	data, err := proto.Marshal(&msg)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+func unmarshalCampaignTerminal(data []byte) (model.CampaignTerminal, error) {
+	var msg promopb.CampaignTerminalData
+	err := proto.Unmarshal(data, &msg)
+	if err != nil {
+		return model.CampaignTerminal{}, err
+	}
+	return model.CampaignTerminal{
+		CampaignID:   msg.CampaignId,
+		Hash:         msg.Hash,
+		MerchantCode: msg.MerchantCode,
+		TerminalCode: msg.TerminalCode,
+
+		Status:    model.CampaignTerminalStatus(msg.Status),
+		StartTime: nullTimeFromTimestamp(msg.StartTime),
+		EndTime:   nullTimeFromTimestamp(msg.EndTime),
+	}, nil
+}
+
+func newCampaignTerminalHashDB(repo repository.Campaign, options ...repository.HashDatabaseOption) dhash.HashDatabase {
+	return repository.NewHashDatabase(func(ctx context.Context) (uint64, error) {
+		count, err := repo.CountCampaignTerminals(ctx)
+		if err != nil {
+			return 0, err
+		}
+		return log2Int(count), nil
+	}, func(ctx context.Context, inputs []repository.HashRange) ([]dhash.Entry, error) {
+		terminals, err := repo.SelectCampaignTerminals(ctx, inputs)
+		if err != nil {
+			return nil, err
+		}
+
+		entries := make([]dhash.Entry, 0, len(terminals))
+		for _, t := range terminals {
+			entries = append(entries, dhash.Entry{
+				Hash: t.Hash,
+				Data: marshalCampaignTerminal(t),
+			})
+		}
+		return entries, nil
+	}, options...)
+}
+
+func marshalCampaignBank(b model.CampaignBank) []byte {
+	msg := promopb.CampaignBankData{
+		CampaignId: b.CampaignID,
+		Hash:       b.Hash,
+		BankCode:   b.BankCode,
+
+		Status:    uint32(b.Status),
+		StartTime: newTimestampNull(b.StartTime),
+		EndTime:   newTimestampNull(b.EndTime),
+	}
+	data, err := proto.Marshal(&msg)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+func unmarshalCampaignBank(data []byte) (model.CampaignBank, error) {
+	var msg promopb.CampaignBankData
+	err := proto.Unmarshal(data, &msg)
+	if err != nil {
+		return model.CampaignBank{}, err
+	}
+	return model.CampaignBank{
+		CampaignID: msg.CampaignId,
+		Hash:       msg.Hash,
+		BankCode:   msg.BankCode,
+
+		Status:    model.CampaignBankStatus(msg.Status),
+		StartTime: nullTimeFromTimestamp(msg.StartTime),
+		EndTime:   nullTimeFromTimestamp(msg.EndTime),
+	}, nil
+}
+
+func newCampaignBankHashDB(repo repository.Campaign, options ...repository.HashDatabaseOption) dhash.HashDatabase {
+	return repository.NewHashDatabase(func(ctx context.Context) (uint64, error) {
+		count, err := repo.CountCampaignBanks(ctx)
+		if err != nil {
+			return 0, err
+		}
+		return log2Int(count), nil
+	}, func(ctx context.Context, inputs []repository.HashRange) ([]dhash.Entry, error) {
+		banks, err := repo.SelectCampaignBanks(ctx, inputs)
+		if err != nil {
+			return nil, err
+		}
+
+		entries := make([]dhash.Entry, 0, len(banks))
+		for _, b := range banks {
+			entries = append(entries, dhash.Entry{
+				Hash: b.Hash,
+				Data: marshalCampaignBank(b),
+			})
+		}
+		return entries, nil
+	}, options...)
+}
+
+func marshalCampaignCustomer(c model.CampaignCustomer) []byte {
+	msg := promopb.CampaignCustomerData{
+		CampaignId: c.CampaignID,
+		Hash:       c.Hash,
+		Phone:      c.Phone,
+
+		Status:    uint32(c.Status),
+		StartTime: newTimestampNull(c.StartTime),
+		EndTime:   newTimestampNull(c.EndTime),
+	}
+	data, err := proto.Marshal(&msg)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+func unmarshalCampaignCustomer(data []byte) (model.CampaignCustomer, error) {
+	var msg promopb.CampaignCustomerData
+	err := proto.Unmarshal(data, &msg)
+	if err != nil {
+		return model.CampaignCustomer{}, err
+	}
+	return model.CampaignCustomer{
+		CampaignID: msg.CampaignId,
+		Hash:       msg.Hash,
+		Phone:      msg.Phone,
+
+		Status:    model.CampaignCustomerStatus(msg.Status),
+		StartTime: nullTimeFromTimestamp(msg.StartTime),
+		EndTime:   nullTimeFromTimestamp(msg.EndTime),
+	}, nil
+}
+
+func newCampaignCustomerHashDB(repo repository.Campaign, options ...repository.HashDatabaseOption) dhash.HashDatabase {
+	return repository.NewHashDatabase(func(ctx context.Context) (uint64, error) {
+		count, err := repo.CountCampaignCustomers(ctx)
+		if err != nil {
+			return 0, err
+		}
+		return log2Int(count), nil
+	}, func(ctx context.Context, inputs []repository.HashRange) ([]dhash.Entry, error) {
+		customers, err := repo.SelectCampaignCustomers(ctx, inputs)
+		if err != nil {
+			return nil, err
+		}
+
+		entries := make([]dhash.Entry, 0, len(customers))
+		for _, c := range customers {
+			entries = append(entries, dhash.Entry{
+				Hash: c.Hash,
+				Data: marshalCampaignCustomer(c),
+			})
+		}
+		return entries, nil
+	}, options...)
+}
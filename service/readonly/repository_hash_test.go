@@ -2,10 +2,15 @@ package readonly
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"github.com/QuangTung97/promo-readonly/model"
 	"github.com/QuangTung97/promo-readonly/pkg/dhash"
+	"github.com/QuangTung97/promo-readonly/pkg/util"
 	"github.com/QuangTung97/promo-readonly/repository"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
+	"strings"
 	"testing"
 )
 
@@ -158,7 +163,737 @@ func TestBlacklistCustomerHashDB__Select_Entries__Returns_Correct_Data(t *testin
 	}, entries2)
 }
 
-func TestCampaignHashDB__Select_Entries__Returns_Correct_Data(*testing.T) {
-	//repo := &repository.BlacklistMock{}
-	//db := newBlacklistCustomerHashDB(repo)
+func TestBlacklistTerminalHashDB__GetSizeLog(t *testing.T) {
+	repo := &repository.BlacklistMock{}
+	db := newBlacklistTerminalHashDB(repo)
+
+	repo.GetConfigFunc = func(ctx context.Context) (model.BlacklistConfig, error) {
+		return model.BlacklistConfig{
+			CustomerCount: 100,
+			MerchantCount: 100,
+			TerminalCount: 15,
+		}, nil
+	}
+
+	num, err := db.GetSizeLog(newContext())()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, uint64(4), num)
+
+	assert.Equal(t, 1, len(repo.GetConfigCalls()))
+}
+
+func TestBlacklistTerminalHashDB__Select_Entries__Returns_Correct_Data(t *testing.T) {
+	repo := &repository.BlacklistMock{}
+	db := newBlacklistTerminalHashDB(repo)
+
+	terminal1 := model.BlacklistTerminal{
+		Hash:         30,
+		MerchantCode: "MERCHANT01",
+		TerminalCode: "TERMINAL01",
+		Status:       model.BlacklistTerminalStatusActive,
+		StartTime:    newNullTime("2022-05-10T10:00:00+07:00"),
+		EndTime:      newNullTime("2022-05-20T10:00:00+07:00"),
+	}
+	terminal2 := model.BlacklistTerminal{
+		Hash:         250,
+		MerchantCode: "MERCHANT02",
+		TerminalCode: "TERMINAL02",
+		Status:       model.BlacklistTerminalStatusInactive,
+	}
+
+	repo.SelectBlacklistTerminalsFunc = func(
+		ctx context.Context, ranges []repository.HashRange,
+	) ([]model.BlacklistTerminal, error) {
+		return []model.BlacklistTerminal{terminal1, terminal2}, nil
+	}
+
+	fn1 := db.SelectEntries(newContext(), 20, newNullUint32(100))
+	fn2 := db.SelectEntries(newContext(), 220, dhash.NullUint32{})
+
+	entries1, err := fn1()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []dhash.Entry{
+		{
+			Hash: 30,
+			Data: marshalBlacklistTerminal(terminal1),
+		},
+	}, entries1)
+
+	entries2, err := fn2()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []dhash.Entry{
+		{
+			Hash: 250,
+			Data: marshalBlacklistTerminal(terminal2),
+		},
+	}, entries2)
+
+	assert.Equal(t, 1, len(repo.SelectBlacklistTerminalsCalls()))
+	assert.Equal(t, []repository.HashRange{
+		{
+			Begin: 20,
+			End:   newNullUint32(100),
+		},
+		{
+			Begin: 220,
+		},
+	}, repo.SelectBlacklistTerminalsCalls()[0].Ranges)
+
+	terminal, err := unmarshalBlacklistTerminal(entries1[0].Data)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, terminal1, terminal)
+}
+
+func TestCampaignHashDB__GetSizeLog(t *testing.T) {
+	repo := &repository.CampaignMock{}
+	db := newCampaignHashDB(repo)
+
+	repo.CountCampaignsFunc = func(ctx context.Context) (int64, error) {
+		return 33, nil
+	}
+
+	num, err := db.GetSizeLog(newContext())()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, uint64(6), num)
+
+	assert.Equal(t, 1, len(repo.CountCampaignsCalls()))
+}
+
+func TestCampaignHashDB__Select_Entries__Returns_Correct_Data(t *testing.T) {
+	repo := &repository.CampaignMock{}
+	db := newCampaignHashDB(repo)
+
+	campaign1 := model.Campaign{
+		ID:     21,
+		Name:   "campaign 01",
+		Status: model.CampaignStatusActive,
+		Type:   model.CampaignTypeMerchant,
+
+		VoucherHash: 30,
+		VoucherCode: "VOUCHER01",
+		StartTime:   newTime("2022-05-10T10:00:00+07:00"),
+		EndTime:     newTime("2022-05-20T10:00:00+07:00"),
+
+		BudgetMax:        decimal.NewNullDecimal(newDecimal("120000.00")),
+		CampaignUsageMax: sql.NullInt64{Valid: true, Int64: 200},
+		CustomerUsageMax: 5,
+
+		PeriodUsageType:        model.PeriodUsageTypeDaily,
+		PeriodCustomerUsageMax: sql.NullInt64{Valid: true, Int64: 2},
+		PeriodTermType:         model.PeriodTermTypeCampaign,
+
+		AllMerchants: true,
+	}
+	campaign2 := model.Campaign{
+		ID:     22,
+		Name:   "campaign 02",
+		Status: model.CampaignStatusInactive,
+		Type:   model.CampaignTypeBank,
+
+		VoucherHash: 250,
+		VoucherCode: "VOUCHER02",
+		StartTime:   newTime("2022-05-11T10:00:00+07:00"),
+		EndTime:     newTime("2022-05-21T10:00:00+07:00"),
+	}
+
+	repo.SelectCampaignsByHashRangeFunc = func(
+		ctx context.Context, ranges []repository.HashRange,
+	) ([]model.Campaign, error) {
+		return []model.Campaign{campaign1, campaign2}, nil
+	}
+
+	fn1 := db.SelectEntries(newContext(), 20, newNullUint32(100))
+	fn2 := db.SelectEntries(newContext(), 220, dhash.NullUint32{})
+
+	entries1, err := fn1()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []dhash.Entry{
+		{
+			Hash: 30,
+			Data: marshalCampaign(campaign1),
+		},
+	}, entries1)
+
+	entries2, err := fn2()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []dhash.Entry{
+		{
+			Hash: 250,
+			Data: marshalCampaign(campaign2),
+		},
+	}, entries2)
+
+	assert.Equal(t, 1, len(repo.SelectCampaignsByHashRangeCalls()))
+
+	campaign, err := unmarshalCampaign(entries1[0].Data)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, campaign1, campaign)
+
+	campaign, err = unmarshalCampaign(entries2[0].Data)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, campaign2, campaign)
+}
+
+func TestCampaignBenefitStoreDB__Get__Returns_Correct_Data(t *testing.T) {
+	repo := &repository.CampaignMock{}
+	db := newCampaignBenefitStoreDB(repo)
+
+	benefit1 := model.CampaignBenefit{
+		ID:         1,
+		CampaignID: 11,
+		StartTime:  newTime("2022-05-10T10:00:00+07:00"),
+		EndTime:    newTime("2022-05-20T10:00:00+07:00"),
+
+		TxnMinAmount:      newDecimal("50000.00"),
+		DiscountPercent:   newDecimal("10.00"),
+		MaxDiscountAmount: newDecimal("20000.00"),
+	}
+	benefit2 := model.CampaignBenefit{
+		ID:         2,
+		CampaignID: 11,
+		StartTime:  newTime("2022-05-10T10:00:00+07:00"),
+		EndTime:    newTime("2022-05-20T10:00:00+07:00"),
+
+		TxnMinAmount:      newDecimal("100000.00"),
+		DiscountPercent:   newDecimal("15.00"),
+		MaxDiscountAmount: newDecimal("30000.00"),
+	}
+
+	repo.GetCampaignBenefitsFunc = func(ctx context.Context, campaignIDs []int64) ([]model.CampaignBenefit, error) {
+		return []model.CampaignBenefit{benefit1, benefit2}, nil
+	}
+
+	fn1 := db.Get(newContext(), campaignBenefitKey(11))
+	fn2 := db.Get(newContext(), campaignBenefitKey(12))
+	fn3 := db.Get(newContext(), campaignBenefitKey(11))
+
+	data1, err := fn1()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, marshalCampaignBenefits([]model.CampaignBenefit{benefit1, benefit2}), data1)
+
+	data2, err := fn2()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, marshalCampaignBenefits(nil), data2)
+
+	data3, err := fn3()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, data1, data3)
+
+	assert.Equal(t, 1, len(repo.GetCampaignBenefitsCalls()))
+	assert.Equal(t, []int64{11, 12}, repo.GetCampaignBenefitsCalls()[0].CampaignIDs)
+
+	benefits, err := unmarshalCampaignBenefits(data1)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []model.CampaignBenefit{benefit1, benefit2}, benefits)
+}
+
+func TestCampaignBenefitStoreDB__Get__Returns_Error(t *testing.T) {
+	repo := &repository.CampaignMock{}
+	db := newCampaignBenefitStoreDB(repo)
+
+	someErr := errors.New("some error")
+	repo.GetCampaignBenefitsFunc = func(ctx context.Context, campaignIDs []int64) ([]model.CampaignBenefit, error) {
+		return nil, someErr
+	}
+
+	data, err := db.Get(newContext(), campaignBenefitKey(11))()
+	assert.Equal(t, someErr, err)
+	assert.Nil(t, data)
+}
+
+func TestCampaignUsageStoreDB__Get__Returns_Correct_Data(t *testing.T) {
+	repo := &repository.CampaignMock{}
+	db := newCampaignUsageStoreDB(repo)
+
+	usage := model.CampaignUsage{
+		CampaignID:   11,
+		BudgetUsed:   newDecimal("150000.50"),
+		CampaignUsed: 12,
+	}
+
+	repo.GetCampaignUsagesFunc = func(ctx context.Context, campaignIDs []int64) ([]model.CampaignUsage, error) {
+		return []model.CampaignUsage{usage}, nil
+	}
+
+	fn1 := db.Get(newContext(), campaignUsageKey(11))
+	fn2 := db.Get(newContext(), campaignUsageKey(12))
+
+	data1, err := fn1()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, marshalCampaignUsage(usage), data1)
+
+	data2, err := fn2()
+	assert.Equal(t, dhash.ErrNotFound, err)
+	assert.Nil(t, data2)
+
+	assert.Equal(t, 1, len(repo.GetCampaignUsagesCalls()))
+	assert.Equal(t, []int64{11, 12}, repo.GetCampaignUsagesCalls()[0].CampaignIDs)
+}
+
+func TestCampaignUsageStoreDB__Get__Returns_Error(t *testing.T) {
+	repo := &repository.CampaignMock{}
+	db := newCampaignUsageStoreDB(repo)
+
+	someErr := errors.New("some error")
+	repo.GetCampaignUsagesFunc = func(ctx context.Context, campaignIDs []int64) ([]model.CampaignUsage, error) {
+		return nil, someErr
+	}
+
+	data, err := db.Get(newContext(), campaignUsageKey(11))()
+	assert.Equal(t, someErr, err)
+	assert.Nil(t, data)
+}
+
+func TestCampaignCustomerUsageStoreDB__Get__Returns_Correct_Data(t *testing.T) {
+	repo := &repository.CampaignMock{}
+	db := newCampaignCustomerUsageStoreDB(repo)
+
+	usage := model.CampaignCustomerUsage{
+		CampaignID: 11,
+		Hash:       util.CampaignCustomerHash(11, "0987000111"),
+		Phone:      "0987000111",
+		UsageNum:   3,
+	}
+
+	repo.GetCampaignCustomerUsagesFunc = func(
+		ctx context.Context, keys []repository.CampaignCustomerKey,
+	) ([]model.CampaignCustomerUsage, error) {
+		return []model.CampaignCustomerUsage{usage}, nil
+	}
+
+	fn1 := db.Get(newContext(), campaignCustomerUsageKey(11, "0987000111"))
+	fn2 := db.Get(newContext(), campaignCustomerUsageKey(11, "0987000222"))
+
+	data1, err := fn1()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, marshalCampaignCustomerUsage(usage), data1)
+
+	data2, err := fn2()
+	assert.Equal(t, dhash.ErrNotFound, err)
+	assert.Nil(t, data2)
+
+	assert.Equal(t, 1, len(repo.GetCampaignCustomerUsagesCalls()))
+	assert.Equal(t, []repository.CampaignCustomerKey{
+		{CampaignID: 11, Hash: util.CampaignCustomerHash(11, "0987000111"), Phone: "0987000111"},
+		{CampaignID: 11, Hash: util.CampaignCustomerHash(11, "0987000222"), Phone: "0987000222"},
+	}, repo.GetCampaignCustomerUsagesCalls()[0].Keys)
+}
+
+func TestCampaignCustomerUsageStoreDB__Get__Invalid_Key(t *testing.T) {
+	repo := &repository.CampaignMock{}
+	db := newCampaignCustomerUsageStoreDB(repo)
+
+	data, err := db.Get(newContext(), "cp:cu:11")()
+	assert.Equal(t, errors.New("invalid store key: cp:cu:11"), err)
+	assert.Nil(t, data)
+}
+
+func TestCampaignPeriodUsageStoreDB__Get__Returns_Correct_Data(t *testing.T) {
+	repo := &repository.CampaignMock{}
+	db := newCampaignPeriodUsageStoreDB(repo)
+
+	usage := model.CampaignPeriodUsage{
+		CampaignID: 11,
+		Hash:       util.CampaignCustomerHash(11, "0987000111"),
+		Phone:      "0987000111",
+		TermCode:   "20220515:MERCHANT01",
+		UsageNum:   2,
+		ExpiredOn:  newTime("2022-05-16T00:00:00+07:00"),
+	}
+
+	repo.GetCampaignPeriodUsagesFunc = func(
+		ctx context.Context, keys []repository.CampaignPeriodUsageKey,
+	) ([]model.CampaignPeriodUsage, error) {
+		return []model.CampaignPeriodUsage{usage}, nil
+	}
+
+	fn1 := db.Get(newContext(), campaignPeriodUsageKey(11, "0987000111", "20220515:MERCHANT01"))
+	fn2 := db.Get(newContext(), campaignPeriodUsageKey(11, "0987000111", "20220515:MERCHANT02"))
+
+	data1, err := fn1()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, marshalCampaignPeriodUsage(usage), data1)
+
+	data2, err := fn2()
+	assert.Equal(t, dhash.ErrNotFound, err)
+	assert.Nil(t, data2)
+
+	assert.Equal(t, 1, len(repo.GetCampaignPeriodUsagesCalls()))
+	assert.Equal(t, []repository.CampaignPeriodUsageKey{
+		{
+			CampaignID: 11, Hash: util.CampaignCustomerHash(11, "0987000111"),
+			Phone: "0987000111", TermCode: "20220515:MERCHANT01",
+		},
+		{
+			CampaignID: 11, Hash: util.CampaignCustomerHash(11, "0987000111"),
+			Phone: "0987000111", TermCode: "20220515:MERCHANT02",
+		},
+	}, repo.GetCampaignPeriodUsagesCalls()[0].Keys)
+}
+
+func TestCampaignPeriodUsageStoreDB__Get__Returns_Error(t *testing.T) {
+	repo := &repository.CampaignMock{}
+	db := newCampaignPeriodUsageStoreDB(repo)
+
+	someErr := errors.New("some error")
+	repo.GetCampaignPeriodUsagesFunc = func(
+		ctx context.Context, keys []repository.CampaignPeriodUsageKey,
+	) ([]model.CampaignPeriodUsage, error) {
+		return nil, someErr
+	}
+
+	data, err := db.Get(newContext(), campaignPeriodUsageKey(11, "0987000111", "20220515"))()
+	assert.Equal(t, someErr, err)
+	assert.Nil(t, data)
+}
+
+func TestCampaignUsageKeys__Spaces_And_Colons__Encoded(t *testing.T) {
+	customerKey := campaignCustomerUsageKey(11, "0987 000:111")
+	periodKey := campaignPeriodUsageKey(11, "0987 000:111", "20220515:MERCHANT 01:TERMINAL:01")
+
+	for _, key := range []string{customerKey, periodKey} {
+		assert.False(t, strings.ContainsAny(key, " \t\r\n"), key)
+	}
+	assert.Equal(t, 4, len(strings.Split(customerKey, ":")))
+	assert.Equal(t, 5, len(strings.Split(periodKey, ":")))
+}
+
+func TestCampaignPeriodUsageStoreDB__Get__Phone_And_Term_Code_With_Colons(t *testing.T) {
+	repo := &repository.CampaignMock{}
+	db := newCampaignPeriodUsageStoreDB(repo)
+
+	usage := model.CampaignPeriodUsage{
+		CampaignID: 11,
+		Hash:       util.CampaignCustomerHash(11, "0987:000 111"),
+		Phone:      "0987:000 111",
+		TermCode:   "20220515:MERCHANT 01:TERMINAL:01",
+		UsageNum:   2,
+		ExpiredOn:  newTime("2022-05-16T00:00:00+07:00"),
+	}
+
+	repo.GetCampaignPeriodUsagesFunc = func(
+		ctx context.Context, keys []repository.CampaignPeriodUsageKey,
+	) ([]model.CampaignPeriodUsage, error) {
+		return []model.CampaignPeriodUsage{usage}, nil
+	}
+
+	data, err := db.Get(newContext(), campaignPeriodUsageKey(11, usage.Phone, usage.TermCode))()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, marshalCampaignPeriodUsage(usage), data)
+
+	assert.Equal(t, 1, len(repo.GetCampaignPeriodUsagesCalls()))
+	assert.Equal(t, []repository.CampaignPeriodUsageKey{
+		{
+			CampaignID: 11, Hash: util.CampaignCustomerHash(11, "0987:000 111"),
+			Phone: "0987:000 111", TermCode: "20220515:MERCHANT 01:TERMINAL:01",
+		},
+	}, repo.GetCampaignPeriodUsagesCalls()[0].Keys)
+}
+
+func TestCampaignCustomerUsageStoreDB__Get__Phone_With_Colon(t *testing.T) {
+	repo := &repository.CampaignMock{}
+	db := newCampaignCustomerUsageStoreDB(repo)
+
+	repo.GetCampaignCustomerUsagesFunc = func(
+		ctx context.Context, keys []repository.CampaignCustomerKey,
+	) ([]model.CampaignCustomerUsage, error) {
+		return nil, nil
+	}
+
+	_, err := db.Get(newContext(), campaignCustomerUsageKey(11, "0987:000111"))()
+	assert.Equal(t, dhash.ErrNotFound, err)
+
+	assert.Equal(t, []repository.CampaignCustomerKey{
+		{CampaignID: 11, Hash: util.CampaignCustomerHash(11, "0987:000111"), Phone: "0987:000111"},
+	}, repo.GetCampaignCustomerUsagesCalls()[0].Keys)
+}
+
+func TestCampaignCustomerUsageStoreDB__Get__Not_Encoded_Key(t *testing.T) {
+	repo := &repository.CampaignMock{}
+	db := newCampaignCustomerUsageStoreDB(repo)
+
+	data, err := db.Get(newContext(), "cp:cu:11:0987 000")()
+	assert.Equal(t, errors.New("invalid store key: cp:cu:11:0987 000"), err)
+	assert.Nil(t, data)
+}
+
+func TestCampaignMerchantHashDB__GetSizeLog(t *testing.T) {
+	repo := &repository.CampaignMock{}
+	db := newCampaignMerchantHashDB(repo)
+
+	repo.CountCampaignMerchantsFunc = func(ctx context.Context) (int64, error) {
+		return 15, nil
+	}
+
+	num, err := db.GetSizeLog(newContext())()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, uint64(4), num)
+
+	assert.Equal(t, 1, len(repo.CountCampaignMerchantsCalls()))
+}
+
+func TestCampaignMerchantHashDB__Select_Entries__Returns_Correct_Data(t *testing.T) {
+	repo := &repository.CampaignMock{}
+	db := newCampaignMerchantHashDB(repo)
+
+	merchant1 := model.CampaignMerchant{
+		CampaignID:   11,
+		Hash:         30,
+		MerchantCode: "MERCHANT01",
+		Status:       model.CampaignMerchantStatusActive,
+		StartTime:    newNullTime("2022-05-10T10:00:00+07:00"),
+		EndTime:      newNullTime("2022-05-20T10:00:00+07:00"),
+		AllTerminals: true,
+	}
+	merchant2 := model.CampaignMerchant{
+		CampaignID:   12,
+		Hash:         250,
+		MerchantCode: "MERCHANT02",
+		Status:       model.CampaignMerchantStatusInactive,
+	}
+
+	repo.SelectCampaignMerchantsFunc = func(
+		ctx context.Context, ranges []repository.HashRange,
+	) ([]model.CampaignMerchant, error) {
+		return []model.CampaignMerchant{merchant1, merchant2}, nil
+	}
+
+	fn1 := db.SelectEntries(newContext(), 20, newNullUint32(100))
+	fn2 := db.SelectEntries(newContext(), 220, dhash.NullUint32{})
+
+	entries1, err := fn1()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []dhash.Entry{
+		{
+			Hash: 30,
+			Data: marshalCampaignMerchant(merchant1),
+		},
+	}, entries1)
+
+	entries2, err := fn2()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []dhash.Entry{
+		{
+			Hash: 250,
+			Data: marshalCampaignMerchant(merchant2),
+		},
+	}, entries2)
+
+	assert.Equal(t, 1, len(repo.SelectCampaignMerchantsCalls()))
+
+	merchant, err := unmarshalCampaignMerchant(entries1[0].Data)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, merchant1, merchant)
+
+	merchant, err = unmarshalCampaignMerchant(entries2[0].Data)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, merchant2, merchant)
+}
+
+func TestCampaignTerminalHashDB__GetSizeLog(t *testing.T) {
+	repo := &repository.CampaignMock{}
+	db := newCampaignTerminalHashDB(repo)
+
+	repo.CountCampaignTerminalsFunc = func(ctx context.Context) (int64, error) {
+		return 65, nil
+	}
+
+	num, err := db.GetSizeLog(newContext())()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, uint64(7), num)
+
+	assert.Equal(t, 1, len(repo.CountCampaignTerminalsCalls()))
+}
+
+func TestCampaignTerminalHashDB__Select_Entries__Returns_Correct_Data(t *testing.T) {
+	repo := &repository.CampaignMock{}
+	db := newCampaignTerminalHashDB(repo)
+
+	terminal1 := model.CampaignTerminal{
+		CampaignID:   11,
+		Hash:         30,
+		MerchantCode: "MERCHANT01",
+		TerminalCode: "TERMINAL01",
+		Status:       model.CampaignTerminalStatusActive,
+		StartTime:    newNullTime("2022-05-10T10:00:00+07:00"),
+	}
+	terminal2 := model.CampaignTerminal{
+		CampaignID:   11,
+		Hash:         30,
+		MerchantCode: "MERCHANT01",
+		TerminalCode: "TERMINAL02",
+		Status:       model.CampaignTerminalStatusInactive,
+		EndTime:      newNullTime("2022-05-20T10:00:00+07:00"),
+	}
+
+	repo.SelectCampaignTerminalsFunc = func(
+		ctx context.Context, ranges []repository.HashRange,
+	) ([]model.CampaignTerminal, error) {
+		return []model.CampaignTerminal{terminal1, terminal2}, nil
+	}
+
+	entries, err := db.SelectEntries(newContext(), 20, newNullUint32(100))()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []dhash.Entry{
+		{
+			Hash: 30,
+			Data: marshalCampaignTerminal(terminal1),
+		},
+		{
+			Hash: 30,
+			Data: marshalCampaignTerminal(terminal2),
+		},
+	}, entries)
+
+	terminal, err := unmarshalCampaignTerminal(entries[0].Data)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, terminal1, terminal)
+
+	terminal, err = unmarshalCampaignTerminal(entries[1].Data)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, terminal2, terminal)
+}
+
+func TestCampaignBankHashDB__GetSizeLog(t *testing.T) {
+	repo := &repository.CampaignMock{}
+	db := newCampaignBankHashDB(repo)
+
+	repo.CountCampaignBanksFunc = func(ctx context.Context) (int64, error) {
+		return 4, nil
+	}
+
+	num, err := db.GetSizeLog(newContext())()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, uint64(2), num)
+
+	assert.Equal(t, 1, len(repo.CountCampaignBanksCalls()))
+}
+
+func TestCampaignBankHashDB__Select_Entries__Returns_Correct_Data(t *testing.T) {
+	repo := &repository.CampaignMock{}
+	db := newCampaignBankHashDB(repo)
+
+	bank1 := model.CampaignBank{
+		CampaignID: 11,
+		Hash:       30,
+		BankCode:   "BANK01",
+		Status:     model.CampaignBankStatusActive,
+		StartTime:  newNullTime("2022-05-10T10:00:00+07:00"),
+		EndTime:    newNullTime("2022-05-20T10:00:00+07:00"),
+	}
+	bank2 := model.CampaignBank{
+		CampaignID: 12,
+		Hash:       250,
+		BankCode:   "BANK02",
+		Status:     model.CampaignBankStatusInactive,
+	}
+
+	repo.SelectCampaignBanksFunc = func(
+		ctx context.Context, ranges []repository.HashRange,
+	) ([]model.CampaignBank, error) {
+		return []model.CampaignBank{bank1, bank2}, nil
+	}
+
+	fn1 := db.SelectEntries(newContext(), 20, newNullUint32(100))
+	fn2 := db.SelectEntries(newContext(), 220, dhash.NullUint32{})
+
+	entries1, err := fn1()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []dhash.Entry{
+		{
+			Hash: 30,
+			Data: marshalCampaignBank(bank1),
+		},
+	}, entries1)
+
+	entries2, err := fn2()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []dhash.Entry{
+		{
+			Hash: 250,
+			Data: marshalCampaignBank(bank2),
+		},
+	}, entries2)
+
+	assert.Equal(t, 1, len(repo.SelectCampaignBanksCalls()))
+
+	bank, err := unmarshalCampaignBank(entries1[0].Data)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, bank1, bank)
+
+	bank, err = unmarshalCampaignBank(entries2[0].Data)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, bank2, bank)
+}
+
+func TestCampaignCustomerHashDB__GetSizeLog(t *testing.T) {
+	repo := &repository.CampaignMock{}
+	db := newCampaignCustomerHashDB(repo)
+
+	repo.CountCampaignCustomersFunc = func(ctx context.Context) (int64, error) {
+		return 1000, nil
+	}
+
+	num, err := db.GetSizeLog(newContext())()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, uint64(10), num)
+
+	assert.Equal(t, 1, len(repo.CountCampaignCustomersCalls()))
+}
+
+func TestCampaignCustomerHashDB__Select_Entries__Returns_Correct_Data(t *testing.T) {
+	repo := &repository.CampaignMock{}
+	db := newCampaignCustomerHashDB(repo)
+
+	customer1 := model.CampaignCustomer{
+		CampaignID: 11,
+		Hash:       30,
+		Phone:      "0987000111",
+		Status:     model.CampaignCustomerStatusActive,
+		StartTime:  newNullTime("2022-05-10T10:00:00+07:00"),
+		EndTime:    newNullTime("2022-05-20T10:00:00+07:00"),
+	}
+	customer2 := model.CampaignCustomer{
+		CampaignID: 12,
+		Hash:       250,
+		Phone:      "0987000222",
+		Status:     model.CampaignCustomerStatusInactive,
+	}
+
+	repo.SelectCampaignCustomersFunc = func(
+		ctx context.Context, ranges []repository.HashRange,
+	) ([]model.CampaignCustomer, error) {
+		return []model.CampaignCustomer{customer1, customer2}, nil
+	}
+
+	fn1 := db.SelectEntries(newContext(), 20, newNullUint32(100))
+	fn2 := db.SelectEntries(newContext(), 220, dhash.NullUint32{})
+
+	entries1, err := fn1()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []dhash.Entry{
+		{
+			Hash: 30,
+			Data: marshalCampaignCustomer(customer1),
+		},
+	}, entries1)
+
+	entries2, err := fn2()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []dhash.Entry{
+		{
+			Hash: 250,
+			Data: marshalCampaignCustomer(customer2),
+		},
+	}, entries2)
+
+	assert.Equal(t, 1, len(repo.SelectCampaignCustomersCalls()))
+
+	customer, err := unmarshalCampaignCustomer(entries1[0].Data)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, customer1, customer)
+
+	customer, err = unmarshalCampaignCustomer(entries2[0].Data)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, customer2, customer)
 }
@@ -23,11 +23,14 @@ type repoIntegrationTest struct {
 	mem       *memtable.MemTable
 	repo      IRepository
 	blacklist repository.Blacklist
+	campaign  repository.Campaign
 }
 
 func newRepoIntegrationTest(tc *integration.TestCase) *repoIntegrationTest {
 	tc.Truncate("blacklist_config")
 	tc.Truncate("blacklist_customer")
+	tc.Truncate("blacklist_terminal")
+	tc.Truncate("campaign")
 
 	client := cacheclient.New("localhost:11211", 1)
 	err := client.UnsafeFlushAll()
@@ -38,11 +41,12 @@ func newRepoIntegrationTest(tc *integration.TestCase) *repoIntegrationTest {
 	txProvider := repository.NewProvider(tc.DB)
 
 	blacklistRepo := repository.NewBlacklist()
+	campaignRepo := repository.NewCampaign()
 
 	mem := memtable.New(100 * 1024)
 
 	dhashProvider := dhash.NewProvider(mem, client)
-	repoProvider := NewRepositoryProvider(dhashProvider, blacklistRepo)
+	repoProvider := NewRepositoryProvider(dhashProvider, blacklistRepo, campaignRepo, nil)
 	repo := repoProvider.NewRepo()
 
 	return &repoIntegrationTest{
@@ -51,6 +55,7 @@ func newRepoIntegrationTest(tc *integration.TestCase) *repoIntegrationTest {
 		mem:       mem,
 		repo:      repo,
 		blacklist: blacklistRepo,
+		campaign:  campaignRepo,
 	}
 }
 
@@ -233,3 +238,99 @@ func TestRepository_GetBlacklistMerchant__Integration(t *testing.T) {
 	assert.Equal(t, true, merchant1.Valid)
 	fmt.Println("Third Get:", time.Since(start))
 }
+
+func TestRepository_GetBlacklistTerminal__Integration(t *testing.T) {
+	tc := integration.NewTestCase()
+	r := newRepoIntegrationTest(tc)
+	defer r.finish()
+
+	terminal := model.BlacklistTerminal{
+		Hash:         util.BlacklistTerminalHash("MERCHANT01", "TERMINAL01"),
+		MerchantCode: "MERCHANT01",
+		TerminalCode: "TERMINAL01",
+		Status:       model.BlacklistTerminalStatusActive,
+		StartTime:    newNullTime("2022-05-08T10:00:00+07:00"),
+		EndTime:      newNullTime("2022-05-18T10:00:00+07:00"),
+	}
+
+	err := r.provider.Transact(newContext(), func(ctx context.Context) error {
+		return r.blacklist.UpsertBlacklistTerminals(ctx, []model.BlacklistTerminal{terminal})
+	})
+	assert.Equal(t, nil, err)
+
+	ctx := r.provider.Readonly(newContext())
+
+	fn1 := r.repo.GetBlacklistTerminal(ctx, "MERCHANT01", "TERMINAL01")
+	fn2 := r.repo.GetBlacklistTerminal(ctx, "MERCHANT01", "TERMINAL02")
+
+	terminal1, err := fn1()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, model.NullBlacklistTerminal{
+		Valid:    true,
+		Terminal: terminal,
+	}, terminal1)
+
+	terminal2, err := fn2()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, model.NullBlacklistTerminal{}, terminal2)
+
+	// Get Cache
+	pipe := r.client.Pipeline()
+	getOutput, err := pipe.Get("bl:tm:size-log")()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, dhash.GetOutput{
+		Found: true, Data: []byte("0"),
+	}, getOutput)
+}
+
+func TestRepository_GetCampaigns__Integration(t *testing.T) {
+	tc := integration.NewTestCase()
+	r := newRepoIntegrationTest(tc)
+	defer r.finish()
+
+	campaign := model.Campaign{
+		ID:     1,
+		Name:   "campaign 01",
+		Status: model.CampaignStatusActive,
+		Type:   model.CampaignTypeMerchant,
+
+		VoucherHash: util.HashFunc("VOUCHER01"),
+		VoucherCode: "VOUCHER01",
+		StartTime:   newTime("2022-05-08T10:00:00+07:00"),
+		EndTime:     newTime("2022-05-18T10:00:00+07:00"),
+
+		CustomerUsageMax: 3,
+		PeriodTermType:   model.PeriodTermTypeCampaign,
+	}
+
+	err := r.provider.Transact(newContext(), func(ctx context.Context) error {
+		return r.campaign.UpsertCampaign(ctx, campaign)
+	})
+	assert.Equal(t, nil, err)
+
+	ctx := r.provider.Readonly(newContext())
+
+	fn1 := r.repo.GetCampaigns(ctx, "VOUCHER01")
+	fn2 := r.repo.GetCampaigns(ctx, "VOUCHER02")
+
+	campaigns, err := fn1()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []model.Campaign{campaign}, campaigns)
+
+	campaigns, err = fn2()
+	assert.Equal(t, nil, err)
+	assert.Nil(t, campaigns)
+
+	// Get Second Times
+	campaigns, err = r.repo.GetCampaigns(ctx, "VOUCHER01")()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []model.Campaign{campaign}, campaigns)
+
+	// Get Cache
+	pipe := r.client.Pipeline()
+	getOutput, err := pipe.Get("campaign:size-log")()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, dhash.GetOutput{
+		Found: true, Data: []byte("0"),
+	}, getOutput)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/QuangTung97/promo-readonly/model"
 	"github.com/QuangTung97/promo-readonly/pkg/dhash"
 	"github.com/QuangTung97/promo-readonly/pkg/util"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"testing"
 	"time"
@@ -31,6 +32,14 @@ func newNullTime(s string) sql.NullTime {
 	}
 }
 
+func newDecimal(s string) decimal.Decimal {
+	d, err := decimal.NewFromString(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
 func newNullUint32(v uint32) dhash.NullUint32 {
 	return dhash.NullUint32{
 		Valid: true,
@@ -54,6 +63,16 @@ func TestLog2Int(t *testing.T) {
 
 type repoTest struct {
 	blacklistMerchantHash *dhash.HashMock
+	blacklistTerminalHash *dhash.HashMock
+	campaignHash          *dhash.HashMock
+	campaignBenefitStore  *dhash.StoreMock
+	campaignUsageStore    *dhash.StoreMock
+	customerUsageStore    *dhash.StoreMock
+	periodUsageStore      *dhash.StoreMock
+	campaignMerchantHash  *dhash.HashMock
+	campaignTerminalHash  *dhash.HashMock
+	campaignBankHash      *dhash.HashMock
+	campaignCustomerHash  *dhash.HashMock
 
 	repo IRepository
 }
@@ -61,30 +80,119 @@ type repoTest struct {
 func newRepoTest() *repoTest {
 	sess := &dhash.SessionMock{}
 	blacklistMerchantHash := &dhash.HashMock{}
+	blacklistTerminalHash := &dhash.HashMock{}
+	campaignHash := &dhash.HashMock{}
+	campaignBenefitStore := &dhash.StoreMock{}
+	campaignUsageStore := &dhash.StoreMock{}
+	customerUsageStore := &dhash.StoreMock{}
+	periodUsageStore := &dhash.StoreMock{}
+	campaignMerchantHash := &dhash.HashMock{}
+	campaignTerminalHash := &dhash.HashMock{}
+	campaignBankHash := &dhash.HashMock{}
+	campaignCustomerHash := &dhash.HashMock{}
 	return &repoTest{
 		blacklistMerchantHash: blacklistMerchantHash,
+		blacklistTerminalHash: blacklistTerminalHash,
+		campaignHash:          campaignHash,
+		campaignBenefitStore:  campaignBenefitStore,
+		campaignUsageStore:    campaignUsageStore,
+		customerUsageStore:    customerUsageStore,
+		periodUsageStore:      periodUsageStore,
+		campaignMerchantHash:  campaignMerchantHash,
+		campaignTerminalHash:  campaignTerminalHash,
+		campaignBankHash:      campaignBankHash,
+		campaignCustomerHash:  campaignCustomerHash,
+
+		repo: newRepository(sess, nil, blacklistMerchantHash, blacklistTerminalHash, campaignHash,
+			campaignBenefitStore, campaignUsageStore, customerUsageStore, periodUsageStore, campaignMerchantHash, campaignTerminalHash, campaignBankHash, campaignCustomerHash),
+	}
+}
 
-		repo: newRepository(sess, nil, blacklistMerchantHash),
+// stubSelectMultiEntries returns the same entries for every hash
+func stubSelectMultiEntries(h *dhash.HashMock, entries []dhash.Entry, err error) {
+	h.SelectMultiEntriesFunc = func(ctx context.Context, hashes []uint32) func() ([][]dhash.Entry, error) {
+		return func() ([][]dhash.Entry, error) {
+			if err != nil {
+				return nil, err
+			}
+			results := make([][]dhash.Entry, len(hashes))
+			for i := range hashes {
+				results[i] = entries
+			}
+			return results, nil
+		}
 	}
 }
 
 func (r *repoTest) stubMerchantSelectEntries(entries []dhash.Entry, err error) {
-	r.blacklistMerchantHash.SelectEntriesFunc = func(ctx context.Context, hash uint32) func() ([]dhash.Entry, error) {
-		return func() ([]dhash.Entry, error) {
-			return entries, err
+	stubSelectMultiEntries(r.blacklistMerchantHash, entries, err)
+}
+
+func (r *repoTest) stubTerminalSelectEntries(entries []dhash.Entry, err error) {
+	stubSelectMultiEntries(r.blacklistTerminalHash, entries, err)
+}
+
+func (r *repoTest) stubCampaignSelectEntries(entries []dhash.Entry, err error) {
+	stubSelectMultiEntries(r.campaignHash, entries, err)
+}
+
+func (r *repoTest) stubCampaignUsageStoreGet(data []byte, err error) {
+	r.campaignUsageStore.GetFunc = func(ctx context.Context, key string) func() ([]byte, error) {
+		return func() ([]byte, error) {
+			return data, err
+		}
+	}
+}
+
+func (r *repoTest) stubCustomerUsageStoreGet(data []byte, err error) {
+	r.customerUsageStore.GetFunc = func(ctx context.Context, key string) func() ([]byte, error) {
+		return func() ([]byte, error) {
+			return data, err
+		}
+	}
+}
+
+func (r *repoTest) stubPeriodUsageStoreGet(data []byte, err error) {
+	r.periodUsageStore.GetFunc = func(ctx context.Context, key string) func() ([]byte, error) {
+		return func() ([]byte, error) {
+			return data, err
 		}
 	}
 }
 
+func (r *repoTest) stubCampaignBenefitStoreGet(data []byte, err error) {
+	r.campaignBenefitStore.GetFunc = func(ctx context.Context, key string) func() ([]byte, error) {
+		return func() ([]byte, error) {
+			return data, err
+		}
+	}
+}
+
+func (r *repoTest) stubCampaignMerchantSelectEntries(entries []dhash.Entry, err error) {
+	stubSelectMultiEntries(r.campaignMerchantHash, entries, err)
+}
+
+func (r *repoTest) stubCampaignTerminalSelectEntries(entries []dhash.Entry, err error) {
+	stubSelectMultiEntries(r.campaignTerminalHash, entries, err)
+}
+
+func (r *repoTest) stubCampaignBankSelectEntries(entries []dhash.Entry, err error) {
+	stubSelectMultiEntries(r.campaignBankHash, entries, err)
+}
+
+func (r *repoTest) stubCampaignCustomerSelectEntries(entries []dhash.Entry, err error) {
+	stubSelectMultiEntries(r.campaignCustomerHash, entries, err)
+}
+
 func TestRepository_GetBlacklistMerchant__Call_Correct_Select_Entries(t *testing.T) {
 	r := newRepoTest()
 
 	r.stubMerchantSelectEntries(nil, nil)
 
-	r.repo.GetBlacklistMerchant(newContext(), "MERCHANT01")
+	_, _ = r.repo.GetBlacklistMerchant(newContext(), "MERCHANT01")()
 
-	assert.Equal(t, 1, len(r.blacklistMerchantHash.SelectEntriesCalls()))
-	assert.Equal(t, util.HashFunc("MERCHANT01"), r.blacklistMerchantHash.SelectEntriesCalls()[0].Hash)
+	assert.Equal(t, 1, len(r.blacklistMerchantHash.SelectMultiEntriesCalls()))
+	assert.Equal(t, []uint32{util.HashFunc("MERCHANT01")}, r.blacklistMerchantHash.SelectMultiEntriesCalls()[0].Hashes)
 }
 
 func TestRepository_GetBlacklistMerchant__Select_Entries__Returns_Error(t *testing.T) {
@@ -187,3 +295,797 @@ func TestRepository_GetBlacklistMerchant__Select_Entries__Code_Mismatch(t *testi
 	assert.Equal(t, nil, err)
 	assert.Equal(t, model.NullBlacklistMerchant{}, nullMerchant)
 }
+
+func TestRepository_GetBlacklistMerchant__Multiple_Calls__Select_Multi_Entries_Once(t *testing.T) {
+	r := newRepoTest()
+
+	merchant1 := model.BlacklistMerchant{
+		Hash:         util.HashFunc("MERCHANT01"),
+		MerchantCode: "MERCHANT01",
+		Status:       model.BlacklistMerchantStatusActive,
+	}
+	merchant2 := model.BlacklistMerchant{
+		Hash:         util.HashFunc("MERCHANT02"),
+		MerchantCode: "MERCHANT02",
+		Status:       model.BlacklistMerchantStatusActive,
+	}
+	r.blacklistMerchantHash.SelectMultiEntriesFunc = func(
+		ctx context.Context, hashes []uint32,
+	) func() ([][]dhash.Entry, error) {
+		return func() ([][]dhash.Entry, error) {
+			return [][]dhash.Entry{
+				{{Hash: merchant1.Hash, Data: marshalBlacklistMerchant(merchant1)}},
+				nil,
+				{{Hash: merchant2.Hash, Data: marshalBlacklistMerchant(merchant2)}},
+			}, nil
+		}
+	}
+	r.stubTerminalSelectEntries(nil, nil)
+
+	ctx := newContext()
+	fn1 := r.repo.GetBlacklistMerchant(ctx, "MERCHANT01")
+	fn2 := r.repo.GetBlacklistMerchant(ctx, "MERCHANT03")
+	fn3 := r.repo.GetBlacklistMerchant(ctx, "MERCHANT02")
+	fn4 := r.repo.GetBlacklistTerminal(ctx, "MERCHANT01", "TERMINAL01")
+
+	assert.Equal(t, 0, len(r.blacklistMerchantHash.SelectMultiEntriesCalls()))
+
+	result, err := fn3()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, model.NullBlacklistMerchant{Valid: true, Merchant: merchant2}, result)
+
+	result, err = fn1()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, model.NullBlacklistMerchant{Valid: true, Merchant: merchant1}, result)
+
+	result, err = fn2()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, model.NullBlacklistMerchant{}, result)
+
+	_, err = fn4()
+	assert.Equal(t, nil, err)
+
+	assert.Equal(t, 1, len(r.blacklistMerchantHash.SelectMultiEntriesCalls()))
+	assert.Equal(t, []uint32{
+		util.HashFunc("MERCHANT01"),
+		util.HashFunc("MERCHANT03"),
+		util.HashFunc("MERCHANT02"),
+	}, r.blacklistMerchantHash.SelectMultiEntriesCalls()[0].Hashes)
+
+	assert.Equal(t, 1, len(r.blacklistTerminalHash.SelectMultiEntriesCalls()))
+	assert.Equal(t, []uint32{
+		util.BlacklistTerminalHash("MERCHANT01", "TERMINAL01"),
+	}, r.blacklistTerminalHash.SelectMultiEntriesCalls()[0].Hashes)
+}
+
+func TestRepository_GetBlacklistMerchant__Call_After_Batch_Started__Use_New_Batch(t *testing.T) {
+	r := newRepoTest()
+
+	r.stubMerchantSelectEntries(nil, nil)
+
+	ctx := newContext()
+	_, _ = r.repo.GetBlacklistMerchant(ctx, "MERCHANT01")()
+	_, _ = r.repo.GetBlacklistMerchant(ctx, "MERCHANT02")()
+
+	assert.Equal(t, 2, len(r.blacklistMerchantHash.SelectMultiEntriesCalls()))
+	assert.Equal(t, []uint32{util.HashFunc("MERCHANT01")}, r.blacklistMerchantHash.SelectMultiEntriesCalls()[0].Hashes)
+	assert.Equal(t, []uint32{util.HashFunc("MERCHANT02")}, r.blacklistMerchantHash.SelectMultiEntriesCalls()[1].Hashes)
+}
+
+func TestRepository_GetBlacklistMerchant__Batch_Error__Returns_Error_For_All_Calls(t *testing.T) {
+	r := newRepoTest()
+
+	someErr := errors.New("some error")
+	r.stubMerchantSelectEntries(nil, someErr)
+
+	ctx := newContext()
+	fn1 := r.repo.GetBlacklistMerchant(ctx, "MERCHANT01")
+	fn2 := r.repo.GetBlacklistMerchant(ctx, "MERCHANT02")
+
+	_, err := fn1()
+	assert.Equal(t, someErr, err)
+	_, err = fn2()
+	assert.Equal(t, someErr, err)
+
+	assert.Equal(t, 1, len(r.blacklistMerchantHash.SelectMultiEntriesCalls()))
+}
+
+func TestRepository_GetBlacklistTerminal__Call_Correct_Select_Entries(t *testing.T) {
+	r := newRepoTest()
+
+	r.stubTerminalSelectEntries(nil, nil)
+
+	_, _ = r.repo.GetBlacklistTerminal(newContext(), "MERCHANT01", "TERMINAL01")()
+
+	assert.Equal(t, 1, len(r.blacklistTerminalHash.SelectMultiEntriesCalls()))
+	assert.Equal(t, []uint32{util.BlacklistTerminalHash("MERCHANT01", "TERMINAL01")}, r.blacklistTerminalHash.SelectMultiEntriesCalls()[0].Hashes)
+}
+
+func TestRepository_GetBlacklistTerminal__Select_Entries__Returns_Error(t *testing.T) {
+	r := newRepoTest()
+
+	someErr := errors.New("some error")
+	r.stubTerminalSelectEntries(nil, someErr)
+
+	terminal, err := r.repo.GetBlacklistTerminal(newContext(), "MERCHANT01", "TERMINAL01")()
+	assert.Equal(t, someErr, err)
+	assert.Equal(t, model.NullBlacklistTerminal{}, terminal)
+}
+
+func TestRepository_GetBlacklistTerminal__Select_Entries__Returns_Empty(t *testing.T) {
+	r := newRepoTest()
+
+	r.stubTerminalSelectEntries(nil, nil)
+
+	terminal, err := r.repo.GetBlacklistTerminal(newContext(), "MERCHANT01", "TERMINAL01")()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, model.NullBlacklistTerminal{}, terminal)
+}
+
+func TestRepository_GetBlacklistTerminal__Select_Entries__Returns_OK(t *testing.T) {
+	r := newRepoTest()
+
+	hash := util.BlacklistTerminalHash("MERCHANT01", "TERMINAL01")
+
+	terminal := model.BlacklistTerminal{
+		Hash:         hash,
+		MerchantCode: "MERCHANT01",
+		TerminalCode: "TERMINAL01",
+		Status:       model.BlacklistTerminalStatusActive,
+	}
+
+	r.stubTerminalSelectEntries([]dhash.Entry{
+		{
+			Hash: hash,
+			Data: marshalBlacklistTerminal(terminal),
+		},
+	}, nil)
+
+	nullTerminal, err := r.repo.GetBlacklistTerminal(newContext(), "MERCHANT01", "TERMINAL01")()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, model.NullBlacklistTerminal{
+		Valid:    true,
+		Terminal: terminal,
+	}, nullTerminal)
+}
+
+func TestRepository_GetBlacklistTerminal__Select_Entries__Hash_Mismatch(t *testing.T) {
+	r := newRepoTest()
+
+	hash := util.BlacklistTerminalHash("MERCHANT01", "TERMINAL01")
+
+	terminal := model.BlacklistTerminal{
+		Hash:         hash,
+		MerchantCode: "MERCHANT01",
+		TerminalCode: "TERMINAL01",
+		Status:       model.BlacklistTerminalStatusActive,
+	}
+
+	r.stubTerminalSelectEntries([]dhash.Entry{
+		{
+			Hash: hash + 1,
+			Data: marshalBlacklistTerminal(terminal),
+		},
+	}, nil)
+
+	nullTerminal, err := r.repo.GetBlacklistTerminal(newContext(), "MERCHANT01", "TERMINAL01")()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, model.NullBlacklistTerminal{}, nullTerminal)
+}
+
+func TestRepository_GetBlacklistTerminal__Select_Entries__Code_Mismatch(t *testing.T) {
+	r := newRepoTest()
+
+	hash := util.BlacklistTerminalHash("MERCHANT01", "TERMINAL01")
+
+	terminal := model.BlacklistTerminal{
+		Hash:         hash,
+		MerchantCode: "MERCHANT01",
+		TerminalCode: "TERMINAL02",
+		Status:       model.BlacklistTerminalStatusActive,
+	}
+
+	r.stubTerminalSelectEntries([]dhash.Entry{
+		{
+			Hash: hash,
+			Data: marshalBlacklistTerminal(terminal),
+		},
+	}, nil)
+
+	nullTerminal, err := r.repo.GetBlacklistTerminal(newContext(), "MERCHANT01", "TERMINAL01")()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, model.NullBlacklistTerminal{}, nullTerminal)
+}
+
+func TestRepository_GetCampaigns__Call_Correct_Select_Entries(t *testing.T) {
+	r := newRepoTest()
+
+	r.stubCampaignSelectEntries(nil, nil)
+
+	_, _ = r.repo.GetCampaigns(newContext(), "VOUCHER01")()
+
+	assert.Equal(t, 1, len(r.campaignHash.SelectMultiEntriesCalls()))
+	assert.Equal(t, []uint32{util.HashFunc("VOUCHER01")}, r.campaignHash.SelectMultiEntriesCalls()[0].Hashes)
+}
+
+func TestRepository_GetCampaigns__Select_Entries__Returns_Error(t *testing.T) {
+	r := newRepoTest()
+
+	someErr := errors.New("some error")
+	r.stubCampaignSelectEntries(nil, someErr)
+
+	campaigns, err := r.repo.GetCampaigns(newContext(), "VOUCHER01")()
+	assert.Equal(t, someErr, err)
+	assert.Nil(t, campaigns)
+}
+
+func TestRepository_GetCampaigns__Select_Entries__Returns_Matched_Campaigns(t *testing.T) {
+	r := newRepoTest()
+
+	voucherCode := "VOUCHER01"
+	hash := util.HashFunc(voucherCode)
+
+	campaign1 := model.Campaign{
+		ID:          11,
+		Name:        "campaign 01",
+		Status:      model.CampaignStatusActive,
+		Type:        model.CampaignTypeMerchant,
+		VoucherHash: hash,
+		VoucherCode: voucherCode,
+		StartTime:   newTime("2022-05-10T10:00:00+07:00"),
+		EndTime:     newTime("2022-05-20T10:00:00+07:00"),
+	}
+	campaign2 := campaign1
+	campaign2.ID = 12
+	campaign2.VoucherCode = "VOUCHER02"
+
+	campaign3 := campaign1
+	campaign3.ID = 13
+
+	r.stubCampaignSelectEntries([]dhash.Entry{
+		{
+			Hash: hash,
+			Data: marshalCampaign(campaign1),
+		},
+		{
+			Hash: hash,
+			Data: marshalCampaign(campaign2),
+		},
+		{
+			Hash: hash + 1,
+			Data: marshalCampaign(campaign3),
+		},
+	}, nil)
+
+	campaigns, err := r.repo.GetCampaigns(newContext(), voucherCode)()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []model.Campaign{campaign1}, campaigns)
+}
+
+func TestRepository_GetCampaignBenefits__Call_Store_Get(t *testing.T) {
+	r := newRepoTest()
+
+	r.stubCampaignBenefitStoreGet(nil, nil)
+
+	r.repo.GetCampaignBenefits(newContext(), 123)
+
+	assert.Equal(t, 1, len(r.campaignBenefitStore.GetCalls()))
+	assert.Equal(t, "cp:bnf:123", r.campaignBenefitStore.GetCalls()[0].Key)
+}
+
+func TestRepository_GetCampaignBenefits__Store_Get_Returns_Error(t *testing.T) {
+	r := newRepoTest()
+
+	someErr := errors.New("some error")
+	r.stubCampaignBenefitStoreGet(nil, someErr)
+
+	benefits, err := r.repo.GetCampaignBenefits(newContext(), 123)()
+	assert.Equal(t, someErr, err)
+	assert.Nil(t, benefits)
+}
+
+func TestRepository_GetCampaignBenefits__Store_Get_Returns_Empty(t *testing.T) {
+	r := newRepoTest()
+
+	r.stubCampaignBenefitStoreGet(nil, nil)
+
+	benefits, err := r.repo.GetCampaignBenefits(newContext(), 123)()
+	assert.Equal(t, nil, err)
+	assert.Nil(t, benefits)
+}
+
+func TestRepository_GetCampaignBenefits__Store_Get_Returns_OK(t *testing.T) {
+	r := newRepoTest()
+
+	benefits := []model.CampaignBenefit{
+		{
+			ID:         1,
+			CampaignID: 123,
+			StartTime:  newTime("2022-05-10T10:00:00+07:00"),
+			EndTime:    newTime("2022-05-20T10:00:00+07:00"),
+
+			TxnMinAmount:      newDecimal("50000.00"),
+			DiscountPercent:   newDecimal("10.00"),
+			MaxDiscountAmount: newDecimal("20000.00"),
+		},
+	}
+	r.stubCampaignBenefitStoreGet(marshalCampaignBenefits(benefits), nil)
+
+	result, err := r.repo.GetCampaignBenefits(newContext(), 123)()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, benefits, result)
+}
+
+func TestRepository_GetCampaignUsage__Call_Store_Get(t *testing.T) {
+	r := newRepoTest()
+
+	r.stubCampaignUsageStoreGet(nil, nil)
+
+	r.repo.GetCampaignUsage(newContext(), 123)
+
+	assert.Equal(t, 1, len(r.campaignUsageStore.GetCalls()))
+	assert.Equal(t, "cp:usg:123", r.campaignUsageStore.GetCalls()[0].Key)
+}
+
+func TestRepository_GetCampaignUsage__Store_Get_Returns_Error(t *testing.T) {
+	r := newRepoTest()
+
+	someErr := errors.New("some error")
+	r.stubCampaignUsageStoreGet(nil, someErr)
+
+	usage, err := r.repo.GetCampaignUsage(newContext(), 123)()
+	assert.Equal(t, someErr, err)
+	assert.Equal(t, model.CampaignUsage{}, usage)
+}
+
+func TestRepository_GetCampaignUsage__Store_Get_Returns_OK(t *testing.T) {
+	r := newRepoTest()
+
+	usage := model.CampaignUsage{
+		CampaignID:   123,
+		BudgetUsed:   newDecimal("150000.50"),
+		CampaignUsed: 12,
+	}
+	r.stubCampaignUsageStoreGet(marshalCampaignUsage(usage), nil)
+
+	result, err := r.repo.GetCampaignUsage(newContext(), 123)()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, usage, result)
+}
+
+func TestRepository_GetCampaignUsage__Store_Get_Not_Found__Returns_Zero_Usage(t *testing.T) {
+	r := newRepoTest()
+
+	r.stubCampaignUsageStoreGet(nil, dhash.ErrNotFound)
+
+	result, err := r.repo.GetCampaignUsage(newContext(), 123)()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, model.CampaignUsage{CampaignID: 123, BudgetUsed: decimal.Zero}, result)
+}
+
+func TestRepository_GetCampaignCustomerUsage__Call_Store_Get(t *testing.T) {
+	r := newRepoTest()
+
+	r.stubCustomerUsageStoreGet(nil, nil)
+
+	r.repo.GetCampaignCustomerUsage(newContext(), 123, "0987000111")
+
+	assert.Equal(t, 1, len(r.customerUsageStore.GetCalls()))
+	assert.Equal(t, "cp:cu:123:MDk4NzAwMDExMQ", r.customerUsageStore.GetCalls()[0].Key)
+}
+
+func TestRepository_GetCampaignCustomerUsage__Store_Get_Returns_OK(t *testing.T) {
+	r := newRepoTest()
+
+	usage := model.CampaignCustomerUsage{
+		CampaignID: 123,
+		Hash:       util.CampaignCustomerHash(123, "0987000111"),
+		Phone:      "0987000111",
+		UsageNum:   3,
+	}
+	r.stubCustomerUsageStoreGet(marshalCampaignCustomerUsage(usage), nil)
+
+	result, err := r.repo.GetCampaignCustomerUsage(newContext(), 123, "0987000111")()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, usage, result)
+}
+
+func TestRepository_GetCampaignCustomerUsage__Store_Get_Not_Found__Returns_Zero_Usage(t *testing.T) {
+	r := newRepoTest()
+
+	r.stubCustomerUsageStoreGet(nil, dhash.ErrNotFound)
+
+	result, err := r.repo.GetCampaignCustomerUsage(newContext(), 123, "0987000111")()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, model.CampaignCustomerUsage{
+		CampaignID: 123,
+		Hash:       util.CampaignCustomerHash(123, "0987000111"),
+		Phone:      "0987000111",
+	}, result)
+}
+
+func TestRepository_GetCampaignPeriodUsage__Call_Store_Get(t *testing.T) {
+	r := newRepoTest()
+
+	r.stubPeriodUsageStoreGet(nil, nil)
+
+	r.repo.GetCampaignPeriodUsage(newContext(), 123, "0987000111", "20220515:MERCHANT01")
+
+	assert.Equal(t, 1, len(r.periodUsageStore.GetCalls()))
+	assert.Equal(t, "cp:pu:123:MDk4NzAwMDExMQ:MjAyMjA1MTU6TUVSQ0hBTlQwMQ", r.periodUsageStore.GetCalls()[0].Key)
+}
+
+func TestRepository_GetCampaignPeriodUsage__Store_Get_Returns_Error(t *testing.T) {
+	r := newRepoTest()
+
+	someErr := errors.New("some error")
+	r.stubPeriodUsageStoreGet(nil, someErr)
+
+	usage, err := r.repo.GetCampaignPeriodUsage(newContext(), 123, "0987000111", "20220515")()
+	assert.Equal(t, someErr, err)
+	assert.Equal(t, model.CampaignPeriodUsage{}, usage)
+}
+
+func TestRepository_GetCampaignPeriodUsage__Store_Get_Returns_OK(t *testing.T) {
+	r := newRepoTest()
+
+	usage := model.CampaignPeriodUsage{
+		CampaignID: 123,
+		Hash:       util.CampaignCustomerHash(123, "0987000111"),
+		Phone:      "0987000111",
+		TermCode:   "20220515",
+		UsageNum:   2,
+		ExpiredOn:  newTime("2022-05-16T00:00:00+07:00").UTC(),
+	}
+	r.stubPeriodUsageStoreGet(marshalCampaignPeriodUsage(usage), nil)
+
+	result, err := r.repo.GetCampaignPeriodUsage(newContext(), 123, "0987000111", "20220515")()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, usage, result)
+}
+
+func TestRepository_GetCampaignMerchant__Call_Correct_Select_Entries(t *testing.T) {
+	r := newRepoTest()
+
+	r.stubCampaignMerchantSelectEntries(nil, nil)
+
+	_, _ = r.repo.GetCampaignMerchant(newContext(), 11, "MERCHANT01")()
+
+	assert.Equal(t, 1, len(r.campaignMerchantHash.SelectMultiEntriesCalls()))
+	assert.Equal(t, []uint32{util.CampaignMerchantHash(11, "MERCHANT01")}, r.campaignMerchantHash.SelectMultiEntriesCalls()[0].Hashes)
+}
+
+func TestRepository_GetCampaignMerchant__Select_Entries__Returns_Error(t *testing.T) {
+	r := newRepoTest()
+
+	someErr := errors.New("some error")
+	r.stubCampaignMerchantSelectEntries(nil, someErr)
+
+	merchant, err := r.repo.GetCampaignMerchant(newContext(), 11, "MERCHANT01")()
+	assert.Equal(t, someErr, err)
+	assert.Equal(t, model.NullCampaignMerchant{}, merchant)
+}
+
+func TestRepository_GetCampaignMerchant__Select_Entries__Returns_Matched(t *testing.T) {
+	r := newRepoTest()
+
+	hash := util.CampaignMerchantHash(11, "MERCHANT01")
+
+	merchant := model.CampaignMerchant{
+		CampaignID:   11,
+		Hash:         hash,
+		MerchantCode: "MERCHANT01",
+		Status:       model.CampaignMerchantStatusActive,
+		StartTime:    newNullTime("2022-05-10T10:00:00+07:00"),
+		AllTerminals: true,
+	}
+	otherCampaign := merchant
+	otherCampaign.CampaignID = 12
+
+	otherCode := merchant
+	otherCode.MerchantCode = "MERCHANT02"
+
+	r.stubCampaignMerchantSelectEntries([]dhash.Entry{
+		{
+			Hash: hash + 1,
+			Data: marshalCampaignMerchant(merchant),
+		},
+		{
+			Hash: hash,
+			Data: marshalCampaignMerchant(otherCampaign),
+		},
+		{
+			Hash: hash,
+			Data: marshalCampaignMerchant(otherCode),
+		},
+		{
+			Hash: hash,
+			Data: marshalCampaignMerchant(merchant),
+		},
+	}, nil)
+
+	result, err := r.repo.GetCampaignMerchant(newContext(), 11, "MERCHANT01")()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, model.NullCampaignMerchant{
+		Valid:    true,
+		Merchant: merchant,
+	}, result)
+}
+
+func TestRepository_GetCampaignMerchant__Select_Entries__Not_Found(t *testing.T) {
+	r := newRepoTest()
+
+	hash := util.CampaignMerchantHash(11, "MERCHANT01")
+
+	r.stubCampaignMerchantSelectEntries([]dhash.Entry{
+		{
+			Hash: hash,
+			Data: marshalCampaignMerchant(model.CampaignMerchant{
+				CampaignID:   11,
+				Hash:         hash,
+				MerchantCode: "MERCHANT02",
+			}),
+		},
+	}, nil)
+
+	result, err := r.repo.GetCampaignMerchant(newContext(), 11, "MERCHANT01")()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, model.NullCampaignMerchant{}, result)
+}
+
+func TestRepository_GetCampaignTerminal__Call_Correct_Select_Entries(t *testing.T) {
+	r := newRepoTest()
+
+	r.stubCampaignTerminalSelectEntries(nil, nil)
+
+	_, _ = r.repo.GetCampaignTerminal(newContext(), 11, "MERCHANT01", "TERMINAL01")()
+
+	assert.Equal(t, 1, len(r.campaignTerminalHash.SelectMultiEntriesCalls()))
+	assert.Equal(t, []uint32{util.CampaignMerchantHash(11, "MERCHANT01")}, r.campaignTerminalHash.SelectMultiEntriesCalls()[0].Hashes)
+}
+
+func TestRepository_GetCampaignTerminal__Select_Entries__Returns_Error(t *testing.T) {
+	r := newRepoTest()
+
+	someErr := errors.New("some error")
+	r.stubCampaignTerminalSelectEntries(nil, someErr)
+
+	terminal, err := r.repo.GetCampaignTerminal(newContext(), 11, "MERCHANT01", "TERMINAL01")()
+	assert.Equal(t, someErr, err)
+	assert.Equal(t, model.NullCampaignTerminal{}, terminal)
+}
+
+func TestRepository_GetCampaignTerminal__Select_Entries__Returns_Matched(t *testing.T) {
+	r := newRepoTest()
+
+	hash := util.CampaignMerchantHash(11, "MERCHANT01")
+
+	terminal := model.CampaignTerminal{
+		CampaignID:   11,
+		Hash:         hash,
+		MerchantCode: "MERCHANT01",
+		TerminalCode: "TERMINAL01",
+		Status:       model.CampaignTerminalStatusActive,
+		EndTime:      newNullTime("2022-05-20T10:00:00+07:00"),
+	}
+	otherTerminal := terminal
+	otherTerminal.TerminalCode = "TERMINAL02"
+
+	r.stubCampaignTerminalSelectEntries([]dhash.Entry{
+		{
+			Hash: hash,
+			Data: marshalCampaignTerminal(otherTerminal),
+		},
+		{
+			Hash: hash,
+			Data: marshalCampaignTerminal(terminal),
+		},
+	}, nil)
+
+	result, err := r.repo.GetCampaignTerminal(newContext(), 11, "MERCHANT01", "TERMINAL01")()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, model.NullCampaignTerminal{
+		Valid:    true,
+		Terminal: terminal,
+	}, result)
+
+	result, err = r.repo.GetCampaignTerminal(newContext(), 11, "MERCHANT01", "TERMINAL03")()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, model.NullCampaignTerminal{}, result)
+}
+
+func TestRepository_GetCampaignBank__Call_Correct_Select_Entries(t *testing.T) {
+	r := newRepoTest()
+
+	r.stubCampaignBankSelectEntries(nil, nil)
+
+	_, _ = r.repo.GetCampaignBank(newContext(), 11, "BANK01")()
+
+	assert.Equal(t, 1, len(r.campaignBankHash.SelectMultiEntriesCalls()))
+	assert.Equal(t, []uint32{util.CampaignBankHash(11, "BANK01")}, r.campaignBankHash.SelectMultiEntriesCalls()[0].Hashes)
+}
+
+func TestRepository_GetCampaignBank__Select_Entries__Returns_Error(t *testing.T) {
+	r := newRepoTest()
+
+	someErr := errors.New("some error")
+	r.stubCampaignBankSelectEntries(nil, someErr)
+
+	bank, err := r.repo.GetCampaignBank(newContext(), 11, "BANK01")()
+	assert.Equal(t, someErr, err)
+	assert.Equal(t, model.NullCampaignBank{}, bank)
+}
+
+func TestRepository_GetCampaignBank__Select_Entries__Returns_Matched(t *testing.T) {
+	r := newRepoTest()
+
+	hash := util.CampaignBankHash(11, "BANK01")
+
+	bank := model.CampaignBank{
+		CampaignID: 11,
+		Hash:       hash,
+		BankCode:   "BANK01",
+		Status:     model.CampaignBankStatusActive,
+		StartTime:  newNullTime("2022-05-10T10:00:00+07:00"),
+	}
+	otherCampaign := bank
+	otherCampaign.CampaignID = 12
+
+	otherCode := bank
+	otherCode.BankCode = "BANK02"
+
+	r.stubCampaignBankSelectEntries([]dhash.Entry{
+		{
+			Hash: hash + 1,
+			Data: marshalCampaignBank(bank),
+		},
+		{
+			Hash: hash,
+			Data: marshalCampaignBank(otherCampaign),
+		},
+		{
+			Hash: hash,
+			Data: marshalCampaignBank(otherCode),
+		},
+		{
+			Hash: hash,
+			Data: marshalCampaignBank(bank),
+		},
+	}, nil)
+
+	result, err := r.repo.GetCampaignBank(newContext(), 11, "BANK01")()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, model.NullCampaignBank{
+		Valid: true,
+		Bank:  bank,
+	}, result)
+
+	result, err = r.repo.GetCampaignBank(newContext(), 11, "BANK03")()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, model.NullCampaignBank{}, result)
+}
+
+func TestRepository_GetCampaignCustomer__Call_Correct_Select_Entries(t *testing.T) {
+	r := newRepoTest()
+
+	r.stubCampaignCustomerSelectEntries(nil, nil)
+
+	_, _ = r.repo.GetCampaignCustomer(newContext(), 11, "0987000111")()
+
+	assert.Equal(t, 1, len(r.campaignCustomerHash.SelectMultiEntriesCalls()))
+	assert.Equal(t, []uint32{util.CampaignCustomerHash(11, "0987000111")}, r.campaignCustomerHash.SelectMultiEntriesCalls()[0].Hashes)
+}
+
+func TestRepository_GetCampaignCustomer__Select_Entries__Returns_Error(t *testing.T) {
+	r := newRepoTest()
+
+	someErr := errors.New("some error")
+	r.stubCampaignCustomerSelectEntries(nil, someErr)
+
+	customer, err := r.repo.GetCampaignCustomer(newContext(), 11, "0987000111")()
+	assert.Equal(t, someErr, err)
+	assert.Equal(t, model.NullCampaignCustomer{}, customer)
+}
+
+func TestRepository_GetCampaignCustomer__Select_Entries__Returns_Matched(t *testing.T) {
+	r := newRepoTest()
+
+	hash := util.CampaignCustomerHash(11, "0987000111")
+
+	customer := model.CampaignCustomer{
+		CampaignID: 11,
+		Hash:       hash,
+		Phone:      "0987000111",
+		Status:     model.CampaignCustomerStatusActive,
+		EndTime:    newNullTime("2022-05-20T10:00:00+07:00"),
+	}
+	otherCampaign := customer
+	otherCampaign.CampaignID = 12
+
+	otherPhone := customer
+	otherPhone.Phone = "0987000222"
+
+	r.stubCampaignCustomerSelectEntries([]dhash.Entry{
+		{
+			Hash: hash + 1,
+			Data: marshalCampaignCustomer(customer),
+		},
+		{
+			Hash: hash,
+			Data: marshalCampaignCustomer(otherCampaign),
+		},
+		{
+			Hash: hash,
+			Data: marshalCampaignCustomer(otherPhone),
+		},
+		{
+			Hash: hash,
+			Data: marshalCampaignCustomer(customer),
+		},
+	}, nil)
+
+	result, err := r.repo.GetCampaignCustomer(newContext(), 11, "0987000111")()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, model.NullCampaignCustomer{
+		Valid:    true,
+		Customer: customer,
+	}, result)
+
+	result, err = r.repo.GetCampaignCustomer(newContext(), 11, "0987000333")()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, model.NullCampaignCustomer{}, result)
+}
+
+func TestRepository_GetCampaignPeriodUsage__Store_Get_Not_Found__Returns_Zero_Usage(t *testing.T) {
+	r := newRepoTest()
+
+	r.stubPeriodUsageStoreGet(nil, dhash.ErrNotFound)
+
+	result, err := r.repo.GetCampaignPeriodUsage(newContext(), 123, "0987000111", "20220515")()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, model.CampaignPeriodUsage{
+		CampaignID: 123,
+		Hash:       util.CampaignCustomerHash(123, "0987000111"),
+		Phone:      "0987000111",
+		TermCode:   "20220515",
+	}, result)
+}
+
+func TestDBRepository__Multiple_Rounds__Get_Blacklist_Customers_And_Merchants_Once(t *testing.T) {
+	blacklistRepo := newEmptyBlacklistRepo()
+	campaignRepo := newCampaignRepoWithRows(campaignRows{
+		campaigns: []model.Campaign{newCheckTestCampaign()},
+		benefits:  []model.CampaignBenefit{newCheckTestBenefit()},
+	})
+	campaignRepo.GetCampaignUsagesFunc = func(ctx context.Context, campaignIDs []int64) ([]model.CampaignUsage, error) {
+		return nil, nil
+	}
+
+	r := NewDBRepoProvider(blacklistRepo, campaignRepo).NewRepo()
+	ctx := newContext()
+
+	customerFn := r.GetBlacklistCustomer(ctx, "0987000111")
+	merchantFn := r.GetBlacklistMerchant(ctx, "MERCHANT01")
+	campaignsFn := r.GetCampaigns(ctx, "VOUCHER01")
+
+	_, err := customerFn()
+	assert.Equal(t, nil, err)
+	_, err = merchantFn()
+	assert.Equal(t, nil, err)
+	campaigns, err := campaignsFn()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, len(campaigns))
+
+	benefits, err := r.GetCampaignBenefits(ctx, 11)()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, len(benefits))
+
+	_, err = r.GetCampaignUsage(ctx, 11)()
+	assert.Equal(t, nil, err)
+
+	assert.Equal(t, 1, len(blacklistRepo.GetBlacklistCustomersCalls()))
+	assert.Equal(t, 1, len(blacklistRepo.GetBlacklistMerchantsCalls()))
+	assert.Equal(t, 1, len(campaignRepo.GetCampaignsByVouchersCalls()))
+	assert.Equal(t, 1, len(campaignRepo.GetCampaignBenefitsCalls()))
+	assert.Equal(t, 1, len(campaignRepo.GetCampaignUsagesCalls()))
+}
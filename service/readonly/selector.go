@@ -0,0 +1,48 @@
+package readonly
+
+import (
+	"github.com/QuangTung97/promo-readonly/model"
+	"github.com/shopspring/decimal"
+)
+
+// CampaignCandidate is an eligible campaign of a voucher with its computed discount
+type CampaignCandidate struct {
+	Campaign       model.Campaign
+	DiscountAmount decimal.Decimal
+}
+
+// CampaignSelector chooses the campaign to apply when a voucher code matches multiple eligible campaigns
+type CampaignSelector interface {
+	// Select returns the index of the chosen candidate, candidates is never empty
+	Select(candidates []CampaignCandidate) int
+}
+
+type bestDiscountSelector struct {
+}
+
+var _ CampaignSelector = bestDiscountSelector{}
+
+// NewBestDiscountSelector chooses the highest discount, then the earliest end time, then the lowest campaign id
+func NewBestDiscountSelector() CampaignSelector {
+	return bestDiscountSelector{}
+}
+
+func (bestDiscountSelector) Select(candidates []CampaignCandidate) int {
+	selected := 0
+	for i := 1; i < len(candidates); i++ {
+		if betterCandidate(candidates[i], candidates[selected]) {
+			selected = i
+		}
+	}
+	return selected
+}
+
+func betterCandidate(a CampaignCandidate, b CampaignCandidate) bool {
+	if cmp := a.DiscountAmount.Cmp(b.DiscountAmount); cmp != 0 {
+		return cmp > 0
+	}
+	if !a.Campaign.EndTime.Equal(b.Campaign.EndTime) {
+		return a.Campaign.EndTime.Before(b.Campaign.EndTime)
+	}
+	return a.Campaign.ID < b.Campaign.ID
+}
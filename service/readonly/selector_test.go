@@ -0,0 +1,66 @@
+package readonly
+
+import (
+	"github.com/QuangTung97/promo-readonly/model"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func newCandidate(id int64, endTime string, discount string) CampaignCandidate {
+	return CampaignCandidate{
+		Campaign: model.Campaign{
+			ID:      id,
+			EndTime: newTime(endTime),
+		},
+		DiscountAmount: newDecimal(discount),
+	}
+}
+
+func TestBestDiscountSelector(t *testing.T) {
+	table := []struct {
+		name       string
+		candidates []CampaignCandidate
+		selected   int
+	}{
+		{
+			name: "single",
+			candidates: []CampaignCandidate{
+				newCandidate(11, "2022-05-20T10:00:00+07:00", "10000"),
+			},
+			selected: 0,
+		},
+		{
+			name: "highest-discount",
+			candidates: []CampaignCandidate{
+				newCandidate(11, "2022-05-20T10:00:00+07:00", "10000"),
+				newCandidate(12, "2022-05-25T10:00:00+07:00", "10000.01"),
+				newCandidate(13, "2022-05-18T10:00:00+07:00", "5000"),
+			},
+			selected: 1,
+		},
+		{
+			name: "same-discount-earliest-end-time",
+			candidates: []CampaignCandidate{
+				newCandidate(11, "2022-05-20T10:00:00+07:00", "10000"),
+				newCandidate(12, "2022-05-18T10:00:00+07:00", "10000.00"),
+				newCandidate(13, "2022-05-19T10:00:00+07:00", "10000"),
+			},
+			selected: 1,
+		},
+		{
+			name: "same-discount-and-end-time-lowest-id",
+			candidates: []CampaignCandidate{
+				newCandidate(13, "2022-05-20T10:00:00+07:00", "10000"),
+				newCandidate(11, "2022-05-20T03:00:00Z", "10000"),
+				newCandidate(12, "2022-05-20T10:00:00+07:00", "10000"),
+			},
+			selected: 1,
+		},
+	}
+	for _, e := range table {
+		t.Run(e.name, func(t *testing.T) {
+			selected := NewBestDiscountSelector().Select(e.candidates)
+			assert.Equal(t, e.selected, selected)
+		})
+	}
+}
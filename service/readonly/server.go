@@ -2,40 +2,137 @@ package readonly
 
 import (
 	"context"
-	"fmt"
 	"github.com/QuangTung97/promo-readonly/pkg/dhash"
 	"github.com/QuangTung97/promo-readonly/promopb"
 	"github.com/QuangTung97/promo-readonly/repository"
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"github.com/shopspring/decimal"
 	"go.opentelemetry.io/otel"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"time"
 )
 
 // Server ...
 type Server struct {
 	promopb.UnimplementedPromoServiceServer
 	service IService
+	options serverOptions
+}
+
+type serverOptions struct {
+	validator          *Validator
+	streamBatchWindow  time.Duration
+	streamMaxBatchSize int
+	sessionOptions     []dhash.SessionOption
+	hashDBOptions      []repository.HashDatabaseOption
+	serviceOptions     []ServiceOption
+}
+
+func newServerOptions(options ...ServerOption) serverOptions {
+	opts := serverOptions{
+		validator:          NewValidator(),
+		streamBatchWindow:  2 * time.Millisecond,
+		streamMaxBatchSize: 100,
+	}
+	for _, fn := range options {
+		fn(&opts)
+	}
+	return opts
+}
+
+// ServerOption ...
+type ServerOption func(opts *serverOptions)
+
+// WithValidator ...
+func WithValidator(v *Validator) ServerOption {
+	return func(opts *serverOptions) {
+		opts.validator = v
+	}
+}
+
+// WithCheckStreamBatchWindow sets the max duration for waiting more inputs of a batch, zero means default
+func WithCheckStreamBatchWindow(d time.Duration) ServerOption {
+	return func(opts *serverOptions) {
+		if d > 0 {
+			opts.streamBatchWindow = d
+		}
+	}
+}
+
+// WithCheckStreamMaxBatchSize sets the max number of inputs of a batch, zero means default
+func WithCheckStreamMaxBatchSize(n int) ServerOption {
+	return func(opts *serverOptions) {
+		if n > 0 {
+			opts.streamMaxBatchSize = n
+		}
+	}
+}
+
+// WithDHashFallbackToDB reads directly from MySQL after lease retries are exhausted,
+// with at most maxConcurrent reads for the whole server, zero means disabled
+func WithDHashFallbackToDB(maxConcurrent int) ServerOption {
+	return func(opts *serverOptions) {
+		if maxConcurrent > 0 {
+			limiter := dhash.NewFallbackLimiter(maxConcurrent)
+			opts.sessionOptions = append(opts.sessionOptions, dhash.WithFallbackToDB(limiter))
+		}
+	}
+}
+
+// WithHashSelectLimits sets the max number of hash ranges in a MySQL query of hash databases,
+// and the max number of those queries executed in parallel, zero means default
+func WithHashSelectLimits(chunkSize int, maxConcurrent int) ServerOption {
+	return func(opts *serverOptions) {
+		opts.hashDBOptions = append(opts.hashDBOptions,
+			repository.WithSelectChunkSize(chunkSize),
+			repository.WithSelectMaxConcurrent(maxConcurrent),
+		)
+	}
+}
+
+// WithBusinessLocation sets the location for computing usage periods of request times
+func WithBusinessLocation(loc *time.Location) ServerOption {
+	return func(opts *serverOptions) {
+		opts.serviceOptions = append(opts.serviceOptions, WithLocation(loc))
+	}
 }
 
 // NewServer ...
 //revive:disable-next-line:flag-parameter
-func NewServer(provider repository.Provider, dhashProvider dhash.Provider, dbOnly bool) *Server {
+func NewServer(
+	provider repository.Provider, dhashProvider dhash.Provider, dbOnly bool, options ...ServerOption,
+) *Server {
 	blacklistRepo := repository.NewBlacklistWrapper(
 		repository.NewBlacklist(),
 		otel.GetTracerProvider().Tracer("server"),
 		"repo::",
 	)
+	campaignRepo := repository.NewCampaignWrapper(
+		repository.NewCampaign(),
+		otel.GetTracerProvider().Tracer("server"),
+		"repo::",
+	)
+
+	opts := newServerOptions(options...)
 
 	var repoProvider IRepositoryProvider
 
 	if dbOnly {
-		repoProvider = NewDBRepoProvider(blacklistRepo)
+		repoProvider = NewDBRepoProvider(blacklistRepo, campaignRepo)
 	} else {
-		repoProvider = NewRepositoryProvider(dhashProvider, blacklistRepo)
+		repoProvider = NewRepositoryProvider(
+			dhashProvider, blacklistRepo, campaignRepo, opts.hashDBOptions, opts.sessionOptions...,
+		)
 	}
 
-	s := NewService(provider, repoProvider)
+	s := NewService(provider, repoProvider, opts.serviceOptions...)
 	return &Server{
 		service: NewIServiceWrapper(s,
 			otel.GetTracerProvider().Tracer("server"), "service::"),
+		options: opts,
 	}
 }
 
@@ -43,36 +140,132 @@ func NewServer(provider repository.Provider, dhashProvider dhash.Provider, dbOnl
 func (s *Server) Check(
 	ctx context.Context, req *promopb.PromoServiceCheckRequest,
 ) (*promopb.PromoServiceCheckResponse, error) {
-	inputs := make([]Input, 0, len(req.Inputs))
-	for _, input := range req.Inputs {
-		inputs = append(inputs, Input{
-			ReqTime:      req.ReqTime.AsTime(),
+	if err := s.options.validator.ValidateBatchSize(len(req.Inputs)); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return &promopb.PromoServiceCheckResponse{
+		Outputs: s.checkInputs(ctx, req.Inputs, req.ReqTime),
+	}, nil
+}
+
+// checkInputs calls Service.Check once for all valid inputs
+func (s *Server) checkInputs(
+	ctx context.Context, reqInputs []*promopb.PromoServiceCheckInput, reqTime *timestamppb.Timestamp,
+) []*promopb.PromoServiceCheckOutput {
+	inputs := make([]Input, 0, len(reqInputs))
+	inputErrors := make([]error, len(reqInputs))
+	for index, input := range reqInputs {
+		amount, err := parseAmount(input.Amount)
+		if err != nil {
+			inputErrors[index] = err
+			continue
+		}
+
+		validInput, err := s.options.validator.ValidateInput(Input{
+			ReqTime:      inputReqTime(input.ReqTime, reqTime),
 			VoucherCode:  input.VoucherCode,
 			MerchantCode: input.MerchantCode,
 			TerminalCode: input.TerminalCode,
 			Phone:        input.Phone,
+			BankCode:     input.BankCode,
+			Amount:       amount,
 		})
+		if err != nil {
+			inputErrors[index] = err
+			continue
+		}
+
+		inputs = append(inputs, validInput)
 	}
 
 	outputs := s.service.Check(ctx, inputs)
-	// fmt.Println(outputs)
-
-	respOutputs := make([]*promopb.PromoServiceCheckOutput, 0, len(outputs))
-	for index, o := range outputs {
-		status := int32(1)
-		if o.Err != nil {
-			if o.Err != ErrCustomerInBlacklist && o.Err != ErrMerchantInBlacklist {
-				fmt.Println(index, o.Err)
-			}
-			status = 2
+
+	respOutputs := make([]*promopb.PromoServiceCheckOutput, 0, len(reqInputs))
+	for index := range reqInputs {
+		var o Output
+		if inputErrors[index] != nil {
+			o = Output{Err: inputErrors[index]}
+		} else {
+			o = outputs[0]
+			outputs = outputs[1:]
+		}
+
+		reason, message := checkReasonFromError(o.Err)
+		if reason == promopb.CheckReason_INTERNAL_ERROR {
+			ctxzap.Extract(ctx).Error("check input failed", zap.Int("index", index), zap.Error(o.Err))
 		}
 
 		respOutputs = append(respOutputs, &promopb.PromoServiceCheckOutput{
-			Status: status,
+			DiscountAmount: o.DiscountAmount.String(),
+			CampaignId:     o.CampaignID,
+			Reason:         reason,
+			Message:        message,
 		})
 	}
+	return respOutputs
+}
 
-	return &promopb.PromoServiceCheckResponse{
-		Outputs: respOutputs,
-	}, nil
+// inputReqTime returns zero time when both are unset,
+// term codes of period usages are computed in the location set by WithBusinessLocation
+func inputReqTime(inputTime *timestamppb.Timestamp, reqTime *timestamppb.Timestamp) time.Time {
+	if inputTime != nil {
+		return inputTime.AsTime()
+	}
+	if reqTime != nil {
+		return reqTime.AsTime()
+	}
+	return time.Time{}
+}
+
+func parseAmount(s string) (decimal.Decimal, error) {
+	if s == "" {
+		return decimal.Zero, nil
+	}
+
+	amount, err := decimal.NewFromString(s)
+	if err != nil || amount.IsNegative() {
+		return decimal.Decimal{}, ErrInvalidAmount
+	}
+	return amount, nil
+}
+
+var checkReasons = map[Reason]promopb.CheckReason{
+	ReasonCustomerBlacklisted:          promopb.CheckReason_CUSTOMER_BLACKLISTED,
+	ReasonMerchantBlacklisted:          promopb.CheckReason_MERCHANT_BLACKLISTED,
+	ReasonTerminalBlacklisted:          promopb.CheckReason_TERMINAL_BLACKLISTED,
+	ReasonVoucherNotFound:              promopb.CheckReason_VOUCHER_NOT_FOUND,
+	ReasonCampaignInactive:             promopb.CheckReason_CAMPAIGN_INACTIVE,
+	ReasonCampaignNotStarted:           promopb.CheckReason_CAMPAIGN_NOT_STARTED,
+	ReasonCampaignEnded:                promopb.CheckReason_CAMPAIGN_ENDED,
+	ReasonNoApplicableBenefit:          promopb.CheckReason_NO_APPLICABLE_BENEFIT,
+	ReasonMerchantNotEligible:          promopb.CheckReason_MERCHANT_NOT_ELIGIBLE,
+	ReasonTerminalNotEligible:          promopb.CheckReason_TERMINAL_NOT_ELIGIBLE,
+	ReasonBankNotEligible:              promopb.CheckReason_BANK_NOT_ELIGIBLE,
+	ReasonCustomerNotEligible:          promopb.CheckReason_CUSTOMER_NOT_ELIGIBLE,
+	ReasonBudgetExhausted:              promopb.CheckReason_BUDGET_EXHAUSTED,
+	ReasonCampaignUsageExhausted:       promopb.CheckReason_CAMPAIGN_USAGE_EXHAUSTED,
+	ReasonCustomerUsageExhausted:       promopb.CheckReason_CUSTOMER_USAGE_EXHAUSTED,
+	ReasonCustomerPeriodUsageExhausted: promopb.CheckReason_CUSTOMER_PERIOD_USAGE_EXHAUSTED,
+	ReasonInvalidArgument:              promopb.CheckReason_INVALID_ARGUMENT,
+}
+
+const internalErrorMessage = "internal error"
+
+// checkReasonFromError does NOT expose the messages of infrastructure failures to clients
+func checkReasonFromError(err error) (promopb.CheckReason, string) {
+	if err == nil {
+		return promopb.CheckReason_OK, ""
+	}
+
+	reason, ok := BusinessErrorReason(err)
+	if !ok {
+		return promopb.CheckReason_INTERNAL_ERROR, internalErrorMessage
+	}
+
+	checkReason, existed := checkReasons[reason]
+	if !existed {
+		return promopb.CheckReason_INTERNAL_ERROR, internalErrorMessage
+	}
+	return checkReason, err.Error()
 }
@@ -0,0 +1,109 @@
+package readonly
+
+import (
+	"github.com/QuangTung97/promo-readonly/promopb"
+	"io"
+	"time"
+)
+
+// CheckStream ...
+func (s *Server) CheckStream(stream promopb.PromoService_CheckStreamServer) error {
+	ctx := stream.Context()
+
+	requests := make(chan *promopb.PromoServiceCheckStreamRequest, s.options.streamMaxBatchSize)
+	recvErr := make(chan error, 1)
+
+	go func() {
+		defer close(requests)
+		for {
+			req, err := stream.Recv()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				recvErr <- err
+				return
+			}
+
+			select {
+			case requests <- req:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	for {
+		batch, more := collectStreamBatch(requests, s.options.streamBatchWindow, s.options.streamMaxBatchSize)
+		if len(batch) > 0 {
+			if err := s.checkStreamBatch(stream, batch); err != nil {
+				return err
+			}
+		}
+		if !more {
+			break
+		}
+	}
+
+	select {
+	case err := <-recvErr:
+		return err
+	default:
+		return ctx.Err()
+	}
+}
+
+// collectStreamBatch blocks until the first request arrives, then waits for more requests
+// until the batch is full or the window elapsed, more = false when the channel is closed
+func collectStreamBatch(
+	requests <-chan *promopb.PromoServiceCheckStreamRequest, window time.Duration, maxSize int,
+) (batch []*promopb.PromoServiceCheckStreamRequest, more bool) {
+	req, ok := <-requests
+	if !ok {
+		return nil, false
+	}
+	batch = append(batch, req)
+
+	timer := time.NewTimer(window)
+	defer timer.Stop()
+
+	for len(batch) < maxSize {
+		select {
+		case req, ok := <-requests:
+			if !ok {
+				return batch, false
+			}
+			batch = append(batch, req)
+		case <-timer.C:
+			return batch, true
+		}
+	}
+	return batch, true
+}
+
+// checkStreamBatch uses a single repository session for the whole batch
+func (s *Server) checkStreamBatch(
+	stream promopb.PromoService_CheckStreamServer, batch []*promopb.PromoServiceCheckStreamRequest,
+) error {
+	inputs := make([]*promopb.PromoServiceCheckInput, 0, len(batch))
+	for _, req := range batch {
+		input := req.Input
+		if input == nil {
+			input = &promopb.PromoServiceCheckInput{}
+		}
+		inputs = append(inputs, input)
+	}
+
+	outputs := s.checkInputs(stream.Context(), inputs, nil)
+
+	for i, output := range outputs {
+		err := stream.Send(&promopb.PromoServiceCheckStreamResponse{
+			RequestId: batch[i].RequestId,
+			Output:    output,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
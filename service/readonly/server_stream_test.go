@@ -0,0 +1,128 @@
+package readonly
+
+import (
+	"context"
+	"github.com/QuangTung97/promo-readonly/promopb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"io"
+	"testing"
+	"time"
+)
+
+type fakeCheckStream struct {
+	grpc.ServerStream
+
+	ctx       context.Context
+	requests  []*promopb.PromoServiceCheckStreamRequest
+	responses []*promopb.PromoServiceCheckStreamResponse
+}
+
+func (s *fakeCheckStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeCheckStream) Send(resp *promopb.PromoServiceCheckStreamResponse) error {
+	s.responses = append(s.responses, resp)
+	return nil
+}
+
+func (s *fakeCheckStream) Recv() (*promopb.PromoServiceCheckStreamRequest, error) {
+	if len(s.requests) == 0 {
+		return nil, io.EOF
+	}
+	req := s.requests[0]
+	s.requests = s.requests[1:]
+	return req, nil
+}
+
+func newStreamTestServer(batchSizes *[]int, options ...ServerOption) *Server {
+	return &Server{
+		service: serviceFunc(func(ctx context.Context, inputs []Input) []Output {
+			*batchSizes = append(*batchSizes, len(inputs))
+			outputs := make([]Output, 0, len(inputs))
+			for _, input := range inputs {
+				if input.VoucherCode == "UNKNOWN" {
+					outputs = append(outputs, Output{Err: ErrVoucherNotFound})
+					continue
+				}
+				outputs = append(outputs, Output{CampaignID: 11, DiscountAmount: newDecimal("1000")})
+			}
+			return outputs
+		}),
+		options: newServerOptions(options...),
+	}
+}
+
+func newStreamInput(voucherCode string) *promopb.PromoServiceCheckInput {
+	return &promopb.PromoServiceCheckInput{
+		VoucherCode:  voucherCode,
+		MerchantCode: "MERCHANT01",
+		Phone:        "0987000111",
+	}
+}
+
+func newStreamRequests() []*promopb.PromoServiceCheckStreamRequest {
+	return []*promopb.PromoServiceCheckStreamRequest{
+		{RequestId: "req-01", Input: newStreamInput("VOUCHER01")},
+		{RequestId: "req-02", Input: newStreamInput("VOUCHER02")},
+		{RequestId: "req-03", Input: newStreamInput("UNKNOWN")},
+	}
+}
+
+func TestServer_CheckStream__Single_Batch(t *testing.T) {
+	var batchSizes []int
+	s := newStreamTestServer(&batchSizes, WithCheckStreamBatchWindow(time.Minute))
+
+	stream := &fakeCheckStream{ctx: newContext(), requests: newStreamRequests()}
+	err := s.CheckStream(stream)
+	assert.Equal(t, nil, err)
+
+	assert.Equal(t, []int{3}, batchSizes)
+	assert.Equal(t, 3, len(stream.responses))
+
+	assert.Equal(t, "req-01", stream.responses[0].RequestId)
+	assert.Equal(t, promopb.CheckReason_OK, stream.responses[0].Output.Reason)
+	assert.Equal(t, int64(11), stream.responses[0].Output.CampaignId)
+	assert.Equal(t, "1000", stream.responses[0].Output.DiscountAmount)
+
+	assert.Equal(t, "req-02", stream.responses[1].RequestId)
+	assert.Equal(t, promopb.CheckReason_OK, stream.responses[1].Output.Reason)
+
+	assert.Equal(t, "req-03", stream.responses[2].RequestId)
+	assert.Equal(t, promopb.CheckReason_VOUCHER_NOT_FOUND, stream.responses[2].Output.Reason)
+}
+
+func TestServer_CheckStream__Max_Batch_Size(t *testing.T) {
+	var batchSizes []int
+	s := newStreamTestServer(&batchSizes,
+		WithCheckStreamBatchWindow(time.Minute),
+		WithCheckStreamMaxBatchSize(2),
+	)
+
+	stream := &fakeCheckStream{ctx: newContext(), requests: newStreamRequests()}
+	err := s.CheckStream(stream)
+	assert.Equal(t, nil, err)
+
+	assert.Equal(t, []int{2, 1}, batchSizes)
+	assert.Equal(t, 3, len(stream.responses))
+
+	assert.Equal(t, "req-01", stream.responses[0].RequestId)
+	assert.Equal(t, "req-02", stream.responses[1].RequestId)
+	assert.Equal(t, "req-03", stream.responses[2].RequestId)
+}
+
+func TestCollectStreamBatch__Window_Elapsed(t *testing.T) {
+	requests := make(chan *promopb.PromoServiceCheckStreamRequest, 10)
+	requests <- &promopb.PromoServiceCheckStreamRequest{RequestId: "req-01"}
+
+	batch, more := collectStreamBatch(requests, time.Millisecond, 100)
+	assert.Equal(t, true, more)
+	assert.Equal(t, 1, len(batch))
+	assert.Equal(t, "req-01", batch[0].RequestId)
+
+	close(requests)
+	batch, more = collectStreamBatch(requests, time.Millisecond, 100)
+	assert.Equal(t, false, more)
+	assert.Equal(t, 0, len(batch))
+}
@@ -0,0 +1,159 @@
+package readonly
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/QuangTung97/promo-readonly/promopb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"testing"
+)
+
+func TestBusinessErrorReason(t *testing.T) {
+	reason, ok := BusinessErrorReason(ErrCampaignEnded)
+	assert.Equal(t, true, ok)
+	assert.Equal(t, ReasonCampaignEnded, reason)
+
+	reason, ok = BusinessErrorReason(fmt.Errorf("wrapped: %w", ErrVoucherNotFound))
+	assert.Equal(t, true, ok)
+	assert.Equal(t, ReasonVoucherNotFound, reason)
+
+	reason, ok = BusinessErrorReason(errors.New("connection refused"))
+	assert.Equal(t, false, ok)
+	assert.Equal(t, Reason(0), reason)
+}
+
+func TestCheckReasonFromError(t *testing.T) {
+	table := []struct {
+		name    string
+		err     error
+		reason  promopb.CheckReason
+		message string
+	}{
+		{
+			name:    "ok",
+			err:     nil,
+			reason:  promopb.CheckReason_OK,
+			message: "",
+		},
+		{
+			name:    "customer-blacklisted",
+			err:     ErrCustomerInBlacklist,
+			reason:  promopb.CheckReason_CUSTOMER_BLACKLISTED,
+			message: "customer in blacklist",
+		},
+		{
+			name:    "budget-exhausted",
+			err:     ErrCampaignBudgetExhausted,
+			reason:  promopb.CheckReason_BUDGET_EXHAUSTED,
+			message: "campaign budget exhausted",
+		},
+		{
+			name:    "infrastructure-failure",
+			err:     errors.New("connection refused"),
+			reason:  promopb.CheckReason_INTERNAL_ERROR,
+			message: "internal error",
+		},
+		{
+			name:    "unknown-reason",
+			err:     newBusinessError(Reason(1000), "unknown"),
+			reason:  promopb.CheckReason_INTERNAL_ERROR,
+			message: "internal error",
+		},
+	}
+	for _, e := range table {
+		t.Run(e.name, func(t *testing.T) {
+			reason, message := checkReasonFromError(e.err)
+			assert.Equal(t, e.reason, reason)
+			assert.Equal(t, e.message, message)
+		})
+	}
+}
+
+func TestCheckReasons__All_Reasons_Mapped(t *testing.T) {
+	for r := ReasonCustomerBlacklisted; r <= ReasonInvalidArgument; r++ {
+		_, existed := checkReasons[r]
+		assert.Equal(t, true, existed, r)
+	}
+}
+
+type serviceFunc func(ctx context.Context, inputs []Input) []Output
+
+func (f serviceFunc) Check(ctx context.Context, inputs []Input) []Output {
+	return f(ctx, inputs)
+}
+
+func TestParseAmount(t *testing.T) {
+	amount, err := parseAmount("")
+	assert.Equal(t, nil, err)
+	assert.True(t, amount.IsZero())
+
+	amount, err = parseAmount("100000.50")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "100000.5", amount.String())
+
+	_, err = parseAmount("abc")
+	assert.Equal(t, ErrInvalidAmount, err)
+
+	_, err = parseAmount("-1")
+	assert.Equal(t, ErrInvalidAmount, err)
+}
+
+func TestServer_Check__Pass_Amount_And_Bank_Code(t *testing.T) {
+	var serviceInputs []Input
+	s := &Server{
+		service: serviceFunc(func(ctx context.Context, inputs []Input) []Output {
+			serviceInputs = inputs
+			return []Output{
+				{CampaignID: 11, DiscountAmount: newDecimal("10000.05")},
+				{CampaignID: 12, Err: ErrBankNotEligible},
+			}
+		}),
+		options: newServerOptions(),
+	}
+
+	resp, err := s.Check(newContext(), &promopb.PromoServiceCheckRequest{
+		ReqTime: timestamppb.New(newTime("2022-05-15T10:00:00+07:00")),
+		Inputs: []*promopb.PromoServiceCheckInput{
+			{VoucherCode: "VOUCHER01", MerchantCode: "MERCHANT01", Phone: "0987000111", BankCode: "BANK01", Amount: "100000.50"},
+			{VoucherCode: "VOUCHER01", MerchantCode: "MERCHANT01", Phone: "0987000111", BankCode: "BANK01", Amount: "invalid"},
+			{VoucherCode: "VOUCHER02", MerchantCode: "MERCHANT01", Phone: "0987000111", BankCode: "BANK02"},
+		},
+	})
+	assert.Equal(t, nil, err)
+
+	assert.Equal(t, 2, len(serviceInputs))
+	assert.Equal(t, "BANK01", serviceInputs[0].BankCode)
+	assert.Equal(t, "100000.5", serviceInputs[0].Amount.String())
+	assert.Equal(t, "BANK02", serviceInputs[1].BankCode)
+	assert.True(t, serviceInputs[1].Amount.IsZero())
+
+	assert.Equal(t, []*promopb.PromoServiceCheckOutput{
+		{
+			DiscountAmount: "10000.05",
+			CampaignId:     11,
+			Reason:         promopb.CheckReason_OK,
+		},
+		{
+			DiscountAmount: "0",
+			Reason:         promopb.CheckReason_INVALID_ARGUMENT,
+			Message:        "amount must be a non-negative decimal",
+		},
+		{
+			DiscountAmount: "0",
+			CampaignId:     12,
+			Reason:         promopb.CheckReason_BANK_NOT_ELIGIBLE,
+			Message:        "bank not eligible for campaign",
+		},
+	}, resp.Outputs)
+}
+
+func TestInputReqTime(t *testing.T) {
+	inputTime := timestamppb.New(newTime("2022-05-15T10:00:00+07:00"))
+	reqTime := timestamppb.New(newTime("2022-05-16T10:00:00+07:00"))
+
+	assert.True(t, newTime("2022-05-15T10:00:00+07:00").Equal(inputReqTime(inputTime, reqTime)))
+	assert.True(t, newTime("2022-05-16T10:00:00+07:00").Equal(inputReqTime(nil, reqTime)))
+	assert.True(t, inputReqTime(nil, nil).IsZero())
+}
@@ -2,10 +2,12 @@ package readonly
 
 import (
 	"context"
-	"errors"
+	"database/sql"
+	"fmt"
 	"github.com/QuangTung97/promo-readonly/model"
 	"github.com/QuangTung97/promo-readonly/repository"
 	"github.com/shopspring/decimal"
+	"sort"
 	"time"
 )
 
@@ -18,7 +20,7 @@ type IService interface {
 
 // Input ...
 type Input struct {
-	ReqTime      time.Time
+	ReqTime      time.Time // zero means the current time of the service clock
 	VoucherCode  string
 	MerchantCode string
 	TerminalCode string
@@ -29,6 +31,7 @@ type Input struct {
 
 // Output ...
 type Output struct {
+	CampaignID     int64
 	DiscountAmount decimal.Decimal
 	Err            error
 }
@@ -37,33 +40,103 @@ type Output struct {
 type Service struct {
 	provider     repository.Provider
 	repoProvider IRepositoryProvider
+	selector     CampaignSelector
+	clock        Clock
+	location     *time.Location
 }
 
-// ErrMerchantInBlacklist ...
-var ErrMerchantInBlacklist = errors.New("merchant in blacklist")
+// Clock ...
+type Clock interface {
+	Now() time.Time
+}
+
+type defaultClock struct {
+}
 
-// ErrCustomerInBlacklist ...
-var ErrCustomerInBlacklist = errors.New("customer in blacklist")
+func (defaultClock) Now() time.Time {
+	return time.Now()
+}
+
+const (
+	maxReqTimeAhead  = 5 * time.Minute
+	maxReqTimeBehind = 24 * time.Hour
+)
+
+// ServiceOption ...
+type ServiceOption func(s *Service)
+
+// WithCampaignSelector replaces the default selector NewBestDiscountSelector
+func WithCampaignSelector(selector CampaignSelector) ServiceOption {
+	return func(s *Service) {
+		s.selector = selector
+	}
+}
+
+// WithClock replaces the system clock, used for defaulting and validating ReqTime
+func WithClock(clock Clock) ServiceOption {
+	return func(s *Service) {
+		s.clock = clock
+	}
+}
+
+// WithLocation sets the location for computing usage periods of request times, defaults to UTC
+func WithLocation(loc *time.Location) ServiceOption {
+	return func(s *Service) {
+		s.location = loc
+	}
+}
 
 // NewService ...
 func NewService(
-	provider repository.Provider, repoProvider IRepositoryProvider,
+	provider repository.Provider, repoProvider IRepositoryProvider, options ...ServiceOption,
 ) *Service {
-	return &Service{
+	s := &Service{
 		provider:     provider,
 		repoProvider: repoProvider,
+		selector:     NewBestDiscountSelector(),
+		clock:        defaultClock{},
+		location:     time.UTC,
 	}
+	for _, fn := range options {
+		fn(s)
+	}
+	return s
 }
 
 type checkState struct {
-	repo  IRepository
-	ctx   context.Context
-	input Input
+	repo     IRepository
+	ctx      context.Context
+	input    Input
+	selector CampaignSelector
 
 	getBlacklistMerchant func() (model.NullBlacklistMerchant, error)
 	getBlacklistCustomer func() (model.NullBlacklistCustomer, error)
+	getBlacklistTerminal func() (model.NullBlacklistTerminal, error)
+	getCampaigns         func() ([]model.Campaign, error)
+
+	campaigns []*campaignState
+
+	campaignID     int64
+	discountAmount decimal.Decimal
+	err            error
+}
+
+// campaignState for checking a single campaign matched by voucher code
+type campaignState struct {
+	campaign model.Campaign
 
-	err error
+	getMerchant func() (model.NullCampaignMerchant, error)
+	getTerminal func() (model.NullCampaignTerminal, error)
+	getBank     func() (model.NullCampaignBank, error)
+	getCustomer func() (model.NullCampaignCustomer, error)
+	getBenefits func() ([]model.CampaignBenefit, error)
+	getUsage    func() (model.CampaignUsage, error)
+
+	getCustomerUsage func() (model.CampaignCustomerUsage, error)
+	getPeriodUsage   func() (model.CampaignPeriodUsage, error)
+
+	discountAmount decimal.Decimal
+	err            error // reason for rejecting this campaign
 }
 
 func (s *checkState) setError(err error) {
@@ -77,6 +150,19 @@ func (s *checkState) doNext(fn func()) {
 	fn()
 }
 
+// doEachCampaign calls fn on campaigns that have not been rejected yet
+func (s *checkState) doEachCampaign(fn func(c *campaignState)) {
+	for _, c := range s.campaigns {
+		if s.err != nil {
+			return
+		}
+		if c.err != nil {
+			continue
+		}
+		fn(c)
+	}
+}
+
 func (s *checkState) fetchBlacklistMerchant() {
 	s.getBlacklistMerchant = s.repo.GetBlacklistMerchant(s.ctx, s.input.MerchantCode)
 }
@@ -85,6 +171,14 @@ func (s *checkState) fetchBlacklistCustomer() {
 	s.getBlacklistCustomer = s.repo.GetBlacklistCustomer(s.ctx, s.input.Phone)
 }
 
+func (s *checkState) fetchBlacklistTerminal() {
+	s.getBlacklistTerminal = s.repo.GetBlacklistTerminal(s.ctx, s.input.MerchantCode, s.input.TerminalCode)
+}
+
+func (s *checkState) fetchCampaigns() {
+	s.getCampaigns = s.repo.GetCampaigns(s.ctx, s.input.VoucherCode)
+}
+
 func (s *checkState) handleBlacklistMerchant() {
 	nullMerchant, err := s.getBlacklistMerchant()
 	if err != nil {
@@ -96,6 +190,11 @@ func (s *checkState) handleBlacklistMerchant() {
 		return
 	}
 
+	m := nullMerchant.Merchant
+	if m.Status != model.BlacklistMerchantStatusActive || !isEffective(m.StartTime, m.EndTime, s.input.ReqTime) {
+		return
+	}
+
 	s.setError(ErrMerchantInBlacklist)
 }
 
@@ -110,38 +209,505 @@ func (s *checkState) handleBlacklistCustomer() {
 		return
 	}
 
+	c := nullCustomer.Customer
+	if c.Status != model.BlacklistCustomerStatusActive || !isEffective(c.StartTime, c.EndTime, s.input.ReqTime) {
+		return
+	}
+
 	s.setError(ErrCustomerInBlacklist)
 }
 
+func (s *checkState) handleBlacklistTerminal() {
+	nullTerminal, err := s.getBlacklistTerminal()
+	if err != nil {
+		s.setError(err)
+		return
+	}
+
+	if !nullTerminal.Valid {
+		return
+	}
+
+	t := nullTerminal.Terminal
+	if t.Status != model.BlacklistTerminalStatusActive || !isEffective(t.StartTime, t.EndTime, s.input.ReqTime) {
+		return
+	}
+
+	s.setError(ErrTerminalInBlacklist)
+}
+
+func (s *checkState) handleCampaigns() {
+	campaigns, err := s.getCampaigns()
+	if err != nil {
+		s.setError(err)
+		return
+	}
+
+	for _, c := range campaigns {
+		s.campaigns = append(s.campaigns, &campaignState{
+			campaign: c,
+			err:      checkCampaignValidity(c, s.input.ReqTime),
+		})
+	}
+	// campaigns from database and dhash buckets are not ordered
+	sort.Slice(s.campaigns, func(i, j int) bool {
+		return s.campaigns[i].campaign.ID < s.campaigns[j].campaign.ID
+	})
+
+	if len(s.campaigns) == 0 {
+		s.setError(ErrVoucherNotFound)
+	}
+}
+
+// validateReqTime rejects request times too far from the server clock
+func validateReqTime(reqTime time.Time, now time.Time) error {
+	if reqTime.After(now.Add(maxReqTimeAhead)) {
+		return ErrInvalidReqTime
+	}
+	if reqTime.Before(now.Add(-maxReqTimeBehind)) {
+		return ErrInvalidReqTime
+	}
+	return nil
+}
+
+// checkCampaignValidity checks the status and the [start, end) time window of the campaign
+func checkCampaignValidity(c model.Campaign, t time.Time) error {
+	if c.Status != model.CampaignStatusActive {
+		return ErrCampaignInactive
+	}
+	if t.Before(c.StartTime) {
+		return ErrCampaignNotStarted
+	}
+	if !t.Before(c.EndTime) {
+		return ErrCampaignEnded
+	}
+	return nil
+}
+
+func needCheckMerchant(c model.Campaign) bool {
+	return c.Type == model.CampaignTypeMerchant && !c.AllMerchants
+}
+
+// fetchCampaignMerchants also fetches the terminals in the same round trip,
+// they are only used when the merchant is not enrolled with all of its terminals
+func (s *checkState) fetchCampaignMerchants() {
+	s.doEachCampaign(func(c *campaignState) {
+		if !needCheckMerchant(c.campaign) {
+			return
+		}
+		c.getMerchant = s.repo.GetCampaignMerchant(s.ctx, c.campaign.ID, s.input.MerchantCode)
+		c.getTerminal = s.repo.GetCampaignTerminal(s.ctx, c.campaign.ID, s.input.MerchantCode, s.input.TerminalCode)
+	})
+}
+
+func (s *checkState) handleCampaignMerchants() {
+	s.doEachCampaign(func(c *campaignState) {
+		if !needCheckMerchant(c.campaign) {
+			return
+		}
+
+		merchant, err := c.getMerchant()
+		if err != nil {
+			s.setError(err)
+			return
+		}
+		terminal, err := c.getTerminal()
+		if err != nil {
+			s.setError(err)
+			return
+		}
+		c.err = checkMerchantEligibility(merchant, terminal, s.input.ReqTime)
+	})
+}
+
+func (s *checkState) fetchCampaignBanks() {
+	s.doEachCampaign(func(c *campaignState) {
+		if c.campaign.Type != model.CampaignTypeBank {
+			return
+		}
+		c.getBank = s.repo.GetCampaignBank(s.ctx, c.campaign.ID, s.input.BankCode)
+	})
+}
+
+func (s *checkState) handleCampaignBanks() {
+	s.doEachCampaign(func(c *campaignState) {
+		if c.campaign.Type != model.CampaignTypeBank {
+			return
+		}
+
+		bank, err := c.getBank()
+		if err != nil {
+			s.setError(err)
+			return
+		}
+		c.err = checkBankEligibility(bank, s.input.ReqTime)
+	})
+}
+
+func (s *checkState) fetchCampaignCustomers() {
+	s.doEachCampaign(func(c *campaignState) {
+		if c.campaign.Type != model.CampaignTypePrivate {
+			return
+		}
+		c.getCustomer = s.repo.GetCampaignCustomer(s.ctx, c.campaign.ID, s.input.Phone)
+	})
+}
+
+func (s *checkState) handleCampaignCustomers() {
+	s.doEachCampaign(func(c *campaignState) {
+		if c.campaign.Type != model.CampaignTypePrivate {
+			return
+		}
+
+		customer, err := c.getCustomer()
+		if err != nil {
+			s.setError(err)
+			return
+		}
+		c.err = checkCustomerEligibility(customer, s.input.ReqTime)
+	})
+}
+
+func (s *checkState) fetchCampaignBenefits() {
+	s.doEachCampaign(func(c *campaignState) {
+		c.getBenefits = s.repo.GetCampaignBenefits(s.ctx, c.campaign.ID)
+	})
+}
+
+func (s *checkState) handleCampaignBenefits() {
+	s.doEachCampaign(func(c *campaignState) {
+		benefits, err := c.getBenefits()
+		if err != nil {
+			s.setError(err)
+			return
+		}
+
+		benefit, ok := findApplicableBenefit(benefits, s.input)
+		if !ok {
+			c.err = ErrNoApplicableBenefit
+			return
+		}
+		c.discountAmount = computeDiscountAmount(benefit, s.input.Amount)
+	})
+}
+
+func needCheckUsage(c model.Campaign) bool {
+	return c.BudgetMax.Valid || c.CampaignUsageMax.Valid
+}
+
+func (s *checkState) fetchCampaignUsages() {
+	s.doEachCampaign(func(c *campaignState) {
+		if !needCheckUsage(c.campaign) {
+			return
+		}
+		c.getUsage = s.repo.GetCampaignUsage(s.ctx, c.campaign.ID)
+	})
+}
+
+// handleCampaignUsages must be called after handleCampaignBenefits
+func (s *checkState) handleCampaignUsages() {
+	s.doEachCampaign(func(c *campaignState) {
+		if !needCheckUsage(c.campaign) {
+			return
+		}
+
+		usage, err := c.getUsage()
+		if err != nil {
+			s.setError(err)
+			return
+		}
+		c.err = checkCampaignUsage(c.campaign, usage, c.discountAmount)
+	})
+}
+
+// needCheckCustomerUsage skips campaigns without limit on usages per customer,
+// customer_usage_max is NOT NULL and zero means unlimited
+func needCheckCustomerUsage(c model.Campaign) bool {
+	return c.CustomerUsageMax > 0
+}
+
+func needCheckPeriodUsage(c model.Campaign) bool {
+	return c.PeriodUsageType != model.PeriodUsageTypeUnspecified && c.PeriodCustomerUsageMax.Valid
+}
+
+func (s *checkState) fetchCustomerUsages() {
+	s.doEachCampaign(func(c *campaignState) {
+		if needCheckCustomerUsage(c.campaign) {
+			c.getCustomerUsage = s.repo.GetCampaignCustomerUsage(s.ctx, c.campaign.ID, s.input.Phone)
+		}
+		if needCheckPeriodUsage(c.campaign) {
+			termCode := computeTermCode(c.campaign, s.input)
+			c.getPeriodUsage = s.repo.GetCampaignPeriodUsage(s.ctx, c.campaign.ID, s.input.Phone, termCode)
+		}
+	})
+}
+
+func (s *checkState) handleCustomerUsages() {
+	s.doEachCampaign(func(c *campaignState) {
+		if needCheckCustomerUsage(c.campaign) {
+			usage, err := c.getCustomerUsage()
+			if err != nil {
+				s.setError(err)
+				return
+			}
+			if usage.UsageNum >= c.campaign.CustomerUsageMax {
+				c.err = ErrCustomerUsageExhausted
+				return
+			}
+		}
+
+		if needCheckPeriodUsage(c.campaign) {
+			usage, err := c.getPeriodUsage()
+			if err != nil {
+				s.setError(err)
+				return
+			}
+			c.err = checkPeriodUsage(c.campaign, usage, s.input.ReqTime)
+		}
+	})
+}
+
+// selectCampaign returns the reason of the campaign with the smallest id when all campaigns are rejected
+func (s *checkState) selectCampaign() {
+	var candidates []CampaignCandidate
+	var rejectErr error
+	var rejectID int64
+
+	for _, c := range s.campaigns {
+		if c.err != nil {
+			if rejectErr == nil {
+				rejectErr = c.err
+				rejectID = c.campaign.ID
+			}
+			continue
+		}
+		candidates = append(candidates, CampaignCandidate{
+			Campaign:       c.campaign,
+			DiscountAmount: c.discountAmount,
+		})
+	}
+
+	if len(candidates) == 0 {
+		s.campaignID = rejectID
+		s.setError(rejectErr)
+		return
+	}
+
+	selected := candidates[s.selector.Select(candidates)]
+	s.campaignID = selected.Campaign.ID
+	s.discountAmount = selected.DiscountAmount
+}
+
+// isEffective checks whether t is inside the optional [start, end) time window
+func isEffective(start sql.NullTime, end sql.NullTime, t time.Time) bool {
+	if start.Valid && t.Before(start.Time) {
+		return false
+	}
+	if end.Valid && !t.Before(end.Time) {
+		return false
+	}
+	return true
+}
+
+func checkMerchantEligibility(
+	merchant model.NullCampaignMerchant, terminal model.NullCampaignTerminal, reqTime time.Time,
+) error {
+	if !merchant.Valid {
+		return ErrMerchantNotEligible
+	}
+	m := merchant.Merchant
+	if m.Status != model.CampaignMerchantStatusActive || !isEffective(m.StartTime, m.EndTime, reqTime) {
+		return ErrMerchantNotEligible
+	}
+
+	if m.AllTerminals {
+		return nil
+	}
+
+	if !terminal.Valid {
+		return ErrTerminalNotEligible
+	}
+	t := terminal.Terminal
+	if t.Status != model.CampaignTerminalStatusActive || !isEffective(t.StartTime, t.EndTime, reqTime) {
+		return ErrTerminalNotEligible
+	}
+	return nil
+}
+
+func checkBankEligibility(bank model.NullCampaignBank, reqTime time.Time) error {
+	if !bank.Valid {
+		return ErrBankNotEligible
+	}
+	b := bank.Bank
+	if b.Status != model.CampaignBankStatusActive || !isEffective(b.StartTime, b.EndTime, reqTime) {
+		return ErrBankNotEligible
+	}
+	return nil
+}
+
+func checkCustomerEligibility(customer model.NullCampaignCustomer, reqTime time.Time) error {
+	if !customer.Valid {
+		return ErrCustomerNotEligible
+	}
+	c := customer.Customer
+	if c.Status != model.CampaignCustomerStatusActive || !isEffective(c.StartTime, c.EndTime, reqTime) {
+		return ErrCustomerNotEligible
+	}
+	return nil
+}
+
+// findApplicableBenefit returns the benefit with the highest minimum transaction amount
+// among benefits that are effective at the request time
+func findApplicableBenefit(benefits []model.CampaignBenefit, input Input) (model.CampaignBenefit, bool) {
+	var result model.CampaignBenefit
+	found := false
+
+	for _, b := range benefits {
+		if input.ReqTime.Before(b.StartTime) || !input.ReqTime.Before(b.EndTime) {
+			continue
+		}
+		if b.TxnMinAmount.GreaterThan(input.Amount) {
+			continue
+		}
+
+		if !found {
+			result = b
+			found = true
+			continue
+		}
+
+		if b.TxnMinAmount.GreaterThan(result.TxnMinAmount) {
+			result = b
+		} else if b.TxnMinAmount.Equal(result.TxnMinAmount) && b.ID < result.ID {
+			result = b
+		}
+	}
+	return result, found
+}
+
+// checkCampaignUsage checks whether applying the discount exceeds the campaign budget or usage count
+func checkCampaignUsage(campaign model.Campaign, usage model.CampaignUsage, discountAmount decimal.Decimal) error {
+	if campaign.BudgetMax.Valid && usage.BudgetUsed.Add(discountAmount).GreaterThan(campaign.BudgetMax.Decimal) {
+		return ErrCampaignBudgetExhausted
+	}
+	if campaign.CampaignUsageMax.Valid && usage.CampaignUsed >= campaign.CampaignUsageMax.Int64 {
+		return ErrCampaignUsageExhausted
+	}
+	return nil
+}
+
+// computeTermCode returns the term code of the period containing the request time,
+// computed in the location of the request time
+func computeTermCode(campaign model.Campaign, input Input) string {
+	t := input.ReqTime
+
+	var period string
+	switch campaign.PeriodUsageType {
+	case model.PeriodUsageTypeDaily:
+		period = t.Format("20060102")
+	case model.PeriodUsageTypeWeekly:
+		year, week := t.ISOWeek()
+		period = fmt.Sprintf("%04dW%02d", year, week)
+	default:
+		period = t.Format("200601")
+	}
+
+	switch campaign.PeriodTermType {
+	case model.PeriodTermTypeMerchant:
+		return period + ":" + input.MerchantCode
+	case model.PeriodTermTypeTerminal:
+		return period + ":" + input.MerchantCode + ":" + input.TerminalCode
+	default:
+		return period
+	}
+}
+
+// checkPeriodUsage ignores usages that already expired
+func checkPeriodUsage(campaign model.Campaign, usage model.CampaignPeriodUsage, t time.Time) error {
+	if !usage.ExpiredOn.After(t) {
+		return nil
+	}
+	if usage.UsageNum >= campaign.PeriodCustomerUsageMax.Int64 {
+		return ErrCustomerPeriodUsageExhausted
+	}
+	return nil
+}
+
+var oneHundred = decimal.NewFromInt(100)
+
+func computeDiscountAmount(benefit model.CampaignBenefit, amount decimal.Decimal) decimal.Decimal {
+	discount := amount.Mul(benefit.DiscountPercent).Div(oneHundred).Truncate(2)
+	return decimal.Min(discount, benefit.MaxDiscountAmount)
+}
+
 // Check ...
 func (s *Service) Check(ctx context.Context, inputs []Input) []Output {
 	ctx = s.provider.Readonly(ctx)
 	repo := s.repoProvider.NewRepo()
 	defer repo.Finish()
 
+	now := s.clock.Now()
+
 	states := make([]*checkState, 0, len(inputs))
 	for _, input := range inputs {
+		if input.ReqTime.IsZero() {
+			input.ReqTime = now
+		}
+		input.ReqTime = input.ReqTime.In(s.location)
 		states = append(states, &checkState{
-			repo:  repo,
-			ctx:   ctx,
-			input: input,
+			repo:     repo,
+			ctx:      ctx,
+			input:    input,
+			selector: s.selector,
+			err:      validateReqTime(input.ReqTime, now),
 		})
 	}
 
 	for _, state := range states {
 		state.doNext(state.fetchBlacklistMerchant)
 		state.doNext(state.fetchBlacklistCustomer)
+		state.doNext(state.fetchBlacklistTerminal)
+		state.doNext(state.fetchCampaigns)
 	}
 
 	for _, state := range states {
 		state.doNext(state.handleBlacklistMerchant)
 		state.doNext(state.handleBlacklistCustomer)
+		state.doNext(state.handleBlacklistTerminal)
+		state.doNext(state.handleCampaigns)
+	}
+
+	for _, state := range states {
+		state.doNext(state.fetchCampaignMerchants)
+		state.doNext(state.fetchCampaignBanks)
+		state.doNext(state.fetchCampaignCustomers)
+		state.doNext(state.fetchCampaignBenefits)
+		state.doNext(state.fetchCampaignUsages)
+		state.doNext(state.fetchCustomerUsages)
+	}
+
+	for _, state := range states {
+		state.doNext(state.handleCampaignMerchants)
+		state.doNext(state.handleCampaignBanks)
+		state.doNext(state.handleCampaignCustomers)
+		state.doNext(state.handleCampaignBenefits)
+		state.doNext(state.handleCampaignUsages)
+		state.doNext(state.handleCustomerUsages)
+		state.doNext(state.selectCampaign)
 	}
 
 	outputs := make([]Output, 0, len(states))
 	for _, state := range states {
+		if state.err != nil {
+			outputs = append(outputs, Output{
+				CampaignID: state.campaignID,
+				Err:        state.err,
+			})
+			continue
+		}
 		outputs = append(outputs, Output{
-			Err: state.err,
+			CampaignID:     state.campaignID,
+			DiscountAmount: state.discountAmount,
 		})
 	}
 	return outputs
@@ -0,0 +1,2316 @@
+package readonly
+
+import (
+	"context"
+	"database/sql"
+	"github.com/QuangTung97/promo-readonly/model"
+	"github.com/QuangTung97/promo-readonly/pkg/dhash"
+	"github.com/QuangTung97/promo-readonly/pkg/util"
+	"github.com/QuangTung97/promo-readonly/repository"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func newBenefit(id int64, txnMinAmount string, percent string, maxDiscount string) model.CampaignBenefit {
+	return model.CampaignBenefit{
+		ID:         id,
+		CampaignID: 11,
+		StartTime:  newTime("2022-05-10T10:00:00+07:00"),
+		EndTime:    newTime("2022-05-20T10:00:00+07:00"),
+
+		TxnMinAmount:      newDecimal(txnMinAmount),
+		DiscountPercent:   newDecimal(percent),
+		MaxDiscountAmount: newDecimal(maxDiscount),
+	}
+}
+
+func TestComputeDiscountAmount(t *testing.T) {
+	table := []struct {
+		name     string
+		benefit  model.CampaignBenefit
+		amount   string
+		expected string
+	}{
+		{
+			name:     "under-max",
+			benefit:  newBenefit(1, "0", "10", "20000"),
+			amount:   "150000",
+			expected: "15000",
+		},
+		{
+			name:     "capped-by-max",
+			benefit:  newBenefit(1, "0", "10", "20000"),
+			amount:   "300000",
+			expected: "20000",
+		},
+		{
+			name:     "truncated",
+			benefit:  newBenefit(1, "0", "3.33", "20000"),
+			amount:   "1234.56",
+			expected: "41.11",
+		},
+		{
+			name:     "zero-amount",
+			benefit:  newBenefit(1, "0", "10", "20000"),
+			amount:   "0",
+			expected: "0",
+		},
+	}
+	for _, e := range table {
+		t.Run(e.name, func(t *testing.T) {
+			result := computeDiscountAmount(e.benefit, newDecimal(e.amount))
+			assert.Equal(t, e.expected, result.String())
+		})
+	}
+}
+
+func TestFindApplicableBenefit(t *testing.T) {
+	notStarted := newBenefit(5, "0", "50", "50000")
+	notStarted.StartTime = newTime("2022-05-16T10:00:00+07:00")
+
+	ended := newBenefit(6, "0", "50", "50000")
+	ended.EndTime = newTime("2022-05-15T10:00:00+07:00")
+
+	table := []struct {
+		name     string
+		benefits []model.CampaignBenefit
+		amount   string
+		found    bool
+		id       int64
+	}{
+		{
+			name:   "empty",
+			amount: "100000",
+			found:  false,
+		},
+		{
+			name: "highest-txn-min-amount",
+			benefits: []model.CampaignBenefit{
+				newBenefit(1, "0", "5", "10000"),
+				newBenefit(2, "100000", "15", "30000"),
+				newBenefit(3, "50000", "10", "20000"),
+			},
+			amount: "120000",
+			found:  true,
+			id:     2,
+		},
+		{
+			name: "txn-min-amount-greater-than-amount",
+			benefits: []model.CampaignBenefit{
+				newBenefit(2, "100000", "15", "30000"),
+				newBenefit(3, "50000", "10", "20000"),
+			},
+			amount: "80000",
+			found:  true,
+			id:     3,
+		},
+		{
+			name: "txn-min-amount-equal-to-amount",
+			benefits: []model.CampaignBenefit{
+				newBenefit(3, "50000", "10", "20000"),
+			},
+			amount: "50000",
+			found:  true,
+			id:     3,
+		},
+		{
+			name: "same-txn-min-amount-lowest-id",
+			benefits: []model.CampaignBenefit{
+				newBenefit(4, "50000", "10", "20000"),
+				newBenefit(3, "50000", "12", "20000"),
+			},
+			amount: "80000",
+			found:  true,
+			id:     3,
+		},
+		{
+			name: "outside-time-window",
+			benefits: []model.CampaignBenefit{
+				notStarted,
+				ended,
+			},
+			amount: "80000",
+			found:  false,
+		},
+		{
+			name: "all-too-high",
+			benefits: []model.CampaignBenefit{
+				newBenefit(2, "100000", "15", "30000"),
+			},
+			amount: "80000",
+			found:  false,
+		},
+	}
+	for _, e := range table {
+		t.Run(e.name, func(t *testing.T) {
+			benefit, ok := findApplicableBenefit(e.benefits, Input{
+				ReqTime: newTime("2022-05-15T10:00:00+07:00"),
+				Amount:  newDecimal(e.amount),
+			})
+			assert.Equal(t, e.found, ok)
+			assert.Equal(t, e.id, benefit.ID)
+		})
+	}
+}
+
+func TestCheckMerchantEligibility(t *testing.T) {
+	activeMerchant := model.NullCampaignMerchant{
+		Valid: true,
+		Merchant: model.CampaignMerchant{
+			CampaignID:   11,
+			MerchantCode: "MERCHANT01",
+			Status:       model.CampaignMerchantStatusActive,
+			StartTime:    newNullTime("2022-05-10T10:00:00+07:00"),
+			EndTime:      newNullTime("2022-05-20T10:00:00+07:00"),
+		},
+	}
+	allTerminalsMerchant := activeMerchant
+	allTerminalsMerchant.Merchant.AllTerminals = true
+
+	inactiveMerchant := activeMerchant
+	inactiveMerchant.Merchant.Status = model.CampaignMerchantStatusInactive
+
+	notStartedMerchant := activeMerchant
+	notStartedMerchant.Merchant.StartTime = newNullTime("2022-05-16T10:00:00+07:00")
+
+	endedMerchant := activeMerchant
+	endedMerchant.Merchant.EndTime = newNullTime("2022-05-15T10:00:00+07:00")
+
+	noWindowMerchant := allTerminalsMerchant
+	noWindowMerchant.Merchant.StartTime = sql.NullTime{}
+	noWindowMerchant.Merchant.EndTime = sql.NullTime{}
+
+	activeTerminal := model.NullCampaignTerminal{
+		Valid: true,
+		Terminal: model.CampaignTerminal{
+			CampaignID:   11,
+			MerchantCode: "MERCHANT01",
+			TerminalCode: "TERMINAL01",
+			Status:       model.CampaignTerminalStatusActive,
+		},
+	}
+	inactiveTerminal := activeTerminal
+	inactiveTerminal.Terminal.Status = model.CampaignTerminalStatusInactive
+
+	endedTerminal := activeTerminal
+	endedTerminal.Terminal.EndTime = newNullTime("2022-05-15T10:00:00+07:00")
+
+	table := []struct {
+		name     string
+		merchant model.NullCampaignMerchant
+		terminal model.NullCampaignTerminal
+		err      error
+	}{
+		{
+			name: "merchant-not-found",
+			err:  ErrMerchantNotEligible,
+		},
+		{
+			name:     "merchant-inactive",
+			merchant: inactiveMerchant,
+			terminal: activeTerminal,
+			err:      ErrMerchantNotEligible,
+		},
+		{
+			name:     "merchant-not-started",
+			merchant: notStartedMerchant,
+			terminal: activeTerminal,
+			err:      ErrMerchantNotEligible,
+		},
+		{
+			name:     "merchant-ended",
+			merchant: endedMerchant,
+			terminal: activeTerminal,
+			err:      ErrMerchantNotEligible,
+		},
+		{
+			name:     "all-terminals",
+			merchant: allTerminalsMerchant,
+			err:      nil,
+		},
+		{
+			name:     "merchant-without-time-window",
+			merchant: noWindowMerchant,
+			err:      nil,
+		},
+		{
+			name:     "terminal-not-found",
+			merchant: activeMerchant,
+			err:      ErrTerminalNotEligible,
+		},
+		{
+			name:     "terminal-inactive",
+			merchant: activeMerchant,
+			terminal: inactiveTerminal,
+			err:      ErrTerminalNotEligible,
+		},
+		{
+			name:     "terminal-ended",
+			merchant: activeMerchant,
+			terminal: endedTerminal,
+			err:      ErrTerminalNotEligible,
+		},
+		{
+			name:     "terminal-active",
+			merchant: activeMerchant,
+			terminal: activeTerminal,
+			err:      nil,
+		},
+	}
+	for _, e := range table {
+		t.Run(e.name, func(t *testing.T) {
+			err := checkMerchantEligibility(e.merchant, e.terminal, newTime("2022-05-15T10:00:00+07:00"))
+			assert.Equal(t, e.err, err)
+		})
+	}
+}
+
+func TestCheckBankEligibility(t *testing.T) {
+	activeBank := model.NullCampaignBank{
+		Valid: true,
+		Bank: model.CampaignBank{
+			CampaignID: 11,
+			BankCode:   "BANK01",
+			Status:     model.CampaignBankStatusActive,
+		},
+	}
+
+	inactiveBank := activeBank
+	inactiveBank.Bank.Status = model.CampaignBankStatusInactive
+
+	notStartedBank := activeBank
+	notStartedBank.Bank.StartTime = newNullTime("2022-05-16T10:00:00+07:00")
+
+	endedBank := activeBank
+	endedBank.Bank.EndTime = newNullTime("2022-05-15T10:00:00+07:00")
+
+	inWindowBank := activeBank
+	inWindowBank.Bank.StartTime = newNullTime("2022-05-15T10:00:00+07:00")
+	inWindowBank.Bank.EndTime = newNullTime("2022-05-15T10:00:01+07:00")
+
+	table := []struct {
+		name string
+		bank model.NullCampaignBank
+		err  error
+	}{
+		{
+			name: "not-found",
+			err:  ErrBankNotEligible,
+		},
+		{
+			name: "inactive",
+			bank: inactiveBank,
+			err:  ErrBankNotEligible,
+		},
+		{
+			name: "not-started",
+			bank: notStartedBank,
+			err:  ErrBankNotEligible,
+		},
+		{
+			name: "ended",
+			bank: endedBank,
+			err:  ErrBankNotEligible,
+		},
+		{
+			name: "active",
+			bank: activeBank,
+			err:  nil,
+		},
+		{
+			name: "in-window",
+			bank: inWindowBank,
+			err:  nil,
+		},
+	}
+	for _, e := range table {
+		t.Run(e.name, func(t *testing.T) {
+			err := checkBankEligibility(e.bank, newTime("2022-05-15T10:00:00+07:00"))
+			assert.Equal(t, e.err, err)
+		})
+	}
+}
+
+func TestCheckCustomerEligibility(t *testing.T) {
+	activeCustomer := model.NullCampaignCustomer{
+		Valid: true,
+		Customer: model.CampaignCustomer{
+			CampaignID: 11,
+			Phone:      "0987000111",
+			Status:     model.CampaignCustomerStatusActive,
+			StartTime:  newNullTime("2022-05-10T10:00:00+07:00"),
+			EndTime:    newNullTime("2022-05-20T10:00:00+07:00"),
+		},
+	}
+
+	inactiveCustomer := activeCustomer
+	inactiveCustomer.Customer.Status = model.CampaignCustomerStatusInactive
+
+	notStartedCustomer := activeCustomer
+	notStartedCustomer.Customer.StartTime = newNullTime("2022-05-16T10:00:00+07:00")
+
+	endedCustomer := activeCustomer
+	endedCustomer.Customer.EndTime = newNullTime("2022-05-15T10:00:00+07:00")
+
+	table := []struct {
+		name     string
+		customer model.NullCampaignCustomer
+		err      error
+	}{
+		{
+			name: "not-enrolled",
+			err:  ErrCustomerNotEligible,
+		},
+		{
+			name:     "inactive",
+			customer: inactiveCustomer,
+			err:      ErrCustomerNotEligible,
+		},
+		{
+			name:     "not-started",
+			customer: notStartedCustomer,
+			err:      ErrCustomerNotEligible,
+		},
+		{
+			name:     "ended",
+			customer: endedCustomer,
+			err:      ErrCustomerNotEligible,
+		},
+		{
+			name:     "active",
+			customer: activeCustomer,
+			err:      nil,
+		},
+	}
+	for _, e := range table {
+		t.Run(e.name, func(t *testing.T) {
+			err := checkCustomerEligibility(e.customer, newTime("2022-05-15T10:00:00+07:00"))
+			assert.Equal(t, e.err, err)
+		})
+	}
+}
+
+func TestCheckCampaignValidity(t *testing.T) {
+	campaign := model.Campaign{
+		Status:    model.CampaignStatusActive,
+		StartTime: newTime("2022-05-10T10:00:00+07:00"),
+		EndTime:   newTime("2022-05-20T10:00:00+07:00"),
+	}
+
+	inactive := campaign
+	inactive.Status = model.CampaignStatusInactive
+
+	table := []struct {
+		name     string
+		campaign model.Campaign
+		reqTime  string
+		err      error
+	}{
+		{
+			name:     "inactive",
+			campaign: inactive,
+			reqTime:  "2022-05-15T10:00:00+07:00",
+			err:      ErrCampaignInactive,
+		},
+		{
+			name:     "not-started",
+			campaign: campaign,
+			reqTime:  "2022-05-10T09:59:59+07:00",
+			err:      ErrCampaignNotStarted,
+		},
+		{
+			name:     "at-start-time",
+			campaign: campaign,
+			reqTime:  "2022-05-10T10:00:00+07:00",
+			err:      nil,
+		},
+		{
+			name:     "at-end-time",
+			campaign: campaign,
+			reqTime:  "2022-05-20T10:00:00+07:00",
+			err:      ErrCampaignEnded,
+		},
+	}
+	for _, e := range table {
+		t.Run(e.name, func(t *testing.T) {
+			err := checkCampaignValidity(e.campaign, newTime(e.reqTime))
+			assert.Equal(t, e.err, err)
+		})
+	}
+}
+
+func TestCheckCampaignUsage(t *testing.T) {
+	budgetMax := newDecimal("100000")
+
+	table := []struct {
+		name     string
+		campaign model.Campaign
+		usage    model.CampaignUsage
+		discount string
+		err      error
+	}{
+		{
+			name:     "no-limit",
+			usage:    model.CampaignUsage{BudgetUsed: newDecimal("500000"), CampaignUsed: 100},
+			discount: "10000",
+			err:      nil,
+		},
+		{
+			name:     "budget-remaining",
+			campaign: model.Campaign{BudgetMax: decimal.NullDecimal{Valid: true, Decimal: budgetMax}},
+			usage:    model.CampaignUsage{BudgetUsed: newDecimal("90000")},
+			discount: "10000",
+			err:      nil,
+		},
+		{
+			name:     "budget-exhausted",
+			campaign: model.Campaign{BudgetMax: decimal.NullDecimal{Valid: true, Decimal: budgetMax}},
+			usage:    model.CampaignUsage{BudgetUsed: newDecimal("90000")},
+			discount: "10000.01",
+			err:      ErrCampaignBudgetExhausted,
+		},
+		{
+			name:     "usage-remaining",
+			campaign: model.Campaign{CampaignUsageMax: sql.NullInt64{Valid: true, Int64: 5}},
+			usage:    model.CampaignUsage{BudgetUsed: newDecimal("0"), CampaignUsed: 4},
+			discount: "10000",
+			err:      nil,
+		},
+		{
+			name:     "usage-exhausted",
+			campaign: model.Campaign{CampaignUsageMax: sql.NullInt64{Valid: true, Int64: 5}},
+			usage:    model.CampaignUsage{BudgetUsed: newDecimal("0"), CampaignUsed: 5},
+			discount: "10000",
+			err:      ErrCampaignUsageExhausted,
+		},
+	}
+	for _, e := range table {
+		t.Run(e.name, func(t *testing.T) {
+			err := checkCampaignUsage(e.campaign, e.usage, newDecimal(e.discount))
+			assert.Equal(t, e.err, err)
+		})
+	}
+}
+
+func TestComputeTermCode(t *testing.T) {
+	input := Input{
+		ReqTime:      newTime("2022-05-15T10:00:00+07:00"),
+		MerchantCode: "MERCHANT01",
+		TerminalCode: "TERMINAL01",
+	}
+
+	table := []struct {
+		name      string
+		usageType model.PeriodUsageType
+		termType  model.PeriodTermType
+		termCode  string
+	}{
+		{
+			name:      "daily-campaign",
+			usageType: model.PeriodUsageTypeDaily,
+			termType:  model.PeriodTermTypeCampaign,
+			termCode:  "20220515",
+		},
+		{
+			name:      "weekly-campaign",
+			usageType: model.PeriodUsageTypeWeekly,
+			termType:  model.PeriodTermTypeCampaign,
+			termCode:  "2022W19",
+		},
+		{
+			name:      "monthly-campaign",
+			usageType: model.PeriodUsageTypeMonthly,
+			termType:  model.PeriodTermTypeCampaign,
+			termCode:  "202205",
+		},
+		{
+			name:      "daily-merchant",
+			usageType: model.PeriodUsageTypeDaily,
+			termType:  model.PeriodTermTypeMerchant,
+			termCode:  "20220515:MERCHANT01",
+		},
+		{
+			name:      "monthly-terminal",
+			usageType: model.PeriodUsageTypeMonthly,
+			termType:  model.PeriodTermTypeTerminal,
+			termCode:  "202205:MERCHANT01:TERMINAL01",
+		},
+	}
+	for _, e := range table {
+		t.Run(e.name, func(t *testing.T) {
+			campaign := model.Campaign{
+				PeriodUsageType: e.usageType,
+				PeriodTermType:  e.termType,
+			}
+			assert.Equal(t, e.termCode, computeTermCode(campaign, input))
+		})
+	}
+}
+
+func TestCheckPeriodUsage(t *testing.T) {
+	campaign := model.Campaign{
+		PeriodUsageType:        model.PeriodUsageTypeDaily,
+		PeriodCustomerUsageMax: sql.NullInt64{Valid: true, Int64: 2},
+	}
+	reqTime := newTime("2022-05-15T10:00:00+07:00")
+
+	table := []struct {
+		name  string
+		usage model.CampaignPeriodUsage
+		err   error
+	}{
+		{
+			name: "not-found",
+			err:  nil,
+		},
+		{
+			name: "remaining",
+			usage: model.CampaignPeriodUsage{
+				UsageNum:  1,
+				ExpiredOn: newTime("2022-05-16T00:00:00+07:00"),
+			},
+			err: nil,
+		},
+		{
+			name: "exhausted",
+			usage: model.CampaignPeriodUsage{
+				UsageNum:  2,
+				ExpiredOn: newTime("2022-05-16T00:00:00+07:00"),
+			},
+			err: ErrCustomerPeriodUsageExhausted,
+		},
+		{
+			name: "expired",
+			usage: model.CampaignPeriodUsage{
+				UsageNum:  2,
+				ExpiredOn: newTime("2022-05-15T10:00:00+07:00"),
+			},
+			err: nil,
+		},
+	}
+	for _, e := range table {
+		t.Run(e.name, func(t *testing.T) {
+			err := checkPeriodUsage(campaign, e.usage, reqTime)
+			assert.Equal(t, e.err, err)
+		})
+	}
+}
+
+type repoProviderFunc func() IRepository
+
+func (f repoProviderFunc) NewRepo() IRepository {
+	return f()
+}
+
+// blacklistRows are the rows stored in the blacklist tables
+type blacklistRows struct {
+	customers []model.BlacklistCustomer
+	merchants []model.BlacklistMerchant
+	terminals []model.BlacklistTerminal
+}
+
+func newCheckTestCampaign() model.Campaign {
+	return model.Campaign{
+		ID:     11,
+		Name:   "campaign 01",
+		Status: model.CampaignStatusActive,
+		Type:   model.CampaignTypeMerchant,
+
+		VoucherHash: util.HashFunc("VOUCHER01"),
+		VoucherCode: "VOUCHER01",
+		StartTime:   newTime("2022-05-10T10:00:00+07:00"),
+		EndTime:     newTime("2022-05-20T10:00:00+07:00"),
+
+		AllMerchants: true,
+	}
+}
+
+func newCheckTestBenefit() model.CampaignBenefit {
+	return newBenefit(1, "0", "10", "50000")
+}
+
+func newCheckTestInput() Input {
+	return Input{
+		ReqTime:      newTime("2022-05-15T10:00:00+07:00"),
+		VoucherCode:  "VOUCHER01",
+		MerchantCode: "MERCHANT01",
+		TerminalCode: "TERMINAL01",
+		Phone:        "0987000111",
+		Amount:       newDecimal("100000"),
+	}
+}
+
+func stubHashEntries(entries []dhash.Entry) *dhash.HashMock {
+	return &dhash.HashMock{
+		SelectMultiEntriesFunc: func(ctx context.Context, hashes []uint32) func() ([][]dhash.Entry, error) {
+			return func() ([][]dhash.Entry, error) {
+				results := make([][]dhash.Entry, len(hashes))
+				for i, hash := range hashes {
+					for _, e := range entries {
+						if e.Hash == hash {
+							results[i] = append(results[i], e)
+						}
+					}
+				}
+				return results, nil
+			}
+		},
+	}
+}
+
+func newDHashRepoProvider(rows blacklistRows) IRepositoryProvider {
+	return repoProviderFunc(func() IRepository {
+		var customerEntries []dhash.Entry
+		for _, c := range rows.customers {
+			customerEntries = append(customerEntries, dhash.Entry{Hash: c.Hash, Data: marshalBlacklistCustomer(c)})
+		}
+
+		var merchantEntries []dhash.Entry
+		for _, m := range rows.merchants {
+			merchantEntries = append(merchantEntries, dhash.Entry{Hash: m.Hash, Data: marshalBlacklistMerchant(m)})
+		}
+
+		var terminalEntries []dhash.Entry
+		for _, t := range rows.terminals {
+			terminalEntries = append(terminalEntries, dhash.Entry{Hash: t.Hash, Data: marshalBlacklistTerminal(t)})
+		}
+
+		campaign := newCheckTestCampaign()
+		campaignEntries := []dhash.Entry{
+			{Hash: campaign.VoucherHash, Data: marshalCampaign(campaign)},
+		}
+
+		benefitStore := &dhash.StoreMock{
+			GetFunc: func(ctx context.Context, key string) func() ([]byte, error) {
+				return func() ([]byte, error) {
+					return marshalCampaignBenefits([]model.CampaignBenefit{newCheckTestBenefit()}), nil
+				}
+			},
+		}
+
+		usageStore := &dhash.StoreMock{
+			GetFunc: func(ctx context.Context, key string) func() ([]byte, error) {
+				return func() ([]byte, error) {
+					return marshalCampaignUsage(model.CampaignUsage{BudgetUsed: decimal.Zero}), nil
+				}
+			},
+		}
+
+		sess := &dhash.SessionMock{
+			FinishFunc: func() {},
+		}
+
+		return newRepository(sess,
+			stubHashEntries(customerEntries),
+			stubHashEntries(merchantEntries),
+			stubHashEntries(terminalEntries),
+			stubHashEntries(campaignEntries),
+			benefitStore,
+			usageStore,
+			&dhash.StoreMock{},
+			&dhash.StoreMock{},
+			stubHashEntries(nil),
+			stubHashEntries(nil),
+			stubHashEntries(nil),
+			stubHashEntries(nil),
+		)
+	})
+}
+
+func newBlacklistRepoWithRows(rows blacklistRows) *repository.BlacklistMock {
+	return &repository.BlacklistMock{
+		GetBlacklistCustomersFunc: func(
+			ctx context.Context, keys []repository.BlacklistCustomerKey,
+		) ([]model.BlacklistCustomer, error) {
+			var result []model.BlacklistCustomer
+			for _, c := range rows.customers {
+				for _, k := range keys {
+					if c.Hash == k.Hash && c.Phone == k.Phone {
+						result = append(result, c)
+					}
+				}
+			}
+			return result, nil
+		},
+		GetBlacklistMerchantsFunc: func(
+			ctx context.Context, keys []repository.BlacklistMerchantKey,
+		) ([]model.BlacklistMerchant, error) {
+			var result []model.BlacklistMerchant
+			for _, m := range rows.merchants {
+				for _, k := range keys {
+					if m.Hash == k.Hash && m.MerchantCode == k.MerchantCode {
+						result = append(result, m)
+					}
+				}
+			}
+			return result, nil
+		},
+		GetBlacklistTerminalsFunc: func(
+			ctx context.Context, keys []repository.BlacklistTerminalKey,
+		) ([]model.BlacklistTerminal, error) {
+			var result []model.BlacklistTerminal
+			for _, t := range rows.terminals {
+				for _, k := range keys {
+					if t.Hash == k.Hash && t.MerchantCode == k.MerchantCode && t.TerminalCode == k.TerminalCode {
+						result = append(result, t)
+					}
+				}
+			}
+			return result, nil
+		},
+	}
+}
+
+func newDBRepoProviderWithRows(rows blacklistRows) IRepositoryProvider {
+	blacklistRepo := newBlacklistRepoWithRows(rows)
+
+	campaignRepo := &repository.CampaignMock{
+		GetCampaignsByVouchersFunc: func(
+			ctx context.Context, keys []repository.CampaignVoucherKey,
+		) ([]model.Campaign, error) {
+			return []model.Campaign{newCheckTestCampaign()}, nil
+		},
+		GetCampaignBenefitsFunc: func(ctx context.Context, campaignIDs []int64) ([]model.CampaignBenefit, error) {
+			return []model.CampaignBenefit{newCheckTestBenefit()}, nil
+		},
+	}
+
+	return NewDBRepoProvider(blacklistRepo, campaignRepo)
+}
+
+func newEmptyBlacklistRepo() *repository.BlacklistMock {
+	return &repository.BlacklistMock{
+		GetBlacklistCustomersFunc: func(
+			ctx context.Context, keys []repository.BlacklistCustomerKey,
+		) ([]model.BlacklistCustomer, error) {
+			return nil, nil
+		},
+		GetBlacklistMerchantsFunc: func(
+			ctx context.Context, keys []repository.BlacklistMerchantKey,
+		) ([]model.BlacklistMerchant, error) {
+			return nil, nil
+		},
+		GetBlacklistTerminalsFunc: func(
+			ctx context.Context, keys []repository.BlacklistTerminalKey,
+		) ([]model.BlacklistTerminal, error) {
+			return nil, nil
+		},
+	}
+}
+
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time {
+	return time.Time(c)
+}
+
+// noopProvider runs the service without a database, the repositories are stubbed
+type noopProvider struct {
+}
+
+func (noopProvider) Transact(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func (noopProvider) Readonly(ctx context.Context) context.Context {
+	return ctx
+}
+
+func newCheckTestService(repoProvider IRepositoryProvider, options ...ServiceOption) *Service {
+	provider := noopProvider{}
+	options = append([]ServiceOption{
+		WithClock(fixedClock(newTime("2022-05-15T10:00:00+07:00"))),
+	}, options...)
+	return NewService(provider, repoProvider, options...)
+}
+
+func TestService_Check__Blacklist_Status_And_Time_Window(t *testing.T) {
+	phone := "0987000111"
+	merchantCode := "MERCHANT01"
+	terminalCode := "TERMINAL01"
+
+	customer := func(
+		status model.BlacklistCustomerStatus, start sql.NullTime, end sql.NullTime,
+	) blacklistRows {
+		return blacklistRows{
+			customers: []model.BlacklistCustomer{
+				{
+					Hash:      util.HashFunc(phone),
+					Phone:     phone,
+					Status:    status,
+					StartTime: start,
+					EndTime:   end,
+				},
+			},
+		}
+	}
+	merchant := func(
+		status model.BlacklistMerchantStatus, start sql.NullTime, end sql.NullTime,
+	) blacklistRows {
+		return blacklistRows{
+			merchants: []model.BlacklistMerchant{
+				{
+					Hash:         util.HashFunc(merchantCode),
+					MerchantCode: merchantCode,
+					Status:       status,
+					StartTime:    start,
+					EndTime:      end,
+				},
+			},
+		}
+	}
+	terminal := func(
+		status model.BlacklistTerminalStatus, start sql.NullTime, end sql.NullTime,
+	) blacklistRows {
+		return blacklistRows{
+			terminals: []model.BlacklistTerminal{
+				{
+					Hash:         util.BlacklistTerminalHash(merchantCode, terminalCode),
+					MerchantCode: merchantCode,
+					TerminalCode: terminalCode,
+					Status:       status,
+					StartTime:    start,
+					EndTime:      end,
+				},
+			},
+		}
+	}
+
+	noTime := sql.NullTime{}
+	before := newNullTime("2022-05-14T10:00:00+07:00")
+	reqTime := newNullTime("2022-05-15T10:00:00+07:00")
+	after := newNullTime("2022-05-16T10:00:00+07:00")
+
+	customerActive := model.BlacklistCustomerStatusActive
+	customerInactive := model.BlacklistCustomerStatusInactive
+	merchantActive := model.BlacklistMerchantStatusActive
+	merchantInactive := model.BlacklistMerchantStatusInactive
+	terminalActive := model.BlacklistTerminalStatusActive
+	terminalInactive := model.BlacklistTerminalStatusInactive
+
+	table := []struct {
+		name string
+		rows blacklistRows
+		err  error
+	}{
+		{name: "no-blacklist", rows: blacklistRows{}, err: nil},
+
+		{name: "customer-active", rows: customer(customerActive, noTime, noTime), err: ErrCustomerInBlacklist},
+		{name: "customer-inactive", rows: customer(customerInactive, noTime, noTime), err: nil},
+		{name: "customer-in-window", rows: customer(customerActive, before, after), err: ErrCustomerInBlacklist},
+		{name: "customer-start-at-req-time", rows: customer(customerActive, reqTime, after), err: ErrCustomerInBlacklist},
+		{name: "customer-not-started", rows: customer(customerActive, after, noTime), err: nil},
+		{name: "customer-ended", rows: customer(customerActive, noTime, before), err: nil},
+		{name: "customer-end-at-req-time", rows: customer(customerActive, before, reqTime), err: nil},
+		{name: "customer-inactive-in-window", rows: customer(customerInactive, before, after), err: nil},
+
+		{name: "merchant-active", rows: merchant(merchantActive, noTime, noTime), err: ErrMerchantInBlacklist},
+		{name: "merchant-inactive", rows: merchant(merchantInactive, noTime, noTime), err: nil},
+		{name: "merchant-in-window", rows: merchant(merchantActive, before, after), err: ErrMerchantInBlacklist},
+		{name: "merchant-not-started", rows: merchant(merchantActive, after, noTime), err: nil},
+		{name: "merchant-ended", rows: merchant(merchantActive, noTime, before), err: nil},
+		{name: "merchant-end-at-req-time", rows: merchant(merchantActive, before, reqTime), err: nil},
+
+		{name: "terminal-active", rows: terminal(terminalActive, noTime, noTime), err: ErrTerminalInBlacklist},
+		{name: "terminal-inactive", rows: terminal(terminalInactive, noTime, noTime), err: nil},
+		{name: "terminal-in-window", rows: terminal(terminalActive, before, after), err: ErrTerminalInBlacklist},
+		{name: "terminal-not-started", rows: terminal(terminalActive, after, noTime), err: nil},
+		{name: "terminal-ended", rows: terminal(terminalActive, noTime, before), err: nil},
+	}
+
+	repoProviders := []struct {
+		name        string
+		newProvider func(rows blacklistRows) IRepositoryProvider
+	}{
+		{name: "dhash", newProvider: newDHashRepoProvider},
+		{name: "db", newProvider: newDBRepoProviderWithRows},
+	}
+
+	for _, p := range repoProviders {
+		for _, e := range table {
+			t.Run(p.name+"/"+e.name, func(t *testing.T) {
+				s := newCheckTestService(p.newProvider(e.rows))
+
+				outputs := s.Check(newContext(), []Input{newCheckTestInput()})
+				assert.Equal(t, 1, len(outputs))
+				assert.Equal(t, e.err, outputs[0].Err)
+
+				if e.err == nil {
+					assert.Equal(t, "10000", outputs[0].DiscountAmount.String())
+				}
+			})
+		}
+	}
+}
+
+func newBlacklistTerminalRows(merchantCode string, terminalCode string) blacklistRows {
+	return blacklistRows{
+		terminals: []model.BlacklistTerminal{
+			{
+				Hash:         util.BlacklistTerminalHash(merchantCode, terminalCode),
+				MerchantCode: merchantCode,
+				TerminalCode: terminalCode,
+				Status:       model.BlacklistTerminalStatusActive,
+			},
+		},
+	}
+}
+
+func newCheckTestInputWithTerminal(merchantCode string, terminalCode string) Input {
+	input := newCheckTestInput()
+	input.MerchantCode = merchantCode
+	input.TerminalCode = terminalCode
+	return input
+}
+
+func TestService_Check__Blacklisted_And_Not_Blacklisted_Terminals(t *testing.T) {
+	rows := newBlacklistTerminalRows("MERCHANT01", "TERMINAL01")
+
+	inputs := []Input{
+		newCheckTestInputWithTerminal("MERCHANT01", "TERMINAL01"),
+		newCheckTestInputWithTerminal("MERCHANT01", "TERMINAL02"),
+		newCheckTestInputWithTerminal("MERCHANT02", "TERMINAL01"),
+	}
+
+	repoProviders := []struct {
+		name        string
+		newProvider func(rows blacklistRows) IRepositoryProvider
+	}{
+		{name: "dhash", newProvider: newDHashRepoProvider},
+		{name: "db", newProvider: newDBRepoProviderWithRows},
+	}
+
+	for _, p := range repoProviders {
+		t.Run(p.name, func(t *testing.T) {
+			s := newCheckTestService(p.newProvider(rows))
+
+			outputs := s.Check(newContext(), inputs)
+			assert.Equal(t, 3, len(outputs))
+
+			assert.Equal(t, ErrTerminalInBlacklist, outputs[0].Err)
+			assert.Equal(t, int64(0), outputs[0].CampaignID)
+			assert.Equal(t, "0", outputs[0].DiscountAmount.String())
+
+			for _, output := range outputs[1:] {
+				assert.Equal(t, nil, output.Err)
+				assert.Equal(t, int64(11), output.CampaignID)
+				assert.Equal(t, "10000", output.DiscountAmount.String())
+			}
+		})
+	}
+}
+
+func TestService_Check__Blacklist_Terminals__Get_In_Single_Batch(t *testing.T) {
+	rows := newBlacklistTerminalRows("MERCHANT01", "TERMINAL01")
+
+	blacklistRepo := newBlacklistRepoWithRows(rows)
+	campaignRepo := &repository.CampaignMock{
+		GetCampaignsByVouchersFunc: func(
+			ctx context.Context, keys []repository.CampaignVoucherKey,
+		) ([]model.Campaign, error) {
+			return []model.Campaign{newCheckTestCampaign()}, nil
+		},
+		GetCampaignBenefitsFunc: func(ctx context.Context, campaignIDs []int64) ([]model.CampaignBenefit, error) {
+			return []model.CampaignBenefit{newCheckTestBenefit()}, nil
+		},
+	}
+	s := newCheckTestService(NewDBRepoProvider(blacklistRepo, campaignRepo))
+
+	outputs := s.Check(newContext(), []Input{
+		newCheckTestInputWithTerminal("MERCHANT01", "TERMINAL01"),
+		newCheckTestInputWithTerminal("MERCHANT01", "TERMINAL02"),
+	})
+	assert.Equal(t, 2, len(outputs))
+	assert.Equal(t, ErrTerminalInBlacklist, outputs[0].Err)
+	assert.Equal(t, nil, outputs[1].Err)
+
+	assert.Equal(t, 1, len(blacklistRepo.GetBlacklistTerminalsCalls()))
+	assert.Equal(t, []repository.BlacklistTerminalKey{
+		{
+			Hash:         util.BlacklistTerminalHash("MERCHANT01", "TERMINAL01"),
+			MerchantCode: "MERCHANT01",
+			TerminalCode: "TERMINAL01",
+		},
+		{
+			Hash:         util.BlacklistTerminalHash("MERCHANT01", "TERMINAL02"),
+			MerchantCode: "MERCHANT01",
+			TerminalCode: "TERMINAL02",
+		},
+	}, blacklistRepo.GetBlacklistTerminalsCalls()[0].Keys)
+}
+
+func TestService_Check__DB_Repo__Get_Blacklist_Customers_Once(t *testing.T) {
+	campaign := newCheckTestCampaign()
+	campaign.Type = model.CampaignTypeBank
+	campaign.CampaignUsageMax = sql.NullInt64{Valid: true, Int64: 100}
+
+	blacklistRepo := newEmptyBlacklistRepo()
+	campaignRepo := newCampaignRepoWithRows(campaignRows{
+		campaigns: []model.Campaign{campaign},
+		benefits:  []model.CampaignBenefit{newCheckTestBenefit()},
+		banks:     []model.CampaignBank{newCheckTestCampaignBank("BANK01")},
+	})
+	campaignRepo.GetCampaignUsagesFunc = func(ctx context.Context, campaignIDs []int64) ([]model.CampaignUsage, error) {
+		return nil, nil
+	}
+	s := newCheckTestService(NewDBRepoProvider(blacklistRepo, campaignRepo))
+
+	input := newCheckTestInput()
+	input.BankCode = "BANK01"
+
+	outputs := s.Check(newContext(), []Input{input})
+	assert.Equal(t, 1, len(outputs))
+	assert.Equal(t, nil, outputs[0].Err)
+
+	assert.Equal(t, 1, len(blacklistRepo.GetBlacklistCustomersCalls()))
+	assert.Equal(t, 1, len(blacklistRepo.GetBlacklistMerchantsCalls()))
+	assert.Equal(t, 1, len(blacklistRepo.GetBlacklistTerminalsCalls()))
+}
+
+func TestService_Check__Multiple_Inputs__Select_Each_Hash_Once(t *testing.T) {
+	customerHash := stubHashEntries(nil)
+	merchantHash := stubHashEntries(nil)
+	terminalHash := stubHashEntries(nil)
+	campaignHash := stubHashEntries(nil)
+
+	repoProvider := repoProviderFunc(func() IRepository {
+		return newRepository(&dhash.SessionMock{FinishFunc: func() {}},
+			customerHash, merchantHash, terminalHash, campaignHash,
+			&dhash.StoreMock{}, &dhash.StoreMock{}, &dhash.StoreMock{}, &dhash.StoreMock{},
+			stubHashEntries(nil), stubHashEntries(nil), stubHashEntries(nil), stubHashEntries(nil),
+		)
+	})
+	s := newCheckTestService(repoProvider)
+
+	input1 := newCheckTestInput()
+	input2 := newCheckTestInput()
+	input2.Phone = "0987000222"
+	input2.MerchantCode = "MERCHANT02"
+	input2.VoucherCode = "VOUCHER02"
+
+	outputs := s.Check(newContext(), []Input{input1, input2})
+	assert.Equal(t, []Output{
+		{Err: ErrVoucherNotFound},
+		{Err: ErrVoucherNotFound},
+	}, outputs)
+
+	assert.Equal(t, 1, len(customerHash.SelectMultiEntriesCalls()))
+	assert.Equal(t, []uint32{
+		util.HashFunc("0987000111"), util.HashFunc("0987000222"),
+	}, customerHash.SelectMultiEntriesCalls()[0].Hashes)
+
+	assert.Equal(t, 1, len(merchantHash.SelectMultiEntriesCalls()))
+	assert.Equal(t, []uint32{
+		util.HashFunc("MERCHANT01"), util.HashFunc("MERCHANT02"),
+	}, merchantHash.SelectMultiEntriesCalls()[0].Hashes)
+
+	assert.Equal(t, 1, len(terminalHash.SelectMultiEntriesCalls()))
+	assert.Equal(t, 2, len(terminalHash.SelectMultiEntriesCalls()[0].Hashes))
+
+	assert.Equal(t, 1, len(campaignHash.SelectMultiEntriesCalls()))
+	assert.Equal(t, []uint32{
+		util.HashFunc("VOUCHER01"), util.HashFunc("VOUCHER02"),
+	}, campaignHash.SelectMultiEntriesCalls()[0].Hashes)
+}
+
+func TestService_Check__Customer_Usage_Limits(t *testing.T) {
+	campaign := newCheckTestCampaign()
+	campaign.CustomerUsageMax = 3
+	campaign.PeriodUsageType = model.PeriodUsageTypeDaily
+	campaign.PeriodCustomerUsageMax = sql.NullInt64{Valid: true, Int64: 2}
+	campaign.PeriodTermType = model.PeriodTermTypeMerchant
+
+	input := newCheckTestInput()
+
+	table := []struct {
+		name         string
+		customerUsed int64
+		periodUsed   int64
+		err          error
+	}{
+		{
+			name:         "ok",
+			customerUsed: 2,
+			periodUsed:   1,
+			err:          nil,
+		},
+		{
+			name:         "customer-usage-exhausted",
+			customerUsed: 3,
+			periodUsed:   1,
+			err:          ErrCustomerUsageExhausted,
+		},
+		{
+			name:         "period-usage-exhausted",
+			customerUsed: 2,
+			periodUsed:   2,
+			err:          ErrCustomerPeriodUsageExhausted,
+		},
+	}
+	for _, e := range table {
+		t.Run(e.name, func(t *testing.T) {
+			campaignRepo := &repository.CampaignMock{
+				GetCampaignsByVouchersFunc: func(
+					ctx context.Context, keys []repository.CampaignVoucherKey,
+				) ([]model.Campaign, error) {
+					return []model.Campaign{campaign}, nil
+				},
+				GetCampaignBenefitsFunc: func(ctx context.Context, campaignIDs []int64) ([]model.CampaignBenefit, error) {
+					return []model.CampaignBenefit{newCheckTestBenefit()}, nil
+				},
+				GetCampaignCustomerUsagesFunc: func(
+					ctx context.Context, keys []repository.CampaignCustomerKey,
+				) ([]model.CampaignCustomerUsage, error) {
+					return []model.CampaignCustomerUsage{
+						{CampaignID: keys[0].CampaignID, Hash: keys[0].Hash, Phone: keys[0].Phone, UsageNum: e.customerUsed},
+					}, nil
+				},
+				GetCampaignPeriodUsagesFunc: func(
+					ctx context.Context, keys []repository.CampaignPeriodUsageKey,
+				) ([]model.CampaignPeriodUsage, error) {
+					return []model.CampaignPeriodUsage{
+						{
+							CampaignID: keys[0].CampaignID,
+							Hash:       keys[0].Hash,
+							Phone:      keys[0].Phone,
+							TermCode:   keys[0].TermCode,
+							UsageNum:   e.periodUsed,
+							ExpiredOn:  newTime("2022-05-16T00:00:00+07:00"),
+						},
+					}, nil
+				},
+			}
+
+			s := newCheckTestService(NewDBRepoProvider(newEmptyBlacklistRepo(), campaignRepo))
+
+			outputs := s.Check(newContext(), []Input{input})
+			assert.Equal(t, 1, len(outputs))
+			assert.Equal(t, e.err, outputs[0].Err)
+			if e.err == nil {
+				assert.Equal(t, "10000", outputs[0].DiscountAmount.String())
+			}
+
+			assert.Equal(t, 1, len(campaignRepo.GetCampaignPeriodUsagesCalls()))
+			assert.Equal(t, "20220515:"+input.MerchantCode,
+				campaignRepo.GetCampaignPeriodUsagesCalls()[0].Keys[0].TermCode)
+		})
+	}
+}
+
+func TestService_Check__Customer_Usage_Max_Zero__Unlimited(t *testing.T) {
+	campaign := newCheckTestCampaign()
+	campaign.CustomerUsageMax = 0
+
+	campaignRepo := newCampaignRepoWithRows(campaignRows{
+		campaigns: []model.Campaign{campaign},
+		benefits:  []model.CampaignBenefit{newCheckTestBenefit()},
+	})
+	campaignRepo.GetCampaignCustomerUsagesFunc = func(
+		ctx context.Context, keys []repository.CampaignCustomerKey,
+	) ([]model.CampaignCustomerUsage, error) {
+		return []model.CampaignCustomerUsage{
+			{CampaignID: keys[0].CampaignID, Hash: keys[0].Hash, Phone: keys[0].Phone, UsageNum: 1000},
+		}, nil
+	}
+	s := newCheckTestService(NewDBRepoProvider(newEmptyBlacklistRepo(), campaignRepo))
+
+	outputs := s.Check(newContext(), []Input{newCheckTestInput()})
+	assert.Equal(t, 1, len(outputs))
+	assert.Equal(t, nil, outputs[0].Err)
+	assert.Equal(t, int64(11), outputs[0].CampaignID)
+	assert.Equal(t, "10000", outputs[0].DiscountAmount.String())
+
+	assert.Equal(t, 0, len(campaignRepo.GetCampaignCustomerUsagesCalls()))
+}
+
+func TestService_Check__Period_Term_Code__Computed_In_Business_Location(t *testing.T) {
+	campaign := newCheckTestCampaign()
+	campaign.PeriodUsageType = model.PeriodUsageTypeDaily
+	campaign.PeriodCustomerUsageMax = sql.NullInt64{Valid: true, Int64: 2}
+
+	ict := time.FixedZone("ICT", 7*60*60)
+
+	table := []struct {
+		name     string
+		options  []ServiceOption
+		reqTime  time.Time
+		termCode string
+	}{
+		{
+			name:     "default-utc",
+			reqTime:  newTime("2022-05-15T20:00:00Z"),
+			termCode: "20220515",
+		},
+		{
+			name:     "next-day-in-business-location",
+			options:  []ServiceOption{WithLocation(ict)},
+			reqTime:  newTime("2022-05-15T20:00:00Z"),
+			termCode: "20220516",
+		},
+		{
+			name:     "same-day-in-business-location",
+			options:  []ServiceOption{WithLocation(ict)},
+			reqTime:  newTime("2022-05-15T16:59:59Z"),
+			termCode: "20220515",
+		},
+		{
+			name:     "req-time-from-clock",
+			options:  []ServiceOption{WithLocation(ict)},
+			termCode: "20220516",
+		},
+	}
+	for _, e := range table {
+		t.Run(e.name, func(t *testing.T) {
+			campaignRepo := &repository.CampaignMock{
+				GetCampaignsByVouchersFunc: func(
+					ctx context.Context, keys []repository.CampaignVoucherKey,
+				) ([]model.Campaign, error) {
+					return []model.Campaign{campaign}, nil
+				},
+				GetCampaignBenefitsFunc: func(ctx context.Context, campaignIDs []int64) ([]model.CampaignBenefit, error) {
+					return []model.CampaignBenefit{newCheckTestBenefit()}, nil
+				},
+				GetCampaignPeriodUsagesFunc: func(
+					ctx context.Context, keys []repository.CampaignPeriodUsageKey,
+				) ([]model.CampaignPeriodUsage, error) {
+					return nil, nil
+				},
+			}
+
+			options := append([]ServiceOption{
+				WithClock(fixedClock(newTime("2022-05-15T20:00:00Z"))),
+			}, e.options...)
+			s := newCheckTestService(NewDBRepoProvider(newEmptyBlacklistRepo(), campaignRepo), options...)
+
+			input := newCheckTestInput()
+			input.ReqTime = e.reqTime
+
+			outputs := s.Check(newContext(), []Input{input})
+			assert.Equal(t, 1, len(outputs))
+			assert.Equal(t, nil, outputs[0].Err)
+
+			assert.Equal(t, 1, len(campaignRepo.GetCampaignPeriodUsagesCalls()))
+			assert.Equal(t, e.termCode, campaignRepo.GetCampaignPeriodUsagesCalls()[0].Keys[0].TermCode)
+		})
+	}
+}
+
+func TestService_Check__Campaign_Status_And_Time_Window(t *testing.T) {
+	active := newCheckTestCampaign()
+
+	inactive := newCheckTestCampaign()
+	inactive.ID = 12
+	inactive.Status = model.CampaignStatusInactive
+
+	notStarted := newCheckTestCampaign()
+	notStarted.ID = 13
+	notStarted.StartTime = newTime("2022-05-16T10:00:00+07:00")
+
+	ended := newCheckTestCampaign()
+	ended.ID = 14
+	ended.EndTime = newTime("2022-05-15T10:00:00+07:00")
+
+	table := []struct {
+		name       string
+		campaigns  []model.Campaign
+		err        error
+		campaignID int64
+	}{
+		{
+			name: "not-found",
+			err:  ErrVoucherNotFound,
+		},
+		{
+			name:       "inactive",
+			campaigns:  []model.Campaign{inactive},
+			err:        ErrCampaignInactive,
+			campaignID: inactive.ID,
+		},
+		{
+			name:       "not-started",
+			campaigns:  []model.Campaign{notStarted},
+			err:        ErrCampaignNotStarted,
+			campaignID: notStarted.ID,
+		},
+		{
+			name:       "ended",
+			campaigns:  []model.Campaign{ended},
+			err:        ErrCampaignEnded,
+			campaignID: ended.ID,
+		},
+		{
+			name:       "smallest-id-rejected-reason",
+			campaigns:  []model.Campaign{ended, inactive},
+			err:        ErrCampaignInactive,
+			campaignID: inactive.ID,
+		},
+		{
+			name:       "with-active-campaign",
+			campaigns:  []model.Campaign{inactive, notStarted, active, ended},
+			err:        nil,
+			campaignID: active.ID,
+		},
+	}
+	for _, e := range table {
+		t.Run(e.name, func(t *testing.T) {
+			campaignRepo := &repository.CampaignMock{
+				GetCampaignsByVouchersFunc: func(
+					ctx context.Context, keys []repository.CampaignVoucherKey,
+				) ([]model.Campaign, error) {
+					return e.campaigns, nil
+				},
+				GetCampaignBenefitsFunc: func(ctx context.Context, campaignIDs []int64) ([]model.CampaignBenefit, error) {
+					return []model.CampaignBenefit{newCheckTestBenefit()}, nil
+				},
+			}
+
+			s := newCheckTestService(NewDBRepoProvider(newEmptyBlacklistRepo(), campaignRepo))
+
+			outputs := s.Check(newContext(), []Input{newCheckTestInput()})
+			assert.Equal(t, 1, len(outputs))
+			assert.Equal(t, e.err, outputs[0].Err)
+			assert.Equal(t, e.campaignID, outputs[0].CampaignID)
+			if e.err == nil {
+				assert.Equal(t, "10000", outputs[0].DiscountAmount.String())
+				assert.Equal(t, []int64{active.ID}, campaignRepo.GetCampaignBenefitsCalls()[0].CampaignIDs)
+			}
+		})
+	}
+}
+
+type selectorFunc func(candidates []CampaignCandidate) int
+
+func (f selectorFunc) Select(candidates []CampaignCandidate) int {
+	return f(candidates)
+}
+
+func TestService_Check__Select_Campaign(t *testing.T) {
+	campaign1 := newCheckTestCampaign()
+
+	campaign2 := newCheckTestCampaign()
+	campaign2.ID = 12
+	campaign2.EndTime = newTime("2022-05-18T10:00:00+07:00")
+
+	campaign3 := newCheckTestCampaign()
+	campaign3.ID = 13
+	campaign3.Status = model.CampaignStatusInactive
+
+	newCampaignRepo := func() *repository.CampaignMock {
+		return &repository.CampaignMock{
+			GetCampaignsByVouchersFunc: func(
+				ctx context.Context, keys []repository.CampaignVoucherKey,
+			) ([]model.Campaign, error) {
+				return []model.Campaign{campaign1, campaign2, campaign3}, nil
+			},
+			GetCampaignBenefitsFunc: func(ctx context.Context, campaignIDs []int64) ([]model.CampaignBenefit, error) {
+				var result []model.CampaignBenefit
+				for _, id := range campaignIDs {
+					b := newCheckTestBenefit()
+					b.CampaignID = id
+					result = append(result, b)
+				}
+				return result, nil
+			},
+		}
+	}
+
+	t.Run("default-earliest-end-time", func(t *testing.T) {
+		s := newCheckTestService(NewDBRepoProvider(newEmptyBlacklistRepo(), newCampaignRepo()))
+
+		outputs := s.Check(newContext(), []Input{newCheckTestInput()})
+		assert.Equal(t, 1, len(outputs))
+		assert.Equal(t, nil, outputs[0].Err)
+		assert.Equal(t, int64(12), outputs[0].CampaignID)
+		assert.Equal(t, "10000", outputs[0].DiscountAmount.String())
+	})
+
+	t.Run("custom-selector", func(t *testing.T) {
+		var candidateIDs []int64
+		selector := selectorFunc(func(candidates []CampaignCandidate) int {
+			for _, c := range candidates {
+				candidateIDs = append(candidateIDs, c.Campaign.ID)
+			}
+			return 0
+		})
+
+		s := newCheckTestService(
+			NewDBRepoProvider(newEmptyBlacklistRepo(), newCampaignRepo()),
+			WithCampaignSelector(selector),
+		)
+
+		outputs := s.Check(newContext(), []Input{newCheckTestInput()})
+		assert.Equal(t, 1, len(outputs))
+		assert.Equal(t, nil, outputs[0].Err)
+		assert.Equal(t, int64(11), outputs[0].CampaignID)
+		assert.Equal(t, []int64{11, 12}, candidateIDs)
+	})
+}
+
+func TestService_Check__Req_Time(t *testing.T) {
+	table := []struct {
+		name    string
+		reqTime string
+		err     error
+	}{
+		{
+			name:    "default-to-clock",
+			reqTime: "",
+			err:     nil,
+		},
+		{
+			name:    "slightly-ahead",
+			reqTime: "2022-05-15T10:05:00+07:00",
+			err:     nil,
+		},
+		{
+			name:    "too-far-ahead",
+			reqTime: "2022-05-15T10:05:01+07:00",
+			err:     ErrInvalidReqTime,
+		},
+		{
+			name:    "one-day-behind",
+			reqTime: "2022-05-14T10:00:00+07:00",
+			err:     nil,
+		},
+		{
+			name:    "too-far-behind",
+			reqTime: "2022-05-14T09:59:59+07:00",
+			err:     ErrInvalidReqTime,
+		},
+		{
+			name:    "unix-epoch",
+			reqTime: "1970-01-01T00:00:00Z",
+			err:     ErrInvalidReqTime,
+		},
+	}
+	for _, e := range table {
+		t.Run(e.name, func(t *testing.T) {
+			campaignRepo := &repository.CampaignMock{
+				GetCampaignsByVouchersFunc: func(
+					ctx context.Context, keys []repository.CampaignVoucherKey,
+				) ([]model.Campaign, error) {
+					return []model.Campaign{newCheckTestCampaign()}, nil
+				},
+				GetCampaignBenefitsFunc: func(ctx context.Context, campaignIDs []int64) ([]model.CampaignBenefit, error) {
+					return []model.CampaignBenefit{newCheckTestBenefit()}, nil
+				},
+			}
+			s := newCheckTestService(NewDBRepoProvider(newEmptyBlacklistRepo(), campaignRepo))
+
+			input := newCheckTestInput()
+			input.ReqTime = time.Time{}
+			if e.reqTime != "" {
+				input.ReqTime = newTime(e.reqTime)
+			}
+
+			outputs := s.Check(newContext(), []Input{input})
+			assert.Equal(t, 1, len(outputs))
+			assert.Equal(t, e.err, outputs[0].Err)
+			if e.err != nil {
+				assert.Equal(t, 0, len(campaignRepo.GetCampaignsByVouchersCalls()))
+			}
+		})
+	}
+}
+
+// campaignRows are the rows stored in the campaign tables
+type campaignRows struct {
+	campaigns []model.Campaign
+	benefits  []model.CampaignBenefit
+	merchants []model.CampaignMerchant
+	terminals []model.CampaignTerminal
+	banks     []model.CampaignBank
+	customers []model.CampaignCustomer
+}
+
+func newCampaignRepoWithRows(rows campaignRows) *repository.CampaignMock {
+	return &repository.CampaignMock{
+		GetCampaignsByVouchersFunc: func(
+			ctx context.Context, keys []repository.CampaignVoucherKey,
+		) ([]model.Campaign, error) {
+			var result []model.Campaign
+			for _, c := range rows.campaigns {
+				for _, k := range keys {
+					if c.VoucherHash == k.VoucherHash && c.VoucherCode == k.VoucherCode {
+						result = append(result, c)
+					}
+				}
+			}
+			return result, nil
+		},
+		GetCampaignBenefitsFunc: func(ctx context.Context, campaignIDs []int64) ([]model.CampaignBenefit, error) {
+			var result []model.CampaignBenefit
+			for _, b := range rows.benefits {
+				for _, id := range campaignIDs {
+					if b.CampaignID == id {
+						result = append(result, b)
+					}
+				}
+			}
+			return result, nil
+		},
+		GetCampaignMerchantsFunc: func(
+			ctx context.Context, keys []repository.CampaignMerchantKey,
+		) ([]model.CampaignMerchant, error) {
+			var result []model.CampaignMerchant
+			for _, m := range rows.merchants {
+				for _, k := range keys {
+					if m.CampaignID == k.CampaignID && m.Hash == k.Hash && m.MerchantCode == k.MerchantCode {
+						result = append(result, m)
+					}
+				}
+			}
+			return result, nil
+		},
+		GetCampaignTerminalsFunc: func(
+			ctx context.Context, keys []repository.CampaignTerminalKey,
+		) ([]model.CampaignTerminal, error) {
+			var result []model.CampaignTerminal
+			for _, t := range rows.terminals {
+				for _, k := range keys {
+					if t.CampaignID == k.CampaignID && t.Hash == k.Hash &&
+						t.MerchantCode == k.MerchantCode && t.TerminalCode == k.TerminalCode {
+						result = append(result, t)
+					}
+				}
+			}
+			return result, nil
+		},
+		GetCampaignBanksFunc: func(
+			ctx context.Context, keys []repository.CampaignBankKey,
+		) ([]model.CampaignBank, error) {
+			var result []model.CampaignBank
+			for _, b := range rows.banks {
+				for _, k := range keys {
+					if b.CampaignID == k.CampaignID && b.Hash == k.Hash && b.BankCode == k.BankCode {
+						result = append(result, b)
+					}
+				}
+			}
+			return result, nil
+		},
+		GetCampaignCustomersFunc: func(
+			ctx context.Context, keys []repository.CampaignCustomerKey,
+		) ([]model.CampaignCustomer, error) {
+			var result []model.CampaignCustomer
+			for _, c := range rows.customers {
+				for _, k := range keys {
+					if c.CampaignID == k.CampaignID && c.Hash == k.Hash && c.Phone == k.Phone {
+						result = append(result, c)
+					}
+				}
+			}
+			return result, nil
+		},
+	}
+}
+
+func TestService_Check__Discount_Amount(t *testing.T) {
+	lowTier := newBenefit(1, "0", "10", "50000")
+	highTier := newBenefit(2, "1000000", "20", "300000")
+	notStarted := newBenefit(3, "0", "50", "500000")
+	notStarted.StartTime = newTime("2022-05-16T10:00:00+07:00")
+
+	table := []struct {
+		name     string
+		benefits []model.CampaignBenefit
+		amount   string
+		discount string
+		err      error
+	}{
+		{
+			name:     "percent-of-amount",
+			benefits: []model.CampaignBenefit{lowTier},
+			amount:   "100000",
+			discount: "10000",
+		},
+		{
+			name:     "capped-by-max-discount",
+			benefits: []model.CampaignBenefit{lowTier},
+			amount:   "800000",
+			discount: "50000",
+		},
+		{
+			name:     "truncated-to-two-decimals",
+			benefits: []model.CampaignBenefit{lowTier},
+			amount:   "12345.67",
+			discount: "1234.56",
+		},
+		{
+			name:     "zero-amount",
+			benefits: []model.CampaignBenefit{lowTier},
+			amount:   "0",
+			discount: "0",
+		},
+		{
+			name:     "higher-tier",
+			benefits: []model.CampaignBenefit{lowTier, highTier},
+			amount:   "1000000",
+			discount: "200000",
+		},
+		{
+			name:     "higher-tier-capped",
+			benefits: []model.CampaignBenefit{lowTier, highTier},
+			amount:   "2000000",
+			discount: "300000",
+		},
+		{
+			name:     "lower-tier-below-higher-min-amount",
+			benefits: []model.CampaignBenefit{highTier, lowTier},
+			amount:   "999999",
+			discount: "50000",
+		},
+		{
+			name:     "not-started-benefit-ignored",
+			benefits: []model.CampaignBenefit{lowTier, notStarted},
+			amount:   "100000",
+			discount: "10000",
+		},
+		{
+			name:     "below-min-amount",
+			benefits: []model.CampaignBenefit{highTier},
+			amount:   "100000",
+			err:      ErrNoApplicableBenefit,
+		},
+		{
+			name:     "only-not-started-benefit",
+			benefits: []model.CampaignBenefit{notStarted},
+			amount:   "100000",
+			err:      ErrNoApplicableBenefit,
+		},
+		{
+			name:   "no-benefits",
+			amount: "100000",
+			err:    ErrNoApplicableBenefit,
+		},
+	}
+
+	for _, e := range table {
+		t.Run(e.name, func(t *testing.T) {
+			campaignRepo := newCampaignRepoWithRows(campaignRows{
+				campaigns: []model.Campaign{newCheckTestCampaign()},
+				benefits:  e.benefits,
+			})
+			s := newCheckTestService(NewDBRepoProvider(newEmptyBlacklistRepo(), campaignRepo))
+
+			input := newCheckTestInput()
+			input.Amount = newDecimal(e.amount)
+
+			outputs := s.Check(newContext(), []Input{input})
+			assert.Equal(t, 1, len(outputs))
+			assert.Equal(t, e.err, outputs[0].Err)
+			assert.Equal(t, int64(11), outputs[0].CampaignID)
+
+			assert.Equal(t, 1, len(campaignRepo.GetCampaignBenefitsCalls()))
+			assert.Equal(t, []int64{11}, campaignRepo.GetCampaignBenefitsCalls()[0].CampaignIDs)
+
+			if e.err != nil {
+				reason, ok := BusinessErrorReason(outputs[0].Err)
+				assert.Equal(t, true, ok)
+				assert.Equal(t, ReasonNoApplicableBenefit, reason)
+				assert.Equal(t, "0", outputs[0].DiscountAmount.String())
+				return
+			}
+			assert.Equal(t, e.discount, outputs[0].DiscountAmount.String())
+		})
+	}
+}
+
+func TestService_Check__Discount_Amount__Multiple_Inputs__Get_Benefits_Once(t *testing.T) {
+	campaign2 := newCheckTestCampaign()
+	campaign2.ID = 12
+	campaign2.VoucherCode = "VOUCHER02"
+	campaign2.VoucherHash = util.HashFunc("VOUCHER02")
+
+	benefit2 := newBenefit(2, "0", "20", "50000")
+	benefit2.CampaignID = 12
+
+	campaignRepo := newCampaignRepoWithRows(campaignRows{
+		campaigns: []model.Campaign{newCheckTestCampaign(), campaign2},
+		benefits:  []model.CampaignBenefit{newCheckTestBenefit(), benefit2},
+	})
+	s := newCheckTestService(NewDBRepoProvider(newEmptyBlacklistRepo(), campaignRepo))
+
+	input1 := newCheckTestInput()
+	input2 := newCheckTestInput()
+	input2.Amount = newDecimal("200000")
+	input3 := newCheckTestInput()
+	input3.VoucherCode = "VOUCHER02"
+
+	outputs := s.Check(newContext(), []Input{input1, input2, input3})
+	assert.Equal(t, 3, len(outputs))
+	for _, output := range outputs {
+		assert.Equal(t, nil, output.Err)
+	}
+	assert.Equal(t, int64(11), outputs[0].CampaignID)
+	assert.Equal(t, "10000", outputs[0].DiscountAmount.String())
+	assert.Equal(t, int64(11), outputs[1].CampaignID)
+	assert.Equal(t, "20000", outputs[1].DiscountAmount.String())
+	assert.Equal(t, int64(12), outputs[2].CampaignID)
+	assert.Equal(t, "20000", outputs[2].DiscountAmount.String())
+
+	assert.Equal(t, 1, len(campaignRepo.GetCampaignsByVouchersCalls()))
+	assert.Equal(t, 2, len(campaignRepo.GetCampaignsByVouchersCalls()[0].Keys))
+
+	assert.Equal(t, 1, len(campaignRepo.GetCampaignBenefitsCalls()))
+	assert.Equal(t, []int64{11, 12}, campaignRepo.GetCampaignBenefitsCalls()[0].CampaignIDs)
+}
+
+func newCheckTestCampaignMerchant(merchantCode string, allTerminals bool) model.CampaignMerchant {
+	return model.CampaignMerchant{
+		CampaignID:   11,
+		Hash:         util.CampaignMerchantHash(11, merchantCode),
+		MerchantCode: merchantCode,
+		Status:       model.CampaignMerchantStatusActive,
+		AllTerminals: allTerminals,
+	}
+}
+
+func newCheckTestCampaignTerminal(merchantCode string, terminalCode string) model.CampaignTerminal {
+	return model.CampaignTerminal{
+		CampaignID:   11,
+		Hash:         util.CampaignMerchantHash(11, merchantCode),
+		MerchantCode: merchantCode,
+		TerminalCode: terminalCode,
+		Status:       model.CampaignTerminalStatusActive,
+	}
+}
+
+func TestService_Check__Merchant_And_Terminal_Eligibility(t *testing.T) {
+	allTerminals := newCheckTestCampaignMerchant("MERCHANT01", true)
+	someTerminals := newCheckTestCampaignMerchant("MERCHANT01", false)
+	otherMerchant := newCheckTestCampaignMerchant("MERCHANT02", true)
+
+	inactiveMerchant := allTerminals
+	inactiveMerchant.Status = model.CampaignMerchantStatusInactive
+
+	endedMerchant := allTerminals
+	endedMerchant.EndTime = newNullTime("2022-05-15T10:00:00+07:00")
+
+	terminal := newCheckTestCampaignTerminal("MERCHANT01", "TERMINAL01")
+	otherTerminal := newCheckTestCampaignTerminal("MERCHANT01", "TERMINAL02")
+
+	inactiveTerminal := terminal
+	inactiveTerminal.Status = model.CampaignTerminalStatusInactive
+
+	notStartedTerminal := terminal
+	notStartedTerminal.StartTime = newNullTime("2022-05-15T10:00:01+07:00")
+
+	table := []struct {
+		name         string
+		allMerchants bool
+		merchants    []model.CampaignMerchant
+		terminals    []model.CampaignTerminal
+		err          error
+		reason       Reason
+	}{
+		{
+			name:         "all-merchants",
+			allMerchants: true,
+		},
+		{
+			name:   "merchant-not-enrolled",
+			err:    ErrMerchantNotEligible,
+			reason: ReasonMerchantNotEligible,
+		},
+		{
+			name:      "other-merchant-enrolled",
+			merchants: []model.CampaignMerchant{otherMerchant},
+			err:       ErrMerchantNotEligible,
+			reason:    ReasonMerchantNotEligible,
+		},
+		{
+			name:      "merchant-with-all-terminals",
+			merchants: []model.CampaignMerchant{allTerminals},
+		},
+		{
+			name:      "merchant-inactive",
+			merchants: []model.CampaignMerchant{inactiveMerchant},
+			err:       ErrMerchantNotEligible,
+			reason:    ReasonMerchantNotEligible,
+		},
+		{
+			name:      "merchant-ended",
+			merchants: []model.CampaignMerchant{endedMerchant},
+			err:       ErrMerchantNotEligible,
+			reason:    ReasonMerchantNotEligible,
+		},
+		{
+			name:      "terminal-enrolled",
+			merchants: []model.CampaignMerchant{someTerminals},
+			terminals: []model.CampaignTerminal{terminal},
+		},
+		{
+			name:      "terminal-not-enrolled",
+			merchants: []model.CampaignMerchant{someTerminals},
+			terminals: []model.CampaignTerminal{otherTerminal},
+			err:       ErrTerminalNotEligible,
+			reason:    ReasonTerminalNotEligible,
+		},
+		{
+			name:      "terminal-inactive",
+			merchants: []model.CampaignMerchant{someTerminals},
+			terminals: []model.CampaignTerminal{inactiveTerminal},
+			err:       ErrTerminalNotEligible,
+			reason:    ReasonTerminalNotEligible,
+		},
+		{
+			name:      "terminal-not-started",
+			merchants: []model.CampaignMerchant{someTerminals},
+			terminals: []model.CampaignTerminal{notStartedTerminal},
+			err:       ErrTerminalNotEligible,
+			reason:    ReasonTerminalNotEligible,
+		},
+		{
+			name:      "terminal-of-merchant-with-all-terminals-not-needed",
+			merchants: []model.CampaignMerchant{allTerminals},
+			terminals: []model.CampaignTerminal{inactiveTerminal},
+		},
+	}
+
+	for _, e := range table {
+		t.Run(e.name, func(t *testing.T) {
+			campaign := newCheckTestCampaign()
+			campaign.AllMerchants = e.allMerchants
+
+			campaignRepo := newCampaignRepoWithRows(campaignRows{
+				campaigns: []model.Campaign{campaign},
+				benefits:  []model.CampaignBenefit{newCheckTestBenefit()},
+				merchants: e.merchants,
+				terminals: e.terminals,
+			})
+			s := newCheckTestService(NewDBRepoProvider(newEmptyBlacklistRepo(), campaignRepo))
+
+			outputs := s.Check(newContext(), []Input{newCheckTestInput()})
+			assert.Equal(t, 1, len(outputs))
+			assert.Equal(t, e.err, outputs[0].Err)
+			assert.Equal(t, int64(11), outputs[0].CampaignID)
+
+			if e.err != nil {
+				reason, ok := BusinessErrorReason(outputs[0].Err)
+				assert.Equal(t, true, ok)
+				assert.Equal(t, e.reason, reason)
+				assert.Equal(t, "0", outputs[0].DiscountAmount.String())
+			} else {
+				assert.Equal(t, "10000", outputs[0].DiscountAmount.String())
+			}
+
+			if e.allMerchants {
+				assert.Equal(t, 0, len(campaignRepo.GetCampaignMerchantsCalls()))
+				assert.Equal(t, 0, len(campaignRepo.GetCampaignTerminalsCalls()))
+				return
+			}
+
+			assert.Equal(t, 1, len(campaignRepo.GetCampaignMerchantsCalls()))
+			assert.Equal(t, []repository.CampaignMerchantKey{
+				{
+					CampaignID:   11,
+					Hash:         util.CampaignMerchantHash(11, "MERCHANT01"),
+					MerchantCode: "MERCHANT01",
+				},
+			}, campaignRepo.GetCampaignMerchantsCalls()[0].Keys)
+
+			assert.Equal(t, 1, len(campaignRepo.GetCampaignTerminalsCalls()))
+			assert.Equal(t, []repository.CampaignTerminalKey{
+				{
+					CampaignID:   11,
+					Hash:         util.CampaignMerchantHash(11, "MERCHANT01"),
+					MerchantCode: "MERCHANT01",
+					TerminalCode: "TERMINAL01",
+				},
+			}, campaignRepo.GetCampaignTerminalsCalls()[0].Keys)
+		})
+	}
+}
+
+func TestService_Check__Merchant_And_Terminal_Eligibility__Multiple_Inputs__Get_In_Single_Batch(t *testing.T) {
+	campaign := newCheckTestCampaign()
+	campaign.AllMerchants = false
+
+	campaignRepo := newCampaignRepoWithRows(campaignRows{
+		campaigns: []model.Campaign{campaign},
+		benefits:  []model.CampaignBenefit{newCheckTestBenefit()},
+		merchants: []model.CampaignMerchant{
+			newCheckTestCampaignMerchant("MERCHANT01", false),
+		},
+		terminals: []model.CampaignTerminal{
+			newCheckTestCampaignTerminal("MERCHANT01", "TERMINAL01"),
+		},
+	})
+	s := newCheckTestService(NewDBRepoProvider(newEmptyBlacklistRepo(), campaignRepo))
+
+	outputs := s.Check(newContext(), []Input{
+		newCheckTestInputWithTerminal("MERCHANT01", "TERMINAL01"),
+		newCheckTestInputWithTerminal("MERCHANT01", "TERMINAL02"),
+		newCheckTestInputWithTerminal("MERCHANT02", "TERMINAL01"),
+	})
+	assert.Equal(t, 3, len(outputs))
+	assert.Equal(t, nil, outputs[0].Err)
+	assert.Equal(t, "10000", outputs[0].DiscountAmount.String())
+	assert.Equal(t, ErrTerminalNotEligible, outputs[1].Err)
+	assert.Equal(t, ErrMerchantNotEligible, outputs[2].Err)
+
+	assert.Equal(t, 1, len(campaignRepo.GetCampaignMerchantsCalls()))
+	assert.Equal(t, []repository.CampaignMerchantKey{
+		{CampaignID: 11, Hash: util.CampaignMerchantHash(11, "MERCHANT01"), MerchantCode: "MERCHANT01"},
+		{CampaignID: 11, Hash: util.CampaignMerchantHash(11, "MERCHANT02"), MerchantCode: "MERCHANT02"},
+	}, campaignRepo.GetCampaignMerchantsCalls()[0].Keys)
+
+	assert.Equal(t, 1, len(campaignRepo.GetCampaignTerminalsCalls()))
+	assert.Equal(t, 3, len(campaignRepo.GetCampaignTerminalsCalls()[0].Keys))
+}
+
+func newCheckTestCampaignBank(bankCode string) model.CampaignBank {
+	return model.CampaignBank{
+		CampaignID: 11,
+		Hash:       util.CampaignBankHash(11, bankCode),
+		BankCode:   bankCode,
+		Status:     model.CampaignBankStatusActive,
+	}
+}
+
+func TestService_Check__Bank_Eligibility(t *testing.T) {
+	bank := newCheckTestCampaignBank("BANK01")
+	otherBank := newCheckTestCampaignBank("BANK02")
+
+	inactiveBank := bank
+	inactiveBank.Status = model.CampaignBankStatusInactive
+
+	notStartedBank := bank
+	notStartedBank.StartTime = newNullTime("2022-05-16T10:00:00+07:00")
+
+	endedBank := bank
+	endedBank.EndTime = newNullTime("2022-05-15T10:00:00+07:00")
+
+	inWindowBank := bank
+	inWindowBank.StartTime = newNullTime("2022-05-15T10:00:00+07:00")
+	inWindowBank.EndTime = newNullTime("2022-05-16T10:00:00+07:00")
+
+	table := []struct {
+		name         string
+		campaignType model.CampaignType
+		banks        []model.CampaignBank
+		err          error
+	}{
+		{
+			name:         "merchant-campaign-not-check-bank",
+			campaignType: model.CampaignTypeMerchant,
+		},
+		{
+			name:         "bank-enrolled",
+			campaignType: model.CampaignTypeBank,
+			banks:        []model.CampaignBank{bank},
+		},
+		{
+			name:         "bank-in-window",
+			campaignType: model.CampaignTypeBank,
+			banks:        []model.CampaignBank{inWindowBank},
+		},
+		{
+			name:         "bank-not-enrolled",
+			campaignType: model.CampaignTypeBank,
+			err:          ErrBankNotEligible,
+		},
+		{
+			name:         "other-bank-enrolled",
+			campaignType: model.CampaignTypeBank,
+			banks:        []model.CampaignBank{otherBank},
+			err:          ErrBankNotEligible,
+		},
+		{
+			name:         "bank-inactive",
+			campaignType: model.CampaignTypeBank,
+			banks:        []model.CampaignBank{inactiveBank},
+			err:          ErrBankNotEligible,
+		},
+		{
+			name:         "bank-not-started",
+			campaignType: model.CampaignTypeBank,
+			banks:        []model.CampaignBank{notStartedBank},
+			err:          ErrBankNotEligible,
+		},
+		{
+			name:         "bank-end-at-req-time",
+			campaignType: model.CampaignTypeBank,
+			banks:        []model.CampaignBank{endedBank},
+			err:          ErrBankNotEligible,
+		},
+	}
+
+	for _, e := range table {
+		t.Run(e.name, func(t *testing.T) {
+			campaign := newCheckTestCampaign()
+			campaign.Type = e.campaignType
+
+			campaignRepo := newCampaignRepoWithRows(campaignRows{
+				campaigns: []model.Campaign{campaign},
+				benefits:  []model.CampaignBenefit{newCheckTestBenefit()},
+				banks:     e.banks,
+			})
+			s := newCheckTestService(NewDBRepoProvider(newEmptyBlacklistRepo(), campaignRepo))
+
+			input := newCheckTestInput()
+			input.BankCode = "BANK01"
+
+			outputs := s.Check(newContext(), []Input{input})
+			assert.Equal(t, 1, len(outputs))
+			assert.Equal(t, e.err, outputs[0].Err)
+			assert.Equal(t, int64(11), outputs[0].CampaignID)
+
+			if e.err != nil {
+				reason, ok := BusinessErrorReason(outputs[0].Err)
+				assert.Equal(t, true, ok)
+				assert.Equal(t, ReasonBankNotEligible, reason)
+				assert.Equal(t, "0", outputs[0].DiscountAmount.String())
+			} else {
+				assert.Equal(t, "10000", outputs[0].DiscountAmount.String())
+			}
+
+			if e.campaignType != model.CampaignTypeBank {
+				assert.Equal(t, 0, len(campaignRepo.GetCampaignBanksCalls()))
+				return
+			}
+
+			assert.Equal(t, 1, len(campaignRepo.GetCampaignBanksCalls()))
+			assert.Equal(t, []repository.CampaignBankKey{
+				{
+					CampaignID: 11,
+					Hash:       util.CampaignBankHash(11, "BANK01"),
+					BankCode:   "BANK01",
+				},
+			}, campaignRepo.GetCampaignBanksCalls()[0].Keys)
+		})
+	}
+}
+
+func TestService_Check__Bank_Eligibility__Multiple_Inputs__Get_In_Single_Batch(t *testing.T) {
+	campaign := newCheckTestCampaign()
+	campaign.Type = model.CampaignTypeBank
+
+	campaignRepo := newCampaignRepoWithRows(campaignRows{
+		campaigns: []model.Campaign{campaign},
+		benefits:  []model.CampaignBenefit{newCheckTestBenefit()},
+		banks:     []model.CampaignBank{newCheckTestCampaignBank("BANK01")},
+	})
+	s := newCheckTestService(NewDBRepoProvider(newEmptyBlacklistRepo(), campaignRepo))
+
+	input1 := newCheckTestInput()
+	input1.BankCode = "BANK01"
+	input2 := newCheckTestInput()
+	input2.BankCode = "BANK02"
+	input3 := newCheckTestInput()
+	input3.BankCode = "BANK01"
+
+	outputs := s.Check(newContext(), []Input{input1, input2, input3})
+	assert.Equal(t, 3, len(outputs))
+	assert.Equal(t, nil, outputs[0].Err)
+	assert.Equal(t, ErrBankNotEligible, outputs[1].Err)
+	assert.Equal(t, nil, outputs[2].Err)
+
+	assert.Equal(t, 1, len(campaignRepo.GetCampaignBanksCalls()))
+	assert.Equal(t, []repository.CampaignBankKey{
+		{CampaignID: 11, Hash: util.CampaignBankHash(11, "BANK01"), BankCode: "BANK01"},
+		{CampaignID: 11, Hash: util.CampaignBankHash(11, "BANK02"), BankCode: "BANK02"},
+	}, campaignRepo.GetCampaignBanksCalls()[0].Keys)
+}
+
+func newCheckTestCampaignCustomer(campaignID int64, phone string) model.CampaignCustomer {
+	return model.CampaignCustomer{
+		CampaignID: campaignID,
+		Hash:       util.CampaignCustomerHash(campaignID, phone),
+		Phone:      phone,
+		Status:     model.CampaignCustomerStatusActive,
+	}
+}
+
+func TestService_Check__Private_Campaign_Customer_Eligibility(t *testing.T) {
+	phone := "0987000111"
+
+	customer := newCheckTestCampaignCustomer(11, phone)
+	otherCustomer := newCheckTestCampaignCustomer(11, "0987000222")
+	otherCampaignCustomer := newCheckTestCampaignCustomer(12, phone)
+
+	inactiveCustomer := customer
+	inactiveCustomer.Status = model.CampaignCustomerStatusInactive
+
+	notStartedCustomer := customer
+	notStartedCustomer.StartTime = newNullTime("2022-05-16T10:00:00+07:00")
+
+	endedCustomer := customer
+	endedCustomer.EndTime = newNullTime("2022-05-15T10:00:00+07:00")
+
+	table := []struct {
+		name         string
+		campaignType model.CampaignType
+		customers    []model.CampaignCustomer
+		err          error
+	}{
+		{
+			name:         "public-campaign-not-check-customer",
+			campaignType: model.CampaignTypeMerchant,
+		},
+		{
+			name:         "customer-enrolled",
+			campaignType: model.CampaignTypePrivate,
+			customers:    []model.CampaignCustomer{customer},
+		},
+		{
+			name:         "customer-not-enrolled",
+			campaignType: model.CampaignTypePrivate,
+			err:          ErrCustomerNotEligible,
+		},
+		{
+			name:         "other-customer-enrolled",
+			campaignType: model.CampaignTypePrivate,
+			customers:    []model.CampaignCustomer{otherCustomer},
+			err:          ErrCustomerNotEligible,
+		},
+		{
+			name:         "enrolled-in-other-campaign",
+			campaignType: model.CampaignTypePrivate,
+			customers:    []model.CampaignCustomer{otherCampaignCustomer},
+			err:          ErrCustomerNotEligible,
+		},
+		{
+			name:         "customer-inactive",
+			campaignType: model.CampaignTypePrivate,
+			customers:    []model.CampaignCustomer{inactiveCustomer},
+			err:          ErrCustomerNotEligible,
+		},
+		{
+			name:         "customer-not-started",
+			campaignType: model.CampaignTypePrivate,
+			customers:    []model.CampaignCustomer{notStartedCustomer},
+			err:          ErrCustomerNotEligible,
+		},
+		{
+			name:         "customer-end-at-req-time",
+			campaignType: model.CampaignTypePrivate,
+			customers:    []model.CampaignCustomer{endedCustomer},
+			err:          ErrCustomerNotEligible,
+		},
+	}
+
+	for _, e := range table {
+		t.Run(e.name, func(t *testing.T) {
+			campaign := newCheckTestCampaign()
+			campaign.Type = e.campaignType
+
+			campaignRepo := newCampaignRepoWithRows(campaignRows{
+				campaigns: []model.Campaign{campaign},
+				benefits:  []model.CampaignBenefit{newCheckTestBenefit()},
+				customers: e.customers,
+			})
+			s := newCheckTestService(NewDBRepoProvider(newEmptyBlacklistRepo(), campaignRepo))
+
+			outputs := s.Check(newContext(), []Input{newCheckTestInput()})
+			assert.Equal(t, 1, len(outputs))
+			assert.Equal(t, e.err, outputs[0].Err)
+			assert.Equal(t, int64(11), outputs[0].CampaignID)
+
+			if e.err != nil {
+				reason, ok := BusinessErrorReason(outputs[0].Err)
+				assert.Equal(t, true, ok)
+				assert.Equal(t, ReasonCustomerNotEligible, reason)
+				assert.Equal(t, "0", outputs[0].DiscountAmount.String())
+			} else {
+				assert.Equal(t, "10000", outputs[0].DiscountAmount.String())
+			}
+
+			if e.campaignType != model.CampaignTypePrivate {
+				assert.Equal(t, 0, len(campaignRepo.GetCampaignCustomersCalls()))
+				return
+			}
+
+			assert.Equal(t, 1, len(campaignRepo.GetCampaignCustomersCalls()))
+			assert.Equal(t, []repository.CampaignCustomerKey{
+				{
+					CampaignID: 11,
+					Hash:       util.CampaignCustomerHash(11, phone),
+					Phone:      phone,
+				},
+			}, campaignRepo.GetCampaignCustomersCalls()[0].Keys)
+		})
+	}
+}
+
+func TestService_Check__Private_Campaign__Select_Public_Campaign_When_Not_Enrolled(t *testing.T) {
+	private := newCheckTestCampaign()
+	private.Type = model.CampaignTypePrivate
+	private.EndTime = newTime("2022-05-18T10:00:00+07:00")
+
+	public := newCheckTestCampaign()
+	public.ID = 12
+
+	benefit2 := newCheckTestBenefit()
+	benefit2.CampaignID = 12
+
+	campaignRepo := newCampaignRepoWithRows(campaignRows{
+		campaigns: []model.Campaign{private, public},
+		benefits:  []model.CampaignBenefit{newCheckTestBenefit(), benefit2},
+		customers: []model.CampaignCustomer{newCheckTestCampaignCustomer(11, "0987000111")},
+	})
+	s := newCheckTestService(NewDBRepoProvider(newEmptyBlacklistRepo(), campaignRepo))
+
+	input1 := newCheckTestInput()
+	input2 := newCheckTestInput()
+	input2.Phone = "0987000222"
+
+	outputs := s.Check(newContext(), []Input{input1, input2})
+	assert.Equal(t, 2, len(outputs))
+
+	assert.Equal(t, nil, outputs[0].Err)
+	assert.Equal(t, int64(11), outputs[0].CampaignID)
+	assert.Equal(t, "10000", outputs[0].DiscountAmount.String())
+
+	assert.Equal(t, nil, outputs[1].Err)
+	assert.Equal(t, int64(12), outputs[1].CampaignID)
+	assert.Equal(t, "10000", outputs[1].DiscountAmount.String())
+
+	assert.Equal(t, 1, len(campaignRepo.GetCampaignCustomersCalls()))
+	assert.Equal(t, []repository.CampaignCustomerKey{
+		{CampaignID: 11, Hash: util.CampaignCustomerHash(11, "0987000111"), Phone: "0987000111"},
+		{CampaignID: 11, Hash: util.CampaignCustomerHash(11, "0987000222"), Phone: "0987000222"},
+	}, campaignRepo.GetCampaignCustomersCalls()[0].Keys)
+}
+
+func TestService_Check__All_Campaigns_Rejected__Returns_Reason_Of_Smallest_Campaign_ID(t *testing.T) {
+	notStarted := newCheckTestCampaign()
+	notStarted.ID = 12
+	notStarted.StartTime = newTime("2022-05-16T10:00:00+07:00")
+
+	bankCampaign := newCheckTestCampaign()
+	bankCampaign.Type = model.CampaignTypeBank
+
+	table := []struct {
+		name      string
+		campaigns []model.Campaign
+	}{
+		{name: "smallest-id-first", campaigns: []model.Campaign{bankCampaign, notStarted}},
+		{name: "smallest-id-last", campaigns: []model.Campaign{notStarted, bankCampaign}},
+	}
+
+	for _, e := range table {
+		t.Run(e.name, func(t *testing.T) {
+			campaignRepo := newCampaignRepoWithRows(campaignRows{
+				campaigns: e.campaigns,
+				benefits:  []model.CampaignBenefit{newCheckTestBenefit()},
+			})
+			s := newCheckTestService(NewDBRepoProvider(newEmptyBlacklistRepo(), campaignRepo))
+
+			input := newCheckTestInput()
+			input.BankCode = "BANK01"
+
+			outputs := s.Check(newContext(), []Input{input})
+			assert.Equal(t, []Output{
+				{CampaignID: 11, Err: ErrBankNotEligible},
+			}, outputs)
+
+			reason, ok := BusinessErrorReason(outputs[0].Err)
+			assert.Equal(t, true, ok)
+			assert.Equal(t, ReasonBankNotEligible, reason)
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package readonly
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const defaultMaxBatchSize = 500
+
+// Max lengths of input fields, same as the columns of database schema
+const (
+	defaultMaxPhoneLength    = 20
+	defaultMaxCodeLength     = 30
+	defaultMaxBankCodeLength = 20
+)
+
+// PhoneNormalizer converts a phone number to the form stored in database, returns error for invalid phones
+type PhoneNormalizer func(phone string) (string, error)
+
+// Validator checks the inputs before calling Service.Check,
+// an invalid input is rejected alone without failing the whole batch
+type Validator struct {
+	options validatorOptions
+}
+
+type validatorOptions struct {
+	maxBatchSize      int
+	maxPhoneLength    int
+	maxCodeLength     int
+	maxBankCodeLength int
+	normalizePhone    PhoneNormalizer
+}
+
+// ValidatorOption ...
+type ValidatorOption func(opts *validatorOptions)
+
+// WithMaxBatchSize sets the max number of inputs of a check request, zero means default
+func WithMaxBatchSize(n int) ValidatorOption {
+	return func(opts *validatorOptions) {
+		if n > 0 {
+			opts.maxBatchSize = n
+		}
+	}
+}
+
+// WithMaxPhoneLength sets the max length of phone after normalized, zero means default
+func WithMaxPhoneLength(n int) ValidatorOption {
+	return func(opts *validatorOptions) {
+		if n > 0 {
+			opts.maxPhoneLength = n
+		}
+	}
+}
+
+// WithMaxCodeLength sets the max length of voucher / merchant / terminal codes, zero means default
+func WithMaxCodeLength(n int) ValidatorOption {
+	return func(opts *validatorOptions) {
+		if n > 0 {
+			opts.maxCodeLength = n
+		}
+	}
+}
+
+// WithMaxBankCodeLength sets the max length of bank code, zero means default
+func WithMaxBankCodeLength(n int) ValidatorOption {
+	return func(opts *validatorOptions) {
+		if n > 0 {
+			opts.maxBankCodeLength = n
+		}
+	}
+}
+
+// WithPhoneNormalizer ...
+func WithPhoneNormalizer(fn PhoneNormalizer) ValidatorOption {
+	return func(opts *validatorOptions) {
+		opts.normalizePhone = fn
+	}
+}
+
+func defaultPhoneNormalizer(phone string) (string, error) {
+	return strings.TrimSpace(phone), nil
+}
+
+// NewValidator ...
+func NewValidator(options ...ValidatorOption) *Validator {
+	opts := validatorOptions{
+		maxBatchSize:      defaultMaxBatchSize,
+		maxPhoneLength:    defaultMaxPhoneLength,
+		maxCodeLength:     defaultMaxCodeLength,
+		maxBankCodeLength: defaultMaxBankCodeLength,
+		normalizePhone:    defaultPhoneNormalizer,
+	}
+	for _, fn := range options {
+		fn(&opts)
+	}
+	return &Validator{options: opts}
+}
+
+// ValidateBatchSize ...
+func (v *Validator) ValidateBatchSize(n int) error {
+	if n > v.options.maxBatchSize {
+		return fmt.Errorf("number of inputs %d exceeds the max batch size %d", n, v.options.maxBatchSize)
+	}
+	return nil
+}
+
+// ValidateInput returns the input with normalized phone
+func (v *Validator) ValidateInput(input Input) (Input, error) {
+	phone, err := v.options.normalizePhone(input.Phone)
+	if err != nil {
+		return Input{}, newInvalidArgumentError("invalid phone: %v", err)
+	}
+	input.Phone = phone
+
+	if err := validateRequiredField("voucher_code", input.VoucherCode, v.options.maxCodeLength); err != nil {
+		return Input{}, err
+	}
+	if err := validateRequiredField("merchant_code", input.MerchantCode, v.options.maxCodeLength); err != nil {
+		return Input{}, err
+	}
+	if err := validateRequiredField("phone", input.Phone, v.options.maxPhoneLength); err != nil {
+		return Input{}, err
+	}
+	if err := validateFieldLength("terminal_code", input.TerminalCode, v.options.maxCodeLength); err != nil {
+		return Input{}, err
+	}
+	if err := validateFieldLength("bank_code", input.BankCode, v.options.maxBankCodeLength); err != nil {
+		return Input{}, err
+	}
+
+	for _, field := range []struct {
+		name  string
+		value string
+	}{
+		{name: "phone", value: input.Phone},
+		{name: "merchant_code", value: input.MerchantCode},
+		{name: "terminal_code", value: input.TerminalCode},
+	} {
+		if err := validateKeyField(field.name, field.value); err != nil {
+			return Input{}, err
+		}
+	}
+	return input, nil
+}
+
+// validateKeyField for fields used in cache keys and usage term codes
+func validateKeyField(name string, value string) error {
+	if strings.IndexFunc(value, func(r rune) bool { return unicode.IsSpace(r) || r == ':' }) >= 0 {
+		return newInvalidArgumentError("%s must not contain spaces or ':'", name)
+	}
+	return nil
+}
+
+func validateRequiredField(name string, value string, maxLength int) error {
+	if value == "" {
+		return newInvalidArgumentError("%s is required", name)
+	}
+	return validateFieldLength(name, value, maxLength)
+}
+
+func validateFieldLength(name string, value string, maxLength int) error {
+	if utf8.RuneCountInString(value) > maxLength {
+		return newInvalidArgumentError("%s exceeds max length %d", name, maxLength)
+	}
+	return nil
+}
@@ -0,0 +1,241 @@
+package readonly
+
+import (
+	"context"
+	"errors"
+	"github.com/QuangTung97/promo-readonly/promopb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"strings"
+	"testing"
+)
+
+func newValidInput() Input {
+	return Input{
+		VoucherCode:  "VOUCHER01",
+		MerchantCode: "MERCHANT01",
+		TerminalCode: "TERMINAL01",
+		Phone:        "0987000111",
+		BankCode:     "BANK01",
+	}
+}
+
+func TestValidator_ValidateInput(t *testing.T) {
+	table := []struct {
+		name    string
+		update  func(input *Input)
+		message string
+	}{
+		{
+			name:    "valid",
+			update:  func(input *Input) {},
+			message: "",
+		},
+		{
+			name:    "empty-phone",
+			update:  func(input *Input) { input.Phone = "" },
+			message: "phone is required",
+		},
+		{
+			name:    "blank-phone",
+			update:  func(input *Input) { input.Phone = "   " },
+			message: "phone is required",
+		},
+		{
+			name:    "phone-too-long",
+			update:  func(input *Input) { input.Phone = strings.Repeat("1", 21) },
+			message: "phone exceeds max length 20",
+		},
+		{
+			name:    "empty-voucher-code",
+			update:  func(input *Input) { input.VoucherCode = "" },
+			message: "voucher_code is required",
+		},
+		{
+			name:    "empty-merchant-code",
+			update:  func(input *Input) { input.MerchantCode = "" },
+			message: "merchant_code is required",
+		},
+		{
+			name:    "merchant-code-too-long",
+			update:  func(input *Input) { input.MerchantCode = strings.Repeat("M", 500) },
+			message: "merchant_code exceeds max length 30",
+		},
+		{
+			name:    "empty-terminal-code",
+			update:  func(input *Input) { input.TerminalCode = "" },
+			message: "",
+		},
+		{
+			name:    "terminal-code-too-long",
+			update:  func(input *Input) { input.TerminalCode = strings.Repeat("T", 31) },
+			message: "terminal_code exceeds max length 30",
+		},
+		{
+			name:    "phone-with-space-inside",
+			update:  func(input *Input) { input.Phone = "0987 000111" },
+			message: "phone must not contain spaces or ':'",
+		},
+		{
+			name:    "phone-with-colon",
+			update:  func(input *Input) { input.Phone = "0987:000111" },
+			message: "phone must not contain spaces or ':'",
+		},
+		{
+			name:    "phone-with-surrounding-spaces",
+			update:  func(input *Input) { input.Phone = " 0987000111 " },
+			message: "",
+		},
+		{
+			name:    "merchant-code-with-space-inside",
+			update:  func(input *Input) { input.MerchantCode = "MERCHANT 01" },
+			message: "merchant_code must not contain spaces or ':'",
+		},
+		{
+			name:    "merchant-code-with-colon",
+			update:  func(input *Input) { input.MerchantCode = "MERCHANT:01" },
+			message: "merchant_code must not contain spaces or ':'",
+		},
+		{
+			name:    "terminal-code-with-tab",
+			update:  func(input *Input) { input.TerminalCode = "TERMINAL\t01" },
+			message: "terminal_code must not contain spaces or ':'",
+		},
+		{
+			name:    "terminal-code-with-colon",
+			update:  func(input *Input) { input.TerminalCode = "TERMINAL:01" },
+			message: "terminal_code must not contain spaces or ':'",
+		},
+		{
+			name:    "bank-code-too-long",
+			update:  func(input *Input) { input.BankCode = strings.Repeat("B", 21) },
+			message: "bank_code exceeds max length 20",
+		},
+	}
+	for _, e := range table {
+		t.Run(e.name, func(t *testing.T) {
+			input := newValidInput()
+			e.update(&input)
+
+			_, err := NewValidator().ValidateInput(input)
+			if e.message == "" {
+				assert.Equal(t, nil, err)
+				return
+			}
+
+			reason, ok := BusinessErrorReason(err)
+			assert.Equal(t, true, ok)
+			assert.Equal(t, ReasonInvalidArgument, reason)
+			assert.Equal(t, e.message, err.Error())
+		})
+	}
+}
+
+func TestValidator_ValidateInput__Phone_Normalizer(t *testing.T) {
+	v := NewValidator(WithPhoneNormalizer(func(phone string) (string, error) {
+		if strings.HasPrefix(phone, "+84") {
+			return "0" + strings.TrimPrefix(phone, "+84"), nil
+		}
+		if !strings.HasPrefix(phone, "0") {
+			return "", errors.New("unknown country code")
+		}
+		return phone, nil
+	}))
+
+	input := newValidInput()
+	input.Phone = "+84987000111"
+	result, err := v.ValidateInput(input)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "0987000111", result.Phone)
+
+	input.Phone = "+1987000111"
+	_, err = v.ValidateInput(input)
+	assert.Equal(t, "invalid phone: unknown country code", err.Error())
+}
+
+func TestValidator_ValidateInput__Max_Field_Lengths(t *testing.T) {
+	v := NewValidator(
+		WithMaxPhoneLength(12),
+		WithMaxCodeLength(10),
+		WithMaxBankCodeLength(6),
+	)
+
+	input := newValidInput()
+	input.Phone = strings.Repeat("1", 12)
+	input.MerchantCode = strings.Repeat("M", 10)
+	input.BankCode = strings.Repeat("B", 6)
+	_, err := v.ValidateInput(input)
+	assert.Equal(t, nil, err)
+
+	input = newValidInput()
+	input.Phone = strings.Repeat("1", 13)
+	_, err = v.ValidateInput(input)
+	assert.Equal(t, "phone exceeds max length 12", err.Error())
+
+	input = newValidInput()
+	input.TerminalCode = strings.Repeat("T", 11)
+	_, err = v.ValidateInput(input)
+	assert.Equal(t, "terminal_code exceeds max length 10", err.Error())
+
+	input = newValidInput()
+	input.BankCode = strings.Repeat("B", 7)
+	_, err = v.ValidateInput(input)
+	assert.Equal(t, "bank_code exceeds max length 6", err.Error())
+
+	v = NewValidator(WithMaxPhoneLength(0), WithMaxCodeLength(0), WithMaxBankCodeLength(0))
+	input = newValidInput()
+	input.BankCode = strings.Repeat("B", defaultMaxBankCodeLength)
+	_, err = v.ValidateInput(input)
+	assert.Equal(t, nil, err)
+}
+
+func TestValidator_ValidateBatchSize(t *testing.T) {
+	v := NewValidator(WithMaxBatchSize(2))
+	assert.Equal(t, nil, v.ValidateBatchSize(2))
+	assert.Equal(t, "number of inputs 3 exceeds the max batch size 2", v.ValidateBatchSize(3).Error())
+
+	v = NewValidator(WithMaxBatchSize(0))
+	assert.Equal(t, nil, v.ValidateBatchSize(defaultMaxBatchSize))
+}
+
+func TestServer_Check__Invalid_Inputs(t *testing.T) {
+	var serviceInputs []Input
+	s := &Server{
+		service: serviceFunc(func(ctx context.Context, inputs []Input) []Output {
+			serviceInputs = inputs
+			return []Output{{CampaignID: 11, DiscountAmount: newDecimal("1000")}}
+		}),
+		options: newServerOptions(WithValidator(NewValidator(WithMaxBatchSize(2)))),
+	}
+
+	resp, err := s.Check(newContext(), &promopb.PromoServiceCheckRequest{
+		Inputs: []*promopb.PromoServiceCheckInput{
+			{VoucherCode: "VOUCHER01", MerchantCode: strings.Repeat("M", 500), Phone: "0987000111"},
+			{VoucherCode: "VOUCHER01", MerchantCode: "MERCHANT01", Phone: " 0987000111 "},
+		},
+	})
+	assert.Equal(t, nil, err)
+
+	assert.Equal(t, 1, len(serviceInputs))
+	assert.Equal(t, "0987000111", serviceInputs[0].Phone)
+
+	assert.Equal(t, []*promopb.PromoServiceCheckOutput{
+		{
+			DiscountAmount: "0",
+			Reason:         promopb.CheckReason_INVALID_ARGUMENT,
+			Message:        "merchant_code exceeds max length 30",
+		},
+		{
+			DiscountAmount: "1000",
+			CampaignId:     11,
+			Reason:         promopb.CheckReason_OK,
+		},
+	}, resp.Outputs)
+
+	resp, err = s.Check(newContext(), &promopb.PromoServiceCheckRequest{
+		Inputs: make([]*promopb.PromoServiceCheckInput, 3),
+	})
+	assert.Nil(t, resp)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}